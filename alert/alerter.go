@@ -0,0 +1,70 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package alert
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type Sender interface {
+	Send(alert *Alert) error
+}
+
+// Alerter sends alerts to all configured webhooks once when they start
+// firing and once when they are resolved
+type Alerter struct {
+	senders []Sender
+
+	firing map[string]bool
+	lock   sync.Mutex
+}
+
+func NewAlerter(senders []Sender) *Alerter {
+	return &Alerter{
+		senders: senders,
+		firing:  make(map[string]bool),
+	}
+}
+
+// Fire sends the alert if it is not already firing
+func (a *Alerter) Fire(alert *Alert) {
+	a.lock.Lock()
+	if a.firing[alert.Key] {
+		a.lock.Unlock()
+		return
+	}
+	a.firing[alert.Key] = true
+	a.lock.Unlock()
+
+	log.Error().Uint8("domainID", alert.DestinationDomain).Str("alert", alert.Name).Msg(alert.Message)
+	a.send(alert)
+}
+
+// Resolve sends the resolved alert if it was firing
+func (a *Alerter) Resolve(alert *Alert) {
+	a.lock.Lock()
+	if !a.firing[alert.Key] {
+		a.lock.Unlock()
+		return
+	}
+	delete(a.firing, alert.Key)
+	a.lock.Unlock()
+
+	alert.Resolved = true
+	log.Info().Uint8("domainID", alert.DestinationDomain).Str("alert", alert.Name).Msg(alert.Message)
+	a.send(alert)
+}
+
+func (a *Alerter) send(alert *Alert) {
+	alert.Time = time.Now()
+	for _, sender := range a.senders {
+		err := sender.Send(alert)
+		if err != nil {
+			log.Warn().Err(err).Str("alert", alert.Name).Msgf("Unable to send alert")
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package alert_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/alert"
+)
+
+type AlerterTestSuite struct {
+	suite.Suite
+
+	server   *httptest.Server
+	requests []map[string]interface{}
+	alerter  *alert.Alerter
+}
+
+func TestRunAlerterTestSuite(t *testing.T) {
+	suite.Run(t, new(AlerterTestSuite))
+}
+
+func (s *AlerterTestSuite) SetupTest() {
+	s.requests = make([]map[string]interface{}, 0)
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var request map[string]interface{}
+		_ = json.Unmarshal(body, &request)
+		s.requests = append(s.requests, request)
+	}))
+	s.alerter = alert.NewAlerter([]alert.Sender{
+		alert.NewWebhook(s.server.URL, alert.PagerDutyFormat, "key"),
+	})
+}
+
+func (s *AlerterTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *AlerterTestSuite) Test_Fire_SendsOnce() {
+	s.alerter.Fire(&alert.Alert{Key: "lag:1:2", Name: "lag"})
+	s.alerter.Fire(&alert.Alert{Key: "lag:1:2", Name: "lag"})
+
+	s.Equal(len(s.requests), 1)
+	s.Equal(s.requests[0]["event_action"], "trigger")
+	s.Equal(s.requests[0]["dedup_key"], "lag:1:2")
+	s.Equal(s.requests[0]["routing_key"], "key")
+}
+
+func (s *AlerterTestSuite) Test_Resolve_NotFiring() {
+	s.alerter.Resolve(&alert.Alert{Key: "lag:1:2", Name: "lag"})
+
+	s.Equal(len(s.requests), 0)
+}
+
+func (s *AlerterTestSuite) Test_Resolve_Firing() {
+	s.alerter.Fire(&alert.Alert{Key: "lag:1:2", Name: "lag"})
+	s.alerter.Resolve(&alert.Alert{Key: "lag:1:2", Name: "lag"})
+
+	s.Equal(len(s.requests), 2)
+	s.Equal(s.requests[1]["event_action"], "resolve")
+}
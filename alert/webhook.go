@@ -0,0 +1,104 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package alert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type WebhookFormat string
+
+const (
+	JSONFormat      WebhookFormat = "json"
+	SlackFormat     WebhookFormat = "slack"
+	PagerDutyFormat WebhookFormat = "pagerduty"
+)
+
+// Alert is a breached or resolved monitoring threshold
+type Alert struct {
+	Key               string    `json:"key"`
+	Name              string    `json:"name"`
+	Severity          string    `json:"severity"`
+	SourceDomain      uint8     `json:"sourceDomainID"`
+	DestinationDomain uint8     `json:"destinationDomainID"`
+	Message           string    `json:"message"`
+	Resolved          bool      `json:"resolved"`
+	Time              time.Time `json:"time"`
+}
+
+// Webhook posts alerts to the URL in the generic JSON, Slack or PagerDuty Events v2 format
+type Webhook struct {
+	url        string
+	format     WebhookFormat
+	routingKey string
+	client     *http.Client
+}
+
+func NewWebhook(url string, format WebhookFormat, routingKey string) *Webhook {
+	return &Webhook{
+		url:        url,
+		format:     format,
+		routingKey: routingKey,
+		client:     &http.Client{Timeout: time.Second * 10},
+	}
+}
+
+// Send posts the alert to the webhook URL
+func (w *Webhook) Send(alert *Alert) error {
+	payload, err := w.payload(alert)
+	if err != nil {
+		return err
+	}
+
+	resp, err := w.client.Post(w.url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s webhook responded with status %d", w.format, resp.StatusCode)
+	}
+	return nil
+}
+
+func (w *Webhook) payload(alert *Alert) ([]byte, error) {
+	switch w.format {
+	case SlackFormat:
+		status := "FIRING"
+		if alert.Resolved {
+			status = "RESOLVED"
+		}
+		return json.Marshal(map[string]string{
+			"text": fmt.Sprintf("[%s] %s: %s", status, alert.Name, alert.Message),
+		})
+	case PagerDutyFormat:
+		action := "trigger"
+		if alert.Resolved {
+			action = "resolve"
+		}
+		return json.Marshal(map[string]interface{}{
+			"routing_key":  w.routingKey,
+			"event_action": action,
+			"dedup_key":    alert.Key,
+			"payload": map[string]interface{}{
+				"summary":   alert.Message,
+				"source":    fmt.Sprintf("spectre-node domain %d", alert.DestinationDomain),
+				"severity":  alert.Severity,
+				"timestamp": alert.Time.Format(time.RFC3339),
+				"custom_details": map[string]interface{}{
+					"name":                alert.Name,
+					"sourceDomainID":      alert.SourceDomain,
+					"destinationDomainID": alert.DestinationDomain,
+				},
+			},
+		})
+	default:
+		return json.Marshal(alert)
+	}
+}
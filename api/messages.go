@@ -0,0 +1,112 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/spectre-node/store"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+type MessageFetcher interface {
+	Message(sourceDomainID uint8, messageType store.MessageType, id string) (*store.Message, error)
+	MessagesByTx(sourceDomainID uint8, txHash string) ([]*store.Message, error)
+}
+
+// MessageHandler serves the delivery status of messages of a source domain.
+// Messages are queried by Hashi message ID, deposit destination and nonce or
+// the source transaction hash:
+//
+//	/messages?source=1&id=123
+//	/messages?source=1&destination=2&nonce=7
+//	/messages?source=1&tx=0x...
+type MessageHandler struct {
+	messageFetcher MessageFetcher
+}
+
+func NewMessageHandler(messageFetcher MessageFetcher) *MessageHandler {
+	return &MessageHandler{
+		messageFetcher: messageFetcher,
+	}
+}
+
+func (h *MessageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	messages, err := h.messages(r)
+	if err != nil {
+		var queryErr *queryError
+		switch {
+		case errors.As(err, &queryErr):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, leveldb.ErrNotFound):
+			http.Error(w, "message not found", http.StatusNotFound)
+		default:
+			log.Error().Err(err).Msgf("Unable to fetch messages for query %s", r.URL.RawQuery)
+			http.Error(w, "internal error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(messages)
+}
+
+func (h *MessageHandler) messages(r *http.Request) ([]*store.Message, error) {
+	query := r.URL.Query()
+	source, err := parseDomain(query.Get("source"))
+	if err != nil {
+		return nil, &queryError{fmt.Sprintf("invalid source domain: %s", err)}
+	}
+
+	switch {
+	case query.Get("id") != "":
+		message, err := h.messageFetcher.Message(source, store.HashiMessageType, query.Get("id"))
+		if err != nil {
+			return nil, err
+		}
+		return []*store.Message{message}, nil
+	case query.Get("nonce") != "":
+		destination, err := parseDomain(query.Get("destination"))
+		if err != nil {
+			return nil, &queryError{fmt.Sprintf("invalid destination domain: %s", err)}
+		}
+		nonce, err := strconv.ParseUint(query.Get("nonce"), 10, 64)
+		if err != nil {
+			return nil, &queryError{fmt.Sprintf("invalid nonce: %s", err)}
+		}
+
+		message, err := h.messageFetcher.Message(source, store.DepositMessageType, store.DepositID(destination, nonce))
+		if err != nil {
+			return nil, err
+		}
+		return []*store.Message{message}, nil
+	case query.Get("tx") != "":
+		return h.messageFetcher.MessagesByTx(source, query.Get("tx"))
+	default:
+		return nil, &queryError{"one of id, nonce or tx is required"}
+	}
+}
+
+func parseDomain(domain string) (uint8, error) {
+	id, err := strconv.ParseUint(domain, 10, 8)
+	return uint8(id), err
+}
+
+type queryError struct {
+	msg string
+}
+
+func (e *queryError) Error() string {
+	return e.msg
+}
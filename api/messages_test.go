@@ -0,0 +1,110 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/api"
+	"github.com/sygmaprotocol/spectre-node/mock"
+	"github.com/sygmaprotocol/spectre-node/store"
+	"github.com/syndtr/goleveldb/leveldb"
+	"go.uber.org/mock/gomock"
+)
+
+type MessageHandlerTestSuite struct {
+	suite.Suite
+
+	mockMessageFetcher *mock.MockMessageFetcher
+	handler            *api.MessageHandler
+}
+
+func TestRunMessageHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(MessageHandlerTestSuite))
+}
+
+func (s *MessageHandlerTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockMessageFetcher = mock.NewMockMessageFetcher(ctrl)
+	s.handler = api.NewMessageHandler(s.mockMessageFetcher)
+}
+
+func (s *MessageHandlerTestSuite) get(query string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/messages?"+query, nil))
+	return w
+}
+
+func (s *MessageHandlerTestSuite) messages(w *httptest.ResponseRecorder) []*store.Message {
+	var messages []*store.Message
+	err := json.Unmarshal(w.Body.Bytes(), &messages)
+	s.Nil(err)
+	return messages
+}
+
+func (s *MessageHandlerTestSuite) Test_InvalidSource() {
+	w := s.get("source=invalid&id=5")
+
+	s.Equal(w.Code, http.StatusBadRequest)
+}
+
+func (s *MessageHandlerTestSuite) Test_MissingQuery() {
+	w := s.get("source=1")
+
+	s.Equal(w.Code, http.StatusBadRequest)
+}
+
+func (s *MessageHandlerTestSuite) Test_NotFound() {
+	s.mockMessageFetcher.EXPECT().Message(uint8(1), store.HashiMessageType, "5").Return(nil, leveldb.ErrNotFound)
+
+	w := s.get("source=1&id=5")
+
+	s.Equal(w.Code, http.StatusNotFound)
+}
+
+func (s *MessageHandlerTestSuite) Test_FetchFails() {
+	s.mockMessageFetcher.EXPECT().MessagesByTx(uint8(1), "0xab").Return(nil, fmt.Errorf("error"))
+
+	w := s.get("source=1&tx=0xab")
+
+	s.Equal(w.Code, http.StatusInternalServerError)
+}
+
+func (s *MessageHandlerTestSuite) Test_MessageByID() {
+	s.mockMessageFetcher.EXPECT().Message(uint8(1), store.HashiMessageType, "5").Return(&store.Message{ID: "5"}, nil)
+
+	w := s.get("source=1&id=5")
+
+	s.Equal(w.Code, http.StatusOK)
+	s.Equal(s.messages(w)[0].ID, "5")
+}
+
+func (s *MessageHandlerTestSuite) Test_DepositByNonce() {
+	s.mockMessageFetcher.EXPECT().Message(uint8(1), store.DepositMessageType, store.DepositID(2, 7)).Return(&store.Message{Nonce: 7}, nil)
+
+	w := s.get("source=1&destination=2&nonce=7")
+
+	s.Equal(w.Code, http.StatusOK)
+	s.Equal(s.messages(w)[0].Nonce, uint64(7))
+}
+
+func (s *MessageHandlerTestSuite) Test_DepositByNonce_MissingDestination() {
+	w := s.get("source=1&nonce=7")
+
+	s.Equal(w.Code, http.StatusBadRequest)
+}
+
+func (s *MessageHandlerTestSuite) Test_MessagesByTx() {
+	s.mockMessageFetcher.EXPECT().MessagesByTx(uint8(1), "0xab").Return([]*store.Message{{ID: "5"}, {ID: "6"}}, nil)
+
+	w := s.get("source=1&tx=0xab")
+
+	s.Equal(w.Code, http.StatusOK)
+	s.Len(s.messages(w), 2)
+}
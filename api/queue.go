@@ -0,0 +1,83 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/spectre-node/store"
+)
+
+type QueueFetcher interface {
+	Pending(destinationDomainID uint8) ([]*store.QueuedMessage, error)
+	DeadLetters(destinationDomainID uint8) ([]*store.QueuedMessage, error)
+}
+
+// Queue is the outbound queue of a destination domain. Message data is
+// omitted as it holds full proofs.
+type Queue struct {
+	Depth       int
+	Pending     []*store.QueuedMessage
+	DeadLetters []*store.QueuedMessage
+}
+
+// QueueHandler serves the messages waiting to be delivered to a destination
+// domain and the messages dropped after exhausting their attempts:
+//
+//	/queue?destination=2
+type QueueHandler struct {
+	queueFetcher QueueFetcher
+}
+
+func NewQueueHandler(queueFetcher QueueFetcher) *QueueHandler {
+	return &QueueHandler{
+		queueFetcher: queueFetcher,
+	}
+}
+
+func (h *QueueHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	destination, err := parseDomain(r.URL.Query().Get("destination"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid destination domain: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	queue, err := h.queue(destination)
+	if err != nil {
+		log.Error().Err(err).Msgf("Unable to fetch queue of domain %d", destination)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(queue)
+}
+
+func (h *QueueHandler) queue(destination uint8) (*Queue, error) {
+	pending, err := h.queueFetcher.Pending(destination)
+	if err != nil {
+		return nil, err
+	}
+	deadLetters, err := h.queueFetcher.DeadLetters(destination)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, msg := range append(pending, deadLetters...) {
+		msg.Data = nil
+	}
+	return &Queue{
+		Depth:       len(pending),
+		Pending:     pending,
+		DeadLetters: deadLetters,
+	}, nil
+}
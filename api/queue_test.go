@@ -0,0 +1,77 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/api"
+	"github.com/sygmaprotocol/spectre-node/mock"
+	"github.com/sygmaprotocol/spectre-node/store"
+	"go.uber.org/mock/gomock"
+)
+
+type QueueHandlerTestSuite struct {
+	suite.Suite
+
+	mockQueueFetcher *mock.MockQueueFetcher
+	handler          *api.QueueHandler
+}
+
+func TestRunQueueHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(QueueHandlerTestSuite))
+}
+
+func (s *QueueHandlerTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockQueueFetcher = mock.NewMockQueueFetcher(ctrl)
+	s.handler = api.NewQueueHandler(s.mockQueueFetcher)
+}
+
+func (s *QueueHandlerTestSuite) get(query string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/queue?"+query, nil))
+	return w
+}
+
+func (s *QueueHandlerTestSuite) Test_InvalidDestination() {
+	w := s.get("destination=invalid")
+
+	s.Equal(w.Code, http.StatusBadRequest)
+}
+
+func (s *QueueHandlerTestSuite) Test_FetchingFails() {
+	s.mockQueueFetcher.EXPECT().Pending(uint8(2)).Return(nil, fmt.Errorf("error"))
+
+	w := s.get("destination=2")
+
+	s.Equal(w.Code, http.StatusInternalServerError)
+}
+
+func (s *QueueHandlerTestSuite) Test_ValidQuery_DataOmitted() {
+	s.mockQueueFetcher.EXPECT().Pending(uint8(2)).Return([]*store.QueuedMessage{
+		{Seq: 4, Destination: 2, Data: []byte(`{"Proof":"AQ=="}`)},
+		{Seq: 5, Destination: 2, Data: []byte(`{"Proof":"Ag=="}`)},
+	}, nil)
+	s.mockQueueFetcher.EXPECT().DeadLetters(uint8(2)).Return([]*store.QueuedMessage{
+		{Seq: 1, Destination: 2, Data: []byte(`{}`), LastError: "error"},
+	}, nil)
+
+	w := s.get("destination=2")
+
+	s.Equal(w.Code, http.StatusOK)
+	queue := &api.Queue{}
+	err := json.Unmarshal(w.Body.Bytes(), queue)
+	s.Nil(err)
+	s.Equal(queue.Depth, 2)
+	s.Equal(queue.Pending[1].Seq, uint64(5))
+	s.Nil(queue.Pending[0].Data)
+	s.Equal(queue.DeadLetters[0].LastError, "error")
+	s.Nil(queue.DeadLetters[0].Data)
+}
@@ -0,0 +1,88 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package abi
+
+const ExecutionReceiverABI = `
+[
+	{
+		"inputs": [
+			{
+				"internalType": "uint8",
+				"name": "sourceDomainID",
+				"type": "uint8"
+			},
+			{
+				"internalType": "uint256",
+				"name": "slot",
+				"type": "uint256"
+			},
+			{
+				"components": [
+					{
+						"internalType": "string",
+						"name": "name",
+						"type": "string"
+					},
+					{
+						"internalType": "bytes32",
+						"name": "value",
+						"type": "bytes32"
+					},
+					{
+						"internalType": "bytes[]",
+						"name": "proof",
+						"type": "bytes[]"
+					}
+				],
+				"internalType": "struct ExecutionField[]",
+				"name": "fields",
+				"type": "tuple[]"
+			},
+			{
+				"components": [
+					{
+						"internalType": "address",
+						"name": "account",
+						"type": "address"
+					},
+					{
+						"internalType": "bytes[]",
+						"name": "proof",
+						"type": "bytes[]"
+					},
+					{
+						"components": [
+							{
+								"internalType": "bytes32",
+								"name": "key",
+								"type": "bytes32"
+							},
+							{
+								"internalType": "bytes32",
+								"name": "value",
+								"type": "bytes32"
+							},
+							{
+								"internalType": "bytes[]",
+								"name": "proof",
+								"type": "bytes[]"
+							}
+						],
+						"internalType": "struct StorageProof[]",
+						"name": "storageProofs",
+						"type": "tuple[]"
+					}
+				],
+				"internalType": "struct AccountProof[]",
+				"name": "accounts",
+				"type": "tuple[]"
+			}
+		],
+		"name": "receiveExecutionProofs",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	}
+]
+`
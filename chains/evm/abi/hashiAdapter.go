@@ -0,0 +1,47 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package abi
+
+const HashiAdapterABI = `
+[
+	{
+		"inputs": [
+			{
+				"internalType": "uint8",
+				"name": "sourceDomainID",
+				"type": "uint8"
+			},
+			{
+				"internalType": "uint256",
+				"name": "slot",
+				"type": "uint256"
+			},
+			{
+				"internalType": "uint256",
+				"name": "blockNumber",
+				"type": "uint256"
+			},
+			{
+				"internalType": "bytes[]",
+				"name": "blockNumberProof",
+				"type": "bytes[]"
+			},
+			{
+				"internalType": "bytes32",
+				"name": "blockHash",
+				"type": "bytes32"
+			},
+			{
+				"internalType": "bytes[]",
+				"name": "blockHashProof",
+				"type": "bytes[]"
+			}
+		],
+		"name": "storeBlockHeader",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	}
+]
+`
@@ -0,0 +1,142 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package beacon
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	FINALIZED_CHECKPOINT_TOPIC         = "finalized_checkpoint"
+	LIGHT_CLIENT_FINALITY_UPDATE_TOPIC = "light_client_finality_update"
+
+	// Maximum size of a single event data line
+	MAX_EVENT_SIZE = 1024 * 1024
+)
+
+// Event is a single event received from the beacon node events stream
+type Event struct {
+	Topic string
+	Data  []byte
+}
+
+// EventStream subscribes to the beacon node Server-Sent Events stream
+// and reconnects when the stream is interrupted or stays silent for longer
+// than the idle timeout, as a half-open connection never returns an error
+type EventStream struct {
+	url               string
+	client            *http.Client
+	reconnectInterval time.Duration
+	idleTimeout       time.Duration
+
+	connected atomic.Bool
+}
+
+func NewEventStream(endpoint string, topics []string, reconnectInterval time.Duration, idleTimeout time.Duration) *EventStream {
+	return &EventStream{
+		url:               fmt.Sprintf("%s/eth/v1/events?topics=%s", strings.TrimSuffix(endpoint, "/"), strings.Join(topics, ",")),
+		client:            &http.Client{},
+		reconnectInterval: reconnectInterval,
+		idleTimeout:       idleTimeout,
+	}
+}
+
+// Subscribe sends received events to the channel until the context is done.
+// Events are dropped if the channel is full, so the channel should be used
+// as a notification that new events are available.
+func (s *EventStream) Subscribe(ctx context.Context, events chan<- *Event) {
+	for {
+		err := s.stream(ctx, events)
+		s.connected.Store(false)
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Warn().Err(err).Msgf("Beacon event stream interrupted, reconnecting in %s", s.reconnectInterval)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.reconnectInterval):
+		}
+	}
+}
+
+// Connected returns true while the event stream is open
+func (s *EventStream) Connected() bool {
+	return s.connected.Load()
+}
+
+func (s *EventStream) stream(ctx context.Context, events chan<- *Event) error {
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	idleTimer := time.AfterFunc(s.idleTimeout, cancel)
+	defer idleTimer.Stop()
+
+	req, err := http.NewRequestWithContext(streamCtx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected event stream status %s", resp.Status)
+	}
+
+	s.connected.Store(true)
+	log.Debug().Msgf("Subscribed to beacon event stream")
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), MAX_EVENT_SIZE)
+	event := &Event{}
+	for scanner.Scan() {
+		// any line, including keepalive comments, shows the connection is alive
+		idleTimer.Reset(s.idleTimeout)
+		line := scanner.Text()
+		if line == "" {
+			if event.Topic != "" {
+				select {
+				case events <- event:
+				default:
+				}
+			}
+			event = &Event{}
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event.Topic = value
+		case "data":
+			if len(event.Data) > 0 {
+				event.Data = append(event.Data, '\n')
+			}
+			event.Data = append(event.Data, value...)
+		}
+	}
+	if streamCtx.Err() != nil && ctx.Err() == nil {
+		return fmt.Errorf("no events received for %s", s.idleTimeout)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("event stream closed")
+}
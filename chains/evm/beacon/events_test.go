@@ -0,0 +1,117 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package beacon_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/beacon"
+)
+
+type EventStreamTestSuite struct {
+	suite.Suite
+
+	beaconNode  *httptest.Server
+	connections atomic.Int32
+	query       atomic.Value
+}
+
+func TestRunEventStreamTestSuite(t *testing.T) {
+	suite.Run(t, new(EventStreamTestSuite))
+}
+
+func (s *EventStreamTestSuite) SetupTest() {
+	s.connections.Store(0)
+	s.beaconNode = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/events" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		s.connections.Add(1)
+		s.query.Store(r.URL.RawQuery)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprint(w, ": keepalive\n\n")
+		_, _ = fmt.Fprint(w, "event: finalized_checkpoint\ndata: {\"epoch\":\"1\"}\n\n")
+		_, _ = fmt.Fprint(w, "event: light_client_finality_update\ndata: {\"version\":\"deneb\",\ndata: \"data\":{}}\n\n")
+	}))
+}
+
+func (s *EventStreamTestSuite) TearDownTest() {
+	s.beaconNode.Close()
+}
+
+func (s *EventStreamTestSuite) Test_Subscribe_ReceivesEventsAndReconnects() {
+	eventStream := beacon.NewEventStream(
+		s.beaconNode.URL,
+		[]string{beacon.FINALIZED_CHECKPOINT_TOPIC, beacon.LIGHT_CLIENT_FINALITY_UPDATE_TOPIC},
+		time.Millisecond*10,
+		time.Minute,
+	)
+	events := make(chan *beacon.Event, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go eventStream.Subscribe(ctx, events)
+
+	s.Equal(<-events, &beacon.Event{
+		Topic: beacon.FINALIZED_CHECKPOINT_TOPIC,
+		Data:  []byte(`{"epoch":"1"}`),
+	})
+	s.Equal(<-events, &beacon.Event{
+		Topic: beacon.LIGHT_CLIENT_FINALITY_UPDATE_TOPIC,
+		Data:  []byte("{\"version\":\"deneb\",\n\"data\":{}}"),
+	})
+	// the server closes the stream after the events
+	s.Eventually(func() bool {
+		return s.connections.Load() > 1
+	}, time.Second, time.Millisecond*5)
+	s.Equal(s.query.Load(), "topics=finalized_checkpoint,light_client_finality_update")
+}
+
+func (s *EventStreamTestSuite) Test_Subscribe_UnavailableStream() {
+	eventStream := beacon.NewEventStream(s.beaconNode.URL+"/invalid", []string{beacon.FINALIZED_CHECKPOINT_TOPIC}, time.Millisecond*10, time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go eventStream.Subscribe(ctx, make(chan *beacon.Event, 1))
+	time.Sleep(time.Millisecond * 30)
+	cancel()
+
+	s.False(eventStream.Connected())
+}
+
+func (s *EventStreamTestSuite) Test_Subscribe_IdleStreamReconnects() {
+	var connections atomic.Int32
+	idleNode := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		connections.Add(1)
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprint(w, ": keepalive\n\n")
+		w.(http.Flusher).Flush()
+		// the connection stays open without sending anything
+		<-r.Context().Done()
+	}))
+	defer idleNode.Close()
+	eventStream := beacon.NewEventStream(idleNode.URL, []string{beacon.FINALIZED_CHECKPOINT_TOPIC}, time.Millisecond*100, time.Millisecond*50)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go eventStream.Subscribe(ctx, make(chan *beacon.Event, 1))
+
+	s.Eventually(func() bool {
+		return eventStream.Connected()
+	}, time.Second, time.Millisecond*5)
+	s.Eventually(func() bool {
+		return !eventStream.Connected()
+	}, time.Second, time.Millisecond*5)
+	s.Eventually(func() bool {
+		return connections.Load() > 1
+	}, time.Second, time.Millisecond*5)
+}
@@ -0,0 +1,139 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package beacon
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Recorded response headers required by the light client and the attestant client
+var recordedHeaders = []string{"Content-Type", "Eth-Consensus-Version"}
+
+// Recording is a single beacon API request and the response the node returned
+type Recording struct {
+	Method     string            `json:"method"`
+	Path       string            `json:"path"`
+	StatusCode int               `json:"statusCode"`
+	Headers    map[string]string `json:"headers"`
+	Body       []byte            `json:"body"`
+	Time       time.Time         `json:"time"`
+}
+
+// Key identifies recordings of the same beacon API request
+func (r *Recording) Key() string {
+	return fmt.Sprintf("%s %s", r.Method, r.Path)
+}
+
+// Recorder proxies beacon API requests to the beacon node and writes every
+// request and response to the recordings directory
+type Recorder struct {
+	target string
+	path   string
+	client *http.Client
+
+	sequence uint64
+	lock     sync.Mutex
+}
+
+func NewRecorder(target string, path string) (*Recorder, error) {
+	err := os.MkdirAll(path, 0755)
+	if err != nil {
+		return nil, err
+	}
+	// continue the sequence of an existing recording
+	files, err := recordingFiles(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Recorder{
+		target:   strings.TrimSuffix(target, "/"),
+		path:     path,
+		client:   &http.Client{Timeout: time.Minute},
+		sequence: uint64(len(files)),
+	}, nil
+}
+
+// ServeHTTP forwards the request to the beacon node and records the response
+func (r *Recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	upstreamReq, err := http.NewRequestWithContext(req.Context(), req.Method, r.target+req.URL.RequestURI(), req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	upstreamReq.Header = req.Header.Clone()
+
+	resp, err := r.client.Do(upstreamReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	recording := &Recording{
+		Method:     req.Method,
+		Path:       req.URL.RequestURI(),
+		StatusCode: resp.StatusCode,
+		Headers:    make(map[string]string),
+		Body:       body,
+		Time:       time.Now(),
+	}
+	for _, header := range recordedHeaders {
+		if value := resp.Header.Get(header); value != "" {
+			recording.Headers[header] = value
+		}
+	}
+	err = r.store(recording)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Unable to record beacon response for %s", recording.Key())
+	}
+
+	writeRecording(w, recording)
+}
+
+func (r *Recorder) store(recording *Recording) error {
+	data, err := json.MarshalIndent(recording, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.sequence++
+	return os.WriteFile(filepath.Join(r.path, fmt.Sprintf("%08d.json", r.sequence)), data, 0600)
+}
+
+// recordingFiles returns the recording files in the directory in the order they were recorded
+func recordingFiles(path string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(path, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func writeRecording(w http.ResponseWriter, recording *Recording) {
+	for header, value := range recording.Headers {
+		w.Header().Set(header, value)
+	}
+	w.WriteHeader(recording.StatusCode)
+	_, _ = w.Write(recording.Body)
+}
@@ -0,0 +1,131 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package beacon_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/beacon"
+)
+
+type RecorderTestSuite struct {
+	suite.Suite
+
+	beaconNode *httptest.Server
+	path       string
+	epoch      int
+}
+
+func TestRunRecorderTestSuite(t *testing.T) {
+	suite.Run(t, new(RecorderTestSuite))
+}
+
+func (s *RecorderTestSuite) SetupTest() {
+	s.path = s.T().TempDir()
+	s.epoch = 0
+	s.beaconNode = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/beacon/states/finalized/finality_checkpoints":
+			s.epoch++
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"data":{"finalized":{"epoch":"%d"}}}`, s.epoch)))
+		case "/eth/v2/beacon/blocks/100":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Header().Set("Eth-Consensus-Version", "deneb")
+			_, _ = w.Write([]byte{1, 2, 3})
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+}
+
+func (s *RecorderTestSuite) TearDownTest() {
+	s.beaconNode.Close()
+}
+
+func (s *RecorderTestSuite) Test_RecordAndReplay() {
+	recorder, err := beacon.NewRecorder(s.beaconNode.URL, s.path)
+	s.Nil(err)
+	recorderServer := httptest.NewServer(recorder)
+	defer recorderServer.Close()
+
+	recorded := []*response{
+		s.get(recorderServer.URL, "/eth/v1/beacon/states/finalized/finality_checkpoints"),
+		s.get(recorderServer.URL, "/eth/v1/beacon/states/finalized/finality_checkpoints"),
+		s.get(recorderServer.URL, "/eth/v2/beacon/blocks/100"),
+		s.get(recorderServer.URL, "/eth/v1/beacon/light_client/updates?start_period=1&count=1"),
+	}
+
+	replayer, err := beacon.NewReplayer(s.path)
+	s.Nil(err)
+	replayerServer := httptest.NewServer(replayer)
+	defer replayerServer.Close()
+
+	s.assertResponse(recorded[0], s.get(replayerServer.URL, "/eth/v1/beacon/states/finalized/finality_checkpoints"))
+	s.assertResponse(recorded[1], s.get(replayerServer.URL, "/eth/v1/beacon/states/finalized/finality_checkpoints"))
+	// exhausted recordings repeat the last response
+	s.assertResponse(recorded[1], s.get(replayerServer.URL, "/eth/v1/beacon/states/finalized/finality_checkpoints"))
+	s.assertResponse(recorded[2], s.get(replayerServer.URL, "/eth/v2/beacon/blocks/100"))
+	s.assertResponse(recorded[3], s.get(replayerServer.URL, "/eth/v1/beacon/light_client/updates?start_period=1&count=1"))
+	s.Equal(s.get(replayerServer.URL, "/eth/v1/node/version").statusCode, http.StatusNotFound)
+}
+
+func (s *RecorderTestSuite) Test_Record_ContinuesExistingRecording() {
+	recorder, err := beacon.NewRecorder(s.beaconNode.URL, s.path)
+	s.Nil(err)
+	recorderServer := httptest.NewServer(recorder)
+	first := s.get(recorderServer.URL, "/eth/v1/beacon/states/finalized/finality_checkpoints")
+	recorderServer.Close()
+
+	recorder, err = beacon.NewRecorder(s.beaconNode.URL, s.path)
+	s.Nil(err)
+	recorderServer = httptest.NewServer(recorder)
+	second := s.get(recorderServer.URL, "/eth/v1/beacon/states/finalized/finality_checkpoints")
+	recorderServer.Close()
+
+	replayer, err := beacon.NewReplayer(s.path)
+	s.Nil(err)
+	replayerServer := httptest.NewServer(replayer)
+	defer replayerServer.Close()
+
+	s.assertResponse(first, s.get(replayerServer.URL, "/eth/v1/beacon/states/finalized/finality_checkpoints"))
+	s.assertResponse(second, s.get(replayerServer.URL, "/eth/v1/beacon/states/finalized/finality_checkpoints"))
+}
+
+func (s *RecorderTestSuite) Test_NewReplayer_EmptyRecording() {
+	_, err := beacon.NewReplayer(s.path)
+
+	s.NotNil(err)
+}
+
+type response struct {
+	statusCode       int
+	contentType      string
+	consensusVersion string
+	body             []byte
+}
+
+func (s *RecorderTestSuite) get(url string, path string) *response {
+	resp, err := http.Get(url + path)
+	s.Nil(err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	s.Nil(err)
+
+	return &response{
+		statusCode:       resp.StatusCode,
+		contentType:      resp.Header.Get("Content-Type"),
+		consensusVersion: resp.Header.Get("Eth-Consensus-Version"),
+		body:             body,
+	}
+}
+
+func (s *RecorderTestSuite) assertResponse(expected *response, actual *response) {
+	s.Equal(expected, actual)
+}
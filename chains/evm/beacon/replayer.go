@@ -0,0 +1,103 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package beacon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Replayer serves recorded beacon API responses in place of a beacon node.
+// Recordings of the same request are served in the order they were recorded
+// and the last one is repeated once they are exhausted.
+type Replayer struct {
+	recordings map[string][]*Recording
+	served     map[string]int
+	lock       sync.Mutex
+}
+
+func NewReplayer(path string) (*Replayer, error) {
+	files, err := recordingFiles(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no beacon recordings found in %s", path)
+	}
+
+	recordings := make(map[string][]*Recording)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		recording := &Recording{}
+		err = json.Unmarshal(data, recording)
+		if err != nil {
+			return nil, fmt.Errorf("invalid beacon recording %s: %w", file, err)
+		}
+
+		recordings[recording.Key()] = append(recordings[recording.Key()], recording)
+	}
+
+	return &Replayer{
+		recordings: recordings,
+		served:     make(map[string]int),
+	}, nil
+}
+
+// ServeHTTP responds with the next recording of the request
+func (r *Replayer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	key := fmt.Sprintf("%s %s", req.Method, req.URL.RequestURI())
+
+	r.lock.Lock()
+	recordings, ok := r.recordings[key]
+	if !ok {
+		r.lock.Unlock()
+		log.Warn().Msgf("Missing beacon recording for %s", key)
+		http.Error(w, fmt.Sprintf("missing recording for %s", key), http.StatusNotFound)
+		return
+	}
+	index := r.served[key]
+	if index < len(recordings)-1 {
+		r.served[key] = index + 1
+	}
+	r.lock.Unlock()
+
+	writeRecording(w, recordings[index])
+}
+
+// Serve serves the handler on a random local port until the context is done
+// and returns the URL it can be reached on
+func Serve(ctx context.Context, handler http.Handler) (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: time.Second * 10,
+	}
+	go func() {
+		err := server.Serve(listener)
+		if err != nil && err != http.ErrServerClosed {
+			log.Error().Err(err).Msgf("Beacon proxy stopped")
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+
+	return fmt.Sprintf("http://%s", listener.Addr().String()), nil
+}
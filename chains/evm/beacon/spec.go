@@ -0,0 +1,93 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/prover"
+)
+
+type SpecProvider interface {
+	Spec(ctx context.Context, opts *api.SpecOpts) (*api.Response[map[string]any], error)
+	Genesis(ctx context.Context, opts *api.GenesisOpts) (*api.Response[*apiv1.Genesis], error)
+}
+
+// NetworkSpec are the parameters of the network served by the beacon node
+type NetworkSpec struct {
+	// Spec is empty if the network doesn't match a prover spec
+	Spec                         prover.Spec
+	SlotsPerEpoch                uint64
+	EpochsPerSyncCommitteePeriod uint64
+	SyncCommitteeSize            uint64
+	SecondsPerSlot               uint64
+}
+
+// FetchNetworkSpec derives the network parameters and the prover spec
+// from the beacon node config spec and genesis
+func FetchNetworkSpec(ctx context.Context, provider SpecProvider) (*NetworkSpec, error) {
+	specResponse, err := provider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching beacon spec: %w", err)
+	}
+	genesis, err := provider.Genesis(ctx, &api.GenesisOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching beacon genesis: %w", err)
+	}
+
+	spec := specResponse.Data
+	networkSpec := &NetworkSpec{}
+	networkSpec.SlotsPerEpoch, err = specUint(spec, "SLOTS_PER_EPOCH")
+	if err != nil {
+		return nil, err
+	}
+	networkSpec.EpochsPerSyncCommitteePeriod, err = specUint(spec, "EPOCHS_PER_SYNC_COMMITTEE_PERIOD")
+	if err != nil {
+		return nil, err
+	}
+	networkSpec.SyncCommitteeSize, err = specUint(spec, "SYNC_COMMITTEE_SIZE")
+	if err != nil {
+		return nil, err
+	}
+	secondsPerSlot, ok := spec["SECONDS_PER_SLOT"].(time.Duration)
+	if !ok {
+		return nil, fmt.Errorf("beacon spec is missing SECONDS_PER_SLOT")
+	}
+	networkSpec.SecondsPerSlot = uint64(secondsPerSlot / time.Second)
+
+	// networks sharing a preset are told apart by their genesis fork version
+	for s, network := range prover.NETWORKS {
+		if network.GenesisForkVersion == genesis.Data.GenesisForkVersion && networkSpec.matches(prover.PRESETS[s]) {
+			networkSpec.Spec = s
+			return networkSpec, nil
+		}
+	}
+	if preset, ok := spec["PRESET_BASE"].(string); ok && prover.Spec(preset) == prover.MINIMAL_SPEC && networkSpec.matches(prover.PRESETS[prover.MINIMAL_SPEC]) {
+		networkSpec.Spec = prover.MINIMAL_SPEC
+	}
+	return networkSpec, nil
+}
+
+// FinalityThreshold returns the sync committee supermajority of the network
+func (s *NetworkSpec) FinalityThreshold() uint64 {
+	return prover.FinalityThreshold(s.SyncCommitteeSize)
+}
+
+func (s *NetworkSpec) matches(preset prover.Preset) bool {
+	return s.SlotsPerEpoch == preset.SlotsPerEpoch &&
+		s.EpochsPerSyncCommitteePeriod == preset.EpochsPerSyncCommitteePeriod &&
+		s.SyncCommitteeSize == preset.SyncCommitteeSize
+}
+
+func specUint(spec map[string]any, key string) (uint64, error) {
+	value, ok := spec[key].(uint64)
+	if !ok {
+		return 0, fmt.Errorf("beacon spec is missing %s", key)
+	}
+	return value, nil
+}
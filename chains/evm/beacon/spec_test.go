@@ -0,0 +1,119 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package beacon_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/beacon"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/prover"
+	"github.com/sygmaprotocol/spectre-node/mock"
+	"go.uber.org/mock/gomock"
+)
+
+type NetworkSpecTestSuite struct {
+	suite.Suite
+
+	mockSpecProvider *mock.MockSpecProvider
+}
+
+func TestRunNetworkSpecTestSuite(t *testing.T) {
+	suite.Run(t, new(NetworkSpecTestSuite))
+}
+
+func (s *NetworkSpecTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockSpecProvider = mock.NewMockSpecProvider(ctrl)
+}
+
+func (s *NetworkSpecTestSuite) mockSpec(preset string, slotsPerEpoch, epochsPerPeriod, committeeSize uint64, genesisForkVersion phase0.Version) {
+	s.mockSpecProvider.EXPECT().Spec(gomock.Any(), gomock.Any()).Return(&api.Response[map[string]any]{
+		Data: map[string]any{
+			"PRESET_BASE":                      preset,
+			"SLOTS_PER_EPOCH":                  slotsPerEpoch,
+			"EPOCHS_PER_SYNC_COMMITTEE_PERIOD": epochsPerPeriod,
+			"SYNC_COMMITTEE_SIZE":              committeeSize,
+			"SECONDS_PER_SLOT":                 time.Second * 12,
+		},
+	}, nil)
+	s.mockSpecProvider.EXPECT().Genesis(gomock.Any(), gomock.Any()).Return(&api.Response[*apiv1.Genesis]{
+		Data: &apiv1.Genesis{
+			GenesisForkVersion: genesisForkVersion,
+		},
+	}, nil)
+}
+
+func (s *NetworkSpecTestSuite) Test_FetchNetworkSpec_FetchingSpecFails() {
+	s.mockSpecProvider.EXPECT().Spec(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+
+	_, err := beacon.FetchNetworkSpec(context.Background(), s.mockSpecProvider)
+
+	s.NotNil(err)
+}
+
+func (s *NetworkSpecTestSuite) Test_FetchNetworkSpec_MissingParameter() {
+	s.mockSpecProvider.EXPECT().Spec(gomock.Any(), gomock.Any()).Return(&api.Response[map[string]any]{
+		Data: map[string]any{
+			"SLOTS_PER_EPOCH": uint64(32),
+		},
+	}, nil)
+	s.mockSpecProvider.EXPECT().Genesis(gomock.Any(), gomock.Any()).Return(&api.Response[*apiv1.Genesis]{
+		Data: &apiv1.Genesis{},
+	}, nil)
+
+	_, err := beacon.FetchNetworkSpec(context.Background(), s.mockSpecProvider)
+
+	s.NotNil(err)
+}
+
+func (s *NetworkSpecTestSuite) Test_FetchNetworkSpec_Mainnet() {
+	s.mockSpec("mainnet", 32, 256, 512, prover.NETWORKS[prover.MAINNET_SPEC].GenesisForkVersion)
+
+	spec, err := beacon.FetchNetworkSpec(context.Background(), s.mockSpecProvider)
+
+	s.Nil(err)
+	s.Equal(spec, &beacon.NetworkSpec{
+		Spec:                         prover.MAINNET_SPEC,
+		SlotsPerEpoch:                32,
+		EpochsPerSyncCommitteePeriod: 256,
+		SyncCommitteeSize:            512,
+		SecondsPerSlot:               12,
+	})
+	s.Equal(spec.FinalityThreshold(), uint64(342))
+}
+
+func (s *NetworkSpecTestSuite) Test_FetchNetworkSpec_Testnet() {
+	s.mockSpec("mainnet", 32, 256, 512, prover.NETWORKS[prover.TESTNET_SPEC].GenesisForkVersion)
+
+	spec, err := beacon.FetchNetworkSpec(context.Background(), s.mockSpecProvider)
+
+	s.Nil(err)
+	s.Equal(spec.Spec, prover.TESTNET_SPEC)
+}
+
+func (s *NetworkSpecTestSuite) Test_FetchNetworkSpec_MinimalDevnet() {
+	s.mockSpec("minimal", 8, 8, 32, phase0.Version{0x10, 0x00, 0x00, 0x38})
+
+	spec, err := beacon.FetchNetworkSpec(context.Background(), s.mockSpecProvider)
+
+	s.Nil(err)
+	s.Equal(spec.Spec, prover.MINIMAL_SPEC)
+	s.Equal(spec.FinalityThreshold(), uint64(22))
+}
+
+func (s *NetworkSpecTestSuite) Test_FetchNetworkSpec_UnknownNetwork() {
+	s.mockSpec("mainnet", 32, 256, 512, phase0.Version{0x01, 0x01, 0x70, 0x00})
+
+	spec, err := beacon.FetchNetworkSpec(context.Background(), s.mockSpecProvider)
+
+	s.Nil(err)
+	s.Equal(spec.Spec, prover.Spec(""))
+}
@@ -7,6 +7,11 @@ import (
 	"fmt"
 
 	"github.com/kelseyhightower/envconfig"
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/beacon"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/execution"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/prover"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/route"
 	"github.com/sygmaprotocol/spectre-node/config"
 )
 
@@ -16,17 +21,115 @@ type EVMConfig struct {
 	Router                string
 	Spectre               string
 	Yaho                  string
-	Spec                  string  `default:"mainnet"`
+	HashiAdapter          string   `split_words:"true"`
+	ChainID               uint64   `split_words:"true"`
+	RelayerRoles          []string `split_words:"true"`
+	Spec                  string
 	MaxGasPrice           int64   `default:"500000000000" split_words:"true"`
 	GasMultiplier         float64 `default:"1" split_words:"true"`
 	GasIncreasePercentage int64   `default:"15" split_words:"true"`
 	RetryInterval         uint64  `default:"12" split_words:"true"`
-	CommitteePeriodLength uint64  `default:"256" split_words:"true"`
+	EventPollInterval     uint64  `default:"384" split_words:"true"`
+	EventIdleTimeout      uint64  `default:"900" split_words:"true"`
+	CommitteePeriodLength uint64  `split_words:"true"`
+	RotationLeadEpochs    *uint64 `split_words:"true"`
 	StartingPeriod        uint64  `required:"true" split_words:"true"`
 	ForcePeriod           bool    `default:"false" split_words:"true"`
-	FinalityThreshold     uint64  `default:"342" split_words:"true"`
-	SlotsPerEpoch         uint64  `default:"32" split_words:"true"`
+	FinalityThreshold     uint64  `split_words:"true"`
+	SlotsPerEpoch         uint64  `split_words:"true"`
 	TargetDomains         []int16 `split_words:"true"`
+	SecondsPerSlot        uint64  `split_words:"true"`
+	MonitorInterval       uint64  `default:"60" split_words:"true"`
+	LogBlockRange         int64   `default:"1000" split_words:"true"`
+	ConfirmationTimeout   uint64  `default:"1800" split_words:"true"`
+	Confirmations         uint64  `default:"10" split_words:"true"`
+	DropTimeout           uint64  `default:"900" split_words:"true"`
+	MaxResubmissions      int     `default:"3" split_words:"true"`
+	StateRootMaxAge       uint64  `default:"60" split_words:"true"`
+	StateRootLagThreshold uint64  `default:"256" split_words:"true"`
+	RotationGracePeriod   uint64  `default:"60" split_words:"true"`
+	BeaconRecordPath      string  `split_words:"true"`
+	BeaconReplayPath      string  `split_words:"true"`
+	// Routes override the default step and rotate routes to target domains
+	Routes route.Routes
+	// CustomSpec defines the spec of networks the prover has no built-in spec for
+	CustomSpec prover.ChainSpec `split_words:"true"`
+	// Execution payload fields and storage proofs the domain receives with steps
+	ExecutionReceiver string   `split_words:"true"`
+	ExecutionFields   []string `split_words:"true"`
+	StorageProofs     []string `split_words:"true"`
+}
+
+// ExecutionTargets returns the execution payload fields and accounts
+// proven for the domain when it is a step destination
+func (c *EVMConfig) ExecutionTargets() (*execution.Targets, error) {
+	return execution.ParseTargets(c.ExecutionFields, c.StorageProofs)
+}
+
+// DestinationRoutes returns routes to the target domains merged with the configured routes
+func (c *EVMConfig) DestinationRoutes() route.Routes {
+	targetDomains := make([]uint8, len(c.TargetDomains))
+	for i, d := range c.TargetDomains {
+		targetDomains[i] = uint8(d)
+	}
+	return route.NewRoutes(targetDomains).Merge(c.Routes)
+}
+
+// ChainSpec returns the custom spec if configured and the built-in spec otherwise
+func (c *EVMConfig) ChainSpec() (*prover.ChainSpec, error) {
+	if c.CustomSpec.Name != "" {
+		spec := c.CustomSpec
+		return &spec, nil
+	}
+	return prover.BuiltinSpec(prover.Spec(c.Spec))
+}
+
+// ApplyNetworkSpec sets network parameters that aren't configured to the values derived
+// from the beacon node and warns about configured values that don't match them
+func (c *EVMConfig) ApplyNetworkSpec(domainID uint8, spec *beacon.NetworkSpec) error {
+	c.SlotsPerEpoch = networkParameter(domainID, "slots per epoch", c.SlotsPerEpoch, spec.SlotsPerEpoch)
+	c.CommitteePeriodLength = networkParameter(domainID, "committee period length", c.CommitteePeriodLength, spec.EpochsPerSyncCommitteePeriod)
+	c.FinalityThreshold = networkParameter(domainID, "finality threshold", c.FinalityThreshold, spec.FinalityThreshold())
+	c.SecondsPerSlot = networkParameter(domainID, "seconds per slot", c.SecondsPerSlot, spec.SecondsPerSlot)
+
+	switch {
+	case c.Spec == "" && spec.Spec == "":
+		return fmt.Errorf("prover spec can't be derived from the beacon node and has to be configured")
+	case c.Spec == "":
+		c.Spec = string(spec.Spec)
+	case spec.Spec != "" && c.Spec != string(spec.Spec):
+		log.Warn().Uint8("domainID", domainID).Msgf("Configured spec %s doesn't match the beacon node spec %s", c.Spec, spec.Spec)
+	}
+
+	return c.validateRotationLead()
+}
+
+func (c *EVMConfig) validateRotationLead() error {
+	if c.RotationLeadEpochs != nil && *c.RotationLeadEpochs > c.CommitteePeriodLength {
+		return fmt.Errorf("rotation lead of %d epochs exceeds the committee period length", *c.RotationLeadEpochs)
+	}
+	return nil
+}
+
+// RotationLead returns the number of epochs before the next committee starts signing
+// at which the committee is rotated. Without a configured lead the committee is rotated
+// a full period ahead, as soon as the period it is proven from starts.
+func (c *EVMConfig) RotationLead() uint64 {
+	if c.RotationLeadEpochs == nil {
+		return c.CommitteePeriodLength
+	}
+	return *c.RotationLeadEpochs
+}
+
+// networkParameter returns the configured value if set and the derived value otherwise
+func networkParameter(domainID uint8, name string, configured uint64, derived uint64) uint64 {
+	if configured == 0 {
+		return derived
+	}
+	if configured != derived {
+		log.Warn().Uint8("domainID", domainID).Msgf("Configured %s %d doesn't match the beacon node value %d", name, configured, derived)
+	}
+	return configured
 }
 
 // LoadEVMConfig loads EVM config from the environment and validates the fields
@@ -36,6 +139,38 @@ func LoadEVMConfig(domainID uint8) (*EVMConfig, error) {
 	if err != nil {
 		return nil, err
 	}
+	if c.BeaconRecordPath != "" && c.BeaconReplayPath != "" {
+		return nil, fmt.Errorf("beacon record and replay paths are mutually exclusive")
+	}
+	if c.LogBlockRange < 1 {
+		return nil, fmt.Errorf("log block range must be positive")
+	}
+	// network parameters that aren't configured are derived from the beacon node later
+	if c.CommitteePeriodLength != 0 {
+		err = c.validateRotationLead()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.CustomSpec.Name != "" {
+		if c.Spec != "" && c.Spec != string(c.CustomSpec.Name) {
+			return nil, fmt.Errorf("spec %s doesn't match the custom spec %s", c.Spec, c.CustomSpec.Name)
+		}
+		c.Spec = string(c.CustomSpec.Name)
+	}
+
+	if _, ok := c.Routes[domainID]; ok {
+		return nil, fmt.Errorf("domain %d can't route to itself", domainID)
+	}
+
+	targets, err := c.ExecutionTargets()
+	if err != nil {
+		return nil, err
+	}
+	if !targets.Empty() && c.ExecutionReceiver == "" {
+		return nil, fmt.Errorf("execution receiver is required to receive execution proofs")
+	}
 
 	return &c, nil
 }
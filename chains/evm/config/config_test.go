@@ -6,9 +6,13 @@ package config_test
 import (
 	"os"
 	"testing"
+	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/beacon"
 	"github.com/sygmaprotocol/spectre-node/chains/evm/config"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/prover"
 	baseConfig "github.com/sygmaprotocol/spectre-node/config"
 )
 
@@ -55,17 +59,24 @@ func (s *EVMConfigTestSuite) Test_LoadEVMConfig_SuccessfulLoad_DefaultValues() {
 		},
 		Yaho:                  "yaho",
 		Spectre:               "spectre",
-		Spec:                  "mainnet",
 		GasMultiplier:         1,
 		GasIncreasePercentage: 15,
 		MaxGasPrice:           500000000000,
 		RetryInterval:         12,
-		CommitteePeriodLength: 256,
+		EventPollInterval:     384,
+		EventIdleTimeout:      900,
 		BeaconEndpoint:        "endpoint",
 		StartingPeriod:        500,
 		ForcePeriod:           false,
-		FinalityThreshold:     342,
-		SlotsPerEpoch:         32,
+		MonitorInterval:       60,
+		LogBlockRange:         1000,
+		ConfirmationTimeout:   1800,
+		Confirmations:         10,
+		DropTimeout:           900,
+		MaxResubmissions:      3,
+		StateRootMaxAge:       60,
+		StateRootLagThreshold: 256,
+		RotationGracePeriod:   60,
 	})
 }
 
@@ -82,13 +93,30 @@ func (s *EVMConfigTestSuite) Test_LoadEVMConfig_SuccessfulLoad() {
 	os.Setenv("SPECTRE_DOMAINS_1_SPEC", "testnet")
 	os.Setenv("SPECTRE_DOMAINS_1_GAS_INCREASE_PERCENTAGE", "20")
 	os.Setenv("SPECTRE_DOMAINS_1_RETRY_INTERVAL", "30")
+	os.Setenv("SPECTRE_DOMAINS_1_EVENT_POLL_INTERVAL", "120")
+	os.Setenv("SPECTRE_DOMAINS_1_EVENT_IDLE_TIMEOUT", "600")
 	os.Setenv("SPECTRE_DOMAINS_1_COMMITTEE_PERIOD_LENGTH", "128")
+	os.Setenv("SPECTRE_DOMAINS_1_ROTATION_LEAD_EPOCHS", "8")
 	os.Setenv("SPECTRE_DOMAINS_2_ROUTER", "invalid")
 	os.Setenv("SPECTRE_DOMAINS_1_STARTING_PERIOD", "500")
 	os.Setenv("SPECTRE_DOMAINS_1_FORCE_PERIOD", "true")
 	os.Setenv("SPECTRE_DOMAINS_1_FINALITY_THRESHOLD", "382")
 	os.Setenv("SPECTRE_DOMAINS_1_SLOTS_PER_EPOCH", "16")
 	os.Setenv("SPECTRE_DOMAINS_1_TARGET_DOMAINS", "1,2")
+	os.Setenv("SPECTRE_DOMAINS_1_SECONDS_PER_SLOT", "5")
+	os.Setenv("SPECTRE_DOMAINS_1_MONITOR_INTERVAL", "30")
+	os.Setenv("SPECTRE_DOMAINS_1_LOG_BLOCK_RANGE", "500")
+	os.Setenv("SPECTRE_DOMAINS_1_CONFIRMATION_TIMEOUT", "600")
+	os.Setenv("SPECTRE_DOMAINS_1_CONFIRMATIONS", "5")
+	os.Setenv("SPECTRE_DOMAINS_1_DROP_TIMEOUT", "1200")
+	os.Setenv("SPECTRE_DOMAINS_1_MAX_RESUBMISSIONS", "2")
+	os.Setenv("SPECTRE_DOMAINS_1_STATE_ROOT_MAX_AGE", "30")
+	os.Setenv("SPECTRE_DOMAINS_1_STATE_ROOT_LAG_THRESHOLD", "64")
+	os.Setenv("SPECTRE_DOMAINS_1_ROTATION_GRACE_PERIOD", "120")
+	os.Setenv("SPECTRE_DOMAINS_1_BEACON_RECORD_PATH", "./recordings")
+	os.Setenv("SPECTRE_DOMAINS_1_EXECUTION_RECEIVER", "receiver")
+	os.Setenv("SPECTRE_DOMAINS_1_EXECUTION_FIELDS", "receipts_root,block_hash")
+	os.Setenv("SPECTRE_DOMAINS_1_STORAGE_PROOFS", "0x5798a6d2a3a4d6e5a2b1e7d4e2d6b6c2d8c6a5b3:0x1")
 
 	c, err := config.LoadEVMConfig(1)
 
@@ -106,12 +134,271 @@ func (s *EVMConfigTestSuite) Test_LoadEVMConfig_SuccessfulLoad() {
 		GasIncreasePercentage: 20,
 		MaxGasPrice:           1000,
 		RetryInterval:         30,
+		EventPollInterval:     120,
+		EventIdleTimeout:      600,
 		CommitteePeriodLength: 128,
+		RotationLeadEpochs:    epochs(8),
 		BeaconEndpoint:        "endpoint",
 		StartingPeriod:        500,
 		ForcePeriod:           true,
 		FinalityThreshold:     382,
 		SlotsPerEpoch:         16,
 		TargetDomains:         []int16{1, 2},
+		SecondsPerSlot:        5,
+		MonitorInterval:       30,
+		LogBlockRange:         500,
+		ConfirmationTimeout:   600,
+		Confirmations:         5,
+		DropTimeout:           1200,
+		MaxResubmissions:      2,
+		StateRootMaxAge:       30,
+		StateRootLagThreshold: 64,
+		RotationGracePeriod:   120,
+		BeaconRecordPath:      "./recordings",
+		ExecutionReceiver:     "receiver",
+		ExecutionFields:       []string{"receipts_root", "block_hash"},
+		StorageProofs:         []string{"0x5798a6d2a3a4d6e5a2b1e7d4e2d6b6c2d8c6a5b3:0x1"},
 	})
 }
+
+func (s *EVMConfigTestSuite) Test_LoadEVMConfig_RecordAndReplay() {
+	os.Setenv("SPECTRE_DOMAINS_1_ENDPOINT", "http://endpoint.com")
+	os.Setenv("SPECTRE_DOMAINS_1_KEY", "key")
+	os.Setenv("SPECTRE_DOMAINS_1_STARTING_PERIOD", "500")
+	os.Setenv("SPECTRE_DOMAINS_1_BEACON_RECORD_PATH", "./recordings")
+	os.Setenv("SPECTRE_DOMAINS_1_BEACON_REPLAY_PATH", "./recordings")
+
+	_, err := config.LoadEVMConfig(1)
+
+	s.NotNil(err)
+}
+
+func (s *EVMConfigTestSuite) Test_LoadEVMConfig_RotationLeadExceedsPeriod() {
+	os.Setenv("SPECTRE_DOMAINS_1_ENDPOINT", "http://endpoint.com")
+	os.Setenv("SPECTRE_DOMAINS_1_KEY", "key")
+	os.Setenv("SPECTRE_DOMAINS_1_STARTING_PERIOD", "500")
+	os.Setenv("SPECTRE_DOMAINS_1_COMMITTEE_PERIOD_LENGTH", "256")
+	os.Setenv("SPECTRE_DOMAINS_1_ROTATION_LEAD_EPOCHS", "257")
+
+	_, err := config.LoadEVMConfig(1)
+
+	s.NotNil(err)
+}
+
+func (s *EVMConfigTestSuite) Test_LoadEVMConfig_InvalidExecutionField() {
+	os.Setenv("SPECTRE_DOMAINS_1_ENDPOINT", "http://endpoint.com")
+	os.Setenv("SPECTRE_DOMAINS_1_KEY", "key")
+	os.Setenv("SPECTRE_DOMAINS_1_STARTING_PERIOD", "500")
+	os.Setenv("SPECTRE_DOMAINS_1_EXECUTION_RECEIVER", "receiver")
+	os.Setenv("SPECTRE_DOMAINS_1_EXECUTION_FIELDS", "receipts")
+
+	_, err := config.LoadEVMConfig(1)
+
+	s.NotNil(err)
+}
+
+func (s *EVMConfigTestSuite) Test_LoadEVMConfig_MissingExecutionReceiver() {
+	os.Setenv("SPECTRE_DOMAINS_1_ENDPOINT", "http://endpoint.com")
+	os.Setenv("SPECTRE_DOMAINS_1_KEY", "key")
+	os.Setenv("SPECTRE_DOMAINS_1_STARTING_PERIOD", "500")
+	os.Setenv("SPECTRE_DOMAINS_1_STORAGE_PROOFS", "0x5798a6d2a3a4d6e5a2b1e7d4e2d6b6c2d8c6a5b3")
+
+	_, err := config.LoadEVMConfig(1)
+
+	s.NotNil(err)
+}
+
+func (s *EVMConfigTestSuite) Test_LoadEVMConfig_InvalidLogBlockRange() {
+	os.Setenv("SPECTRE_DOMAINS_1_ENDPOINT", "http://endpoint.com")
+	os.Setenv("SPECTRE_DOMAINS_1_KEY", "key")
+	os.Setenv("SPECTRE_DOMAINS_1_STARTING_PERIOD", "500")
+	os.Setenv("SPECTRE_DOMAINS_1_LOG_BLOCK_RANGE", "0")
+
+	_, err := config.LoadEVMConfig(1)
+
+	s.NotNil(err)
+}
+
+func (s *EVMConfigTestSuite) Test_LoadEVMConfig_Routes() {
+	os.Setenv("SPECTRE_DOMAINS_1_ENDPOINT", "http://endpoint.com")
+	os.Setenv("SPECTRE_DOMAINS_1_KEY", "key")
+	os.Setenv("SPECTRE_DOMAINS_1_STARTING_PERIOD", "500")
+	os.Setenv("SPECTRE_DOMAINS_1_TARGET_DOMAINS", "2,3")
+	os.Setenv("SPECTRE_DOMAINS_1_ROUTES", `[{"destination":2,"step":false},{"destination":4,"securityModels":[2],"minStepInterval":600}]`)
+
+	c, err := config.LoadEVMConfig(1)
+
+	s.Nil(err)
+	routes := c.DestinationRoutes()
+	s.Equal(routes.StepDomains(), []uint8{3, 4})
+	s.Equal(routes.RotateDomains(), []uint8{2, 3, 4})
+	s.Equal(routes[4].SecurityModels, []uint8{2})
+	s.Equal(routes[4].MinStepInterval, time.Minute*10)
+}
+
+func (s *EVMConfigTestSuite) Test_LoadEVMConfig_InvalidRoutes() {
+	os.Setenv("SPECTRE_DOMAINS_1_ENDPOINT", "http://endpoint.com")
+	os.Setenv("SPECTRE_DOMAINS_1_KEY", "key")
+	os.Setenv("SPECTRE_DOMAINS_1_STARTING_PERIOD", "500")
+	os.Setenv("SPECTRE_DOMAINS_1_ROUTES", `[{"step":false}]`)
+
+	_, err := config.LoadEVMConfig(1)
+
+	s.NotNil(err)
+}
+
+func (s *EVMConfigTestSuite) Test_LoadEVMConfig_RouteToSelf() {
+	os.Setenv("SPECTRE_DOMAINS_1_ENDPOINT", "http://endpoint.com")
+	os.Setenv("SPECTRE_DOMAINS_1_KEY", "key")
+	os.Setenv("SPECTRE_DOMAINS_1_STARTING_PERIOD", "500")
+	os.Setenv("SPECTRE_DOMAINS_1_ROUTES", `[{"destination":1}]`)
+
+	_, err := config.LoadEVMConfig(1)
+
+	s.NotNil(err)
+}
+
+func (s *EVMConfigTestSuite) Test_LoadEVMConfig_CustomSpec() {
+	os.Setenv("SPECTRE_DOMAINS_1_ENDPOINT", "http://endpoint.com")
+	os.Setenv("SPECTRE_DOMAINS_1_KEY", "key")
+	os.Setenv("SPECTRE_DOMAINS_1_STARTING_PERIOD", "500")
+	os.Setenv("SPECTRE_DOMAINS_1_CUSTOM_SPEC", `{"name":"gnosis","slotsPerEpoch":16,"epochsPerSyncCommitteePeriod":512,"syncCommitteeSize":512,"genesisForkVersion":"0x00000064","denebForkVersion":"0x04000064","denebForkEpoch":889856}`)
+
+	c, err := config.LoadEVMConfig(1)
+
+	s.Nil(err)
+	s.Equal(c.Spec, "gnosis")
+	spec, err := c.ChainSpec()
+	s.Nil(err)
+	s.Equal(spec.Name, prover.Spec("gnosis"))
+	s.Equal(spec.Preset.SlotsPerEpoch, uint64(16))
+	s.Equal(spec.Network.DenebForkVersion, phase0.Version{0x04, 0x00, 0x00, 0x64})
+}
+
+func (s *EVMConfigTestSuite) Test_LoadEVMConfig_CustomSpecNameMismatch() {
+	os.Setenv("SPECTRE_DOMAINS_1_ENDPOINT", "http://endpoint.com")
+	os.Setenv("SPECTRE_DOMAINS_1_KEY", "key")
+	os.Setenv("SPECTRE_DOMAINS_1_STARTING_PERIOD", "500")
+	os.Setenv("SPECTRE_DOMAINS_1_SPEC", "mainnet")
+	os.Setenv("SPECTRE_DOMAINS_1_CUSTOM_SPEC", `{"name":"gnosis","slotsPerEpoch":16,"epochsPerSyncCommitteePeriod":512,"syncCommitteeSize":512,"genesisForkVersion":"0x00000064","denebForkVersion":"0x04000064"}`)
+
+	_, err := config.LoadEVMConfig(1)
+
+	s.NotNil(err)
+}
+
+func (s *EVMConfigTestSuite) Test_LoadEVMConfig_InvalidCustomSpec() {
+	os.Setenv("SPECTRE_DOMAINS_1_ENDPOINT", "http://endpoint.com")
+	os.Setenv("SPECTRE_DOMAINS_1_KEY", "key")
+	os.Setenv("SPECTRE_DOMAINS_1_STARTING_PERIOD", "500")
+	os.Setenv("SPECTRE_DOMAINS_1_CUSTOM_SPEC", `{"name":"gnosis"}`)
+
+	_, err := config.LoadEVMConfig(1)
+
+	s.NotNil(err)
+}
+
+func (s *EVMConfigTestSuite) Test_ChainSpec_BuiltinSpec() {
+	c := &config.EVMConfig{Spec: "minimal"}
+
+	spec, err := c.ChainSpec()
+
+	s.Nil(err)
+	s.Equal(spec.Name, prover.MINIMAL_SPEC)
+	s.Equal(spec.Preset.SyncCommitteeSize, uint64(32))
+}
+
+func (s *EVMConfigTestSuite) Test_ChainSpec_UnknownSpec() {
+	c := &config.EVMConfig{Spec: "gnosis"}
+
+	_, err := c.ChainSpec()
+
+	s.NotNil(err)
+}
+
+func (s *EVMConfigTestSuite) Test_ApplyNetworkSpec_DerivesUnsetParameters() {
+	c := &config.EVMConfig{RotationLeadEpochs: epochs(4)}
+
+	err := c.ApplyNetworkSpec(1, &beacon.NetworkSpec{
+		Spec:                         "minimal",
+		SlotsPerEpoch:                8,
+		EpochsPerSyncCommitteePeriod: 8,
+		SyncCommitteeSize:            32,
+		SecondsPerSlot:               6,
+	})
+
+	s.Nil(err)
+	s.Equal(c.Spec, "minimal")
+	s.Equal(c.SlotsPerEpoch, uint64(8))
+	s.Equal(c.CommitteePeriodLength, uint64(8))
+	s.Equal(c.FinalityThreshold, uint64(22))
+	s.Equal(c.SecondsPerSlot, uint64(6))
+}
+
+func (s *EVMConfigTestSuite) Test_ApplyNetworkSpec_KeepsOverrides() {
+	c := &config.EVMConfig{
+		Spec:                  "testnet",
+		SlotsPerEpoch:         16,
+		CommitteePeriodLength: 128,
+		FinalityThreshold:     400,
+		SecondsPerSlot:        5,
+	}
+
+	err := c.ApplyNetworkSpec(1, &beacon.NetworkSpec{
+		Spec:                         "mainnet",
+		SlotsPerEpoch:                32,
+		EpochsPerSyncCommitteePeriod: 256,
+		SyncCommitteeSize:            512,
+		SecondsPerSlot:               12,
+	})
+
+	s.Nil(err)
+	s.Equal(c.Spec, "testnet")
+	s.Equal(c.SlotsPerEpoch, uint64(16))
+	s.Equal(c.CommitteePeriodLength, uint64(128))
+	s.Equal(c.FinalityThreshold, uint64(400))
+	s.Equal(c.SecondsPerSlot, uint64(5))
+}
+
+func (s *EVMConfigTestSuite) Test_ApplyNetworkSpec_UnknownSpec() {
+	c := &config.EVMConfig{}
+
+	err := c.ApplyNetworkSpec(1, &beacon.NetworkSpec{
+		SlotsPerEpoch:                32,
+		EpochsPerSyncCommitteePeriod: 256,
+		SyncCommitteeSize:            512,
+		SecondsPerSlot:               12,
+	})
+
+	s.NotNil(err)
+}
+
+func (s *EVMConfigTestSuite) Test_ApplyNetworkSpec_RotationLeadExceedsPeriod() {
+	c := &config.EVMConfig{RotationLeadEpochs: epochs(9)}
+
+	err := c.ApplyNetworkSpec(1, &beacon.NetworkSpec{
+		Spec:                         "minimal",
+		SlotsPerEpoch:                8,
+		EpochsPerSyncCommitteePeriod: 8,
+		SyncCommitteeSize:            32,
+		SecondsPerSlot:               6,
+	})
+
+	s.NotNil(err)
+}
+
+func (s *EVMConfigTestSuite) Test_RotationLead_DefaultsToFullPeriod() {
+	c := &config.EVMConfig{CommitteePeriodLength: 256}
+
+	s.Equal(c.RotationLead(), uint64(256))
+}
+
+func (s *EVMConfigTestSuite) Test_RotationLead_Configured() {
+	c := &config.EVMConfig{CommitteePeriodLength: 256, RotationLeadEpochs: epochs(0)}
+
+	s.Equal(c.RotationLead(), uint64(0))
+}
+
+func epochs(epochs uint64) *uint64 {
+	return &epochs
+}
@@ -0,0 +1,48 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package contracts
+
+import (
+	"math/big"
+	"strings"
+
+	ethereumABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/sygmaprotocol/spectre-node/chains/evm/abi"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/message"
+	"github.com/sygmaprotocol/sygma-core/chains/evm/client"
+	coreContracts "github.com/sygmaprotocol/sygma-core/chains/evm/contracts"
+	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor"
+)
+
+// HashiAdapter stores block header hashes verified against the finalized
+// header root stored by the Spectre contract, so they can be used by Hashi
+type HashiAdapter struct {
+	coreContracts.Contract
+}
+
+func NewHashiAdapterContract(
+	address common.Address,
+	client client.Client,
+	transactor transactor.Transactor,
+) *HashiAdapter {
+	a, _ := ethereumABI.JSON(strings.NewReader(abi.HashiAdapterABI))
+	return &HashiAdapter{
+		Contract: coreContracts.NewContract(address, a, nil, client, transactor),
+	}
+}
+
+func (c *HashiAdapter) StoreBlockHeader(
+	domainID uint8,
+	slot uint64,
+	header message.BlockHeader,
+	opts transactor.TransactOptions,
+) (*common.Hash, error) {
+	return c.ExecuteTransaction(
+		"storeBlockHeader",
+		opts,
+		domainID, new(big.Int).SetUint64(slot), new(big.Int).SetUint64(header.Number), header.NumberProof, header.Hash, header.HashProof,
+	)
+}
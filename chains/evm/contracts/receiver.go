@@ -0,0 +1,49 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package contracts
+
+import (
+	"math/big"
+	"strings"
+
+	ethereumABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/sygmaprotocol/spectre-node/chains/evm/abi"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/message"
+	"github.com/sygmaprotocol/sygma-core/chains/evm/client"
+	coreContracts "github.com/sygmaprotocol/sygma-core/chains/evm/contracts"
+	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor"
+)
+
+// ExecutionReceiver verifies execution payload fields and account proofs
+// against the finalized header root stored by the Spectre contract
+type ExecutionReceiver struct {
+	coreContracts.Contract
+}
+
+func NewExecutionReceiverContract(
+	address common.Address,
+	client client.Client,
+	transactor transactor.Transactor,
+) *ExecutionReceiver {
+	a, _ := ethereumABI.JSON(strings.NewReader(abi.ExecutionReceiverABI))
+	return &ExecutionReceiver{
+		Contract: coreContracts.NewContract(address, a, nil, client, transactor),
+	}
+}
+
+func (c *ExecutionReceiver) ReceiveExecutionProofs(
+	domainID uint8,
+	slot uint64,
+	fields []message.ExecutionField,
+	accountProofs []message.AccountProof,
+	opts transactor.TransactOptions,
+) (*common.Hash, error) {
+	return c.ExecuteTransaction(
+		"receiveExecutionProofs",
+		opts,
+		domainID, new(big.Int).SetUint64(slot), fields, accountProofs,
+	)
+}
@@ -4,6 +4,7 @@
 package contracts
 
 import (
+	"math/big"
 	"strings"
 
 	ethereumABI "github.com/ethereum/go-ethereum/accounts/abi"
@@ -11,21 +12,25 @@ import (
 
 	"github.com/sygmaprotocol/spectre-node/chains/evm/abi"
 	"github.com/sygmaprotocol/spectre-node/chains/evm/message"
+	"github.com/sygmaprotocol/sygma-core/chains/evm/client"
 	coreContracts "github.com/sygmaprotocol/sygma-core/chains/evm/contracts"
 	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor"
 )
 
 type Spectre struct {
 	coreContracts.Contract
+	client client.Client
 }
 
 func NewSpectreContract(
 	address common.Address,
+	client client.Client,
 	transactor transactor.Transactor,
 ) *Spectre {
 	a, _ := ethereumABI.JSON(strings.NewReader(abi.SpectreABI))
 	return &Spectre{
-		Contract: coreContracts.NewContract(address, a, nil, nil, transactor),
+		Contract: coreContracts.NewContract(address, a, nil, client, transactor),
+		client:   client,
 	}
 }
 
@@ -37,6 +42,11 @@ func (c *Spectre) Step(
 	stateRootProof [][]byte,
 	opts transactor.TransactOptions,
 ) (*common.Hash, error) {
+	err := c.simulate("step", domainID, stepInput, stepProof, stateRoot, stateRootProof)
+	if err != nil {
+		return nil, err
+	}
+
 	return c.ExecuteTransaction(
 		"step",
 		opts,
@@ -51,9 +61,128 @@ func (c *Spectre) Rotate(
 	stepProof []byte,
 	opts transactor.TransactOptions,
 ) (*common.Hash, error) {
+	err := c.simulate("rotate", domainID, rotateProof, stepInput, stepProof)
+	if err != nil {
+		return nil, err
+	}
+
 	return c.ExecuteTransaction(
 		"rotate",
 		opts,
 		domainID, rotateProof, stepInput, stepProof,
 	)
 }
+
+// simulate calls the method before the transaction is sent, so reverts are
+// returned with their revert data instead of failing on chain
+func (c *Spectre) simulate(method string, args ...interface{}) error {
+	_, err := c.CallContract(method, args...)
+	return err
+}
+
+// GetStateRoot returns the execution state root stored for the source domain slot
+func (c *Spectre) GetStateRoot(domainID uint8, slot uint64) ([32]byte, error) {
+	res, err := c.CallContract("getStateRoot", domainID, new(big.Int).SetUint64(slot))
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	return *ethereumABI.ConvertType(res[0], new([32]byte)).(*[32]byte), nil
+}
+
+// StateRoots returns the execution state root stored by the proxy for the source domain slot
+func (c *Spectre) StateRoots(domainID uint8, slot uint64) ([32]byte, error) {
+	res, err := c.CallContract("stateRoots", domainID, new(big.Int).SetUint64(slot))
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	return *ethereumABI.ConvertType(res[0], new([32]byte)).(*[32]byte), nil
+}
+
+// SpectreContract returns the address of the Spectre contract verifying steps of the source domain
+func (c *Spectre) SpectreContract(domainID uint8) (common.Address, error) {
+	res, err := c.CallContract("spectreContracts", domainID)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return *ethereumABI.ConvertType(res[0], new(common.Address)).(*common.Address), nil
+}
+
+// Head returns the latest slot stepped to on the Spectre contract verifying steps of the source domain
+func (c *Spectre) Head(domainID uint8) (uint64, error) {
+	address, err := c.SpectreContract(domainID)
+	if err != nil {
+		return 0, err
+	}
+
+	a, _ := ethereumABI.JSON(strings.NewReader(abi.SpectreLightClientABI))
+	lightClient := coreContracts.NewContract(address, a, nil, c.client, nil)
+	res, err := lightClient.CallContract("head")
+	if err != nil {
+		return 0, err
+	}
+
+	return (*ethereumABI.ConvertType(res[0], new(big.Int)).(*big.Int)).Uint64(), nil
+}
+
+// StateRootIndex returns the generalized index of the state root the proxy verifies branches against
+func (c *Spectre) StateRootIndex() (uint8, error) {
+	res, err := c.CallContract("STATE_ROOT_INDEX")
+	if err != nil {
+		return 0, err
+	}
+
+	return *ethereumABI.ConvertType(res[0], new(uint8)).(*uint8), nil
+}
+
+// DefaultAdminRole returns the role that administers the proxy
+func (c *Spectre) DefaultAdminRole() ([32]byte, error) {
+	res, err := c.CallContract("DEFAULT_ADMIN_ROLE")
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	return *ethereumABI.ConvertType(res[0], new([32]byte)).(*[32]byte), nil
+}
+
+// HasRole returns true if the account was granted the role
+func (c *Spectre) HasRole(role [32]byte, account common.Address) (bool, error) {
+	res, err := c.CallContract("hasRole", role, account)
+	if err != nil {
+		return false, err
+	}
+
+	return *ethereumABI.ConvertType(res[0], new(bool)).(*bool), nil
+}
+
+// GetRoleAdmin returns the role that administers the role
+func (c *Spectre) GetRoleAdmin(role [32]byte) ([32]byte, error) {
+	res, err := c.CallContract("getRoleAdmin", role)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	return *ethereumABI.ConvertType(res[0], new([32]byte)).(*[32]byte), nil
+}
+
+// GetRoleMemberCount returns the number of accounts granted the role
+func (c *Spectre) GetRoleMemberCount(role [32]byte) (uint64, error) {
+	res, err := c.CallContract("getRoleMemberCount", role)
+	if err != nil {
+		return 0, err
+	}
+
+	return (*ethereumABI.ConvertType(res[0], new(*big.Int)).(**big.Int)).Uint64(), nil
+}
+
+// GetRoleMember returns the account granted the role at the index
+func (c *Spectre) GetRoleMember(role [32]byte, index uint64) (common.Address, error) {
+	res, err := c.CallContract("getRoleMember", role, new(big.Int).SetUint64(index))
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return *ethereumABI.ConvertType(res[0], new(common.Address)).(*common.Address), nil
+}
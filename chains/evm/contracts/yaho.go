@@ -0,0 +1,40 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package contracts
+
+import (
+	"strings"
+
+	ethereumABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/sygmaprotocol/spectre-node/chains/evm/abi"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/listener/events"
+	"github.com/sygmaprotocol/sygma-core/chains/evm/client"
+	coreContracts "github.com/sygmaprotocol/sygma-core/chains/evm/contracts"
+)
+
+type Yaho struct {
+	coreContracts.Contract
+}
+
+func NewYahoContract(
+	address common.Address,
+	client client.Client,
+) *Yaho {
+	a, _ := ethereumABI.JSON(strings.NewReader(abi.YahoABI))
+	return &Yaho{
+		Contract: coreContracts.NewContract(address, a, nil, client, nil),
+	}
+}
+
+// CalculateMessageHash returns the hash Hashi adapters store for the message
+func (c *Yaho) CalculateMessageHash(message events.HashiMessage) ([32]byte, error) {
+	res, err := c.CallContract("calculateMessageHash", message)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	return *ethereumABI.ConvertType(res[0], new([32]byte)).(*[32]byte), nil
+}
@@ -0,0 +1,142 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package execution
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	evmMessage "github.com/sygmaprotocol/spectre-node/chains/evm/message"
+	consensus "github.com/umbracle/go-eth-consensus"
+)
+
+const (
+	// BODY_ROOT_INDEX is the generalized index of the body root in the beacon block header
+	BODY_ROOT_INDEX = 12
+	// EXECUTION_PAYLOAD_INDEX is the generalized index of the execution payload in the beacon block body
+	EXECUTION_PAYLOAD_INDEX = 25
+)
+
+// ProveFields returns the execution payload header fields with their branches to
+// the beacon block header root, which the light client stores for finalized slots.
+// Field branches to the execution payload root are extended with the light client
+// execution branch to the body root and the header branch to the header root.
+func ProveFields(header *consensus.LightClientHeaderDeneb, fields []string) ([]evmMessage.ExecutionField, error) {
+	executionNode, err := header.Execution.GetTree()
+	if err != nil {
+		return nil, err
+	}
+	headerNode, err := header.Header.GetTree()
+	if err != nil {
+		return nil, err
+	}
+	bodyRootProof, err := headerNode.Prove(BODY_ROOT_INDEX)
+	if err != nil {
+		return nil, err
+	}
+
+	executionFields := make([]evmMessage.ExecutionField, len(fields))
+	for i, field := range fields {
+		index, ok := FIELD_INDICES[field]
+		if !ok {
+			return nil, fmt.Errorf("invalid execution payload field %s", field)
+		}
+		proof, err := executionNode.Prove(index)
+		if err != nil {
+			return nil, err
+		}
+
+		branch := make([][]byte, 0, len(proof.Hashes)+len(header.ExecutionBranch)+len(bodyRootProof.Hashes))
+		branch = append(branch, proof.Hashes...)
+		for _, node := range header.ExecutionBranch {
+			branch = append(branch, common.CopyBytes(node[:]))
+		}
+		branch = append(branch, bodyRootProof.Hashes...)
+		executionFields[i] = evmMessage.ExecutionField{
+			Name:  field,
+			Value: common.BytesToHash(proof.Leaf),
+			Proof: branch,
+		}
+	}
+	return executionFields, nil
+}
+
+type RPCClient interface {
+	CallContext(ctx context.Context, target interface{}, rpcMethod string, args ...interface{}) error
+}
+
+type Prover struct {
+	client RPCClient
+}
+
+func NewProver(client RPCClient) *Prover {
+	return &Prover{
+		client: client,
+	}
+}
+
+type storageResult struct {
+	Value *hexutil.Big    `json:"value"`
+	Proof []hexutil.Bytes `json:"proof"`
+}
+
+type accountResult struct {
+	AccountProof []hexutil.Bytes `json:"accountProof"`
+	StorageProof []storageResult `json:"storageProof"`
+}
+
+// ProveAccounts fetches the account and storage proofs of the accounts at the execution block
+func (p *Prover) ProveAccounts(blockNumber uint64, accounts []*Account) ([]evmMessage.AccountProof, error) {
+	accountProofs := make([]evmMessage.AccountProof, len(accounts))
+	for i, account := range accounts {
+		slots := account.Slots
+		if slots == nil {
+			slots = []common.Hash{}
+		}
+
+		var result accountResult
+		err := p.client.CallContext(
+			context.Background(),
+			&result,
+			"eth_getProof",
+			account.Address,
+			slots,
+			hexutil.EncodeUint64(blockNumber))
+		if err != nil {
+			return nil, err
+		}
+		if len(result.StorageProof) != len(account.Slots) {
+			return nil, fmt.Errorf("expected %d storage proofs for account %s, got %d", len(account.Slots), account.Address, len(result.StorageProof))
+		}
+
+		storageProofs := make([]evmMessage.StorageProof, len(account.Slots))
+		for j, slot := range account.Slots {
+			var value common.Hash
+			if result.StorageProof[j].Value != nil {
+				value = common.BigToHash(result.StorageProof[j].Value.ToInt())
+			}
+			storageProofs[j] = evmMessage.StorageProof{
+				Key:   slot,
+				Value: value,
+				Proof: toBytes(result.StorageProof[j].Proof),
+			}
+		}
+		accountProofs[i] = evmMessage.AccountProof{
+			Account:       account.Address,
+			Proof:         toBytes(result.AccountProof),
+			StorageProofs: storageProofs,
+		}
+	}
+	return accountProofs, nil
+}
+
+func toBytes(nodes []hexutil.Bytes) [][]byte {
+	bytes := make([][]byte, len(nodes))
+	for i, node := range nodes {
+		bytes[i] = node
+	}
+	return bytes
+}
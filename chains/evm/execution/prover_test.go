@@ -0,0 +1,154 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package execution_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/execution"
+	evmMessage "github.com/sygmaprotocol/spectre-node/chains/evm/message"
+	"github.com/sygmaprotocol/spectre-node/mock"
+	consensus "github.com/umbracle/go-eth-consensus"
+	"go.uber.org/mock/gomock"
+)
+
+type ProverTestSuite struct {
+	suite.Suite
+
+	mockRPCClient *mock.MockRPCClient
+	prover        *execution.Prover
+}
+
+func TestRunProverTestSuite(t *testing.T) {
+	suite.Run(t, new(ProverTestSuite))
+}
+
+func (s *ProverTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockRPCClient = mock.NewMockRPCClient(ctrl)
+	s.prover = execution.NewProver(s.mockRPCClient)
+}
+
+func (s *ProverTestSuite) Test_ProveFields_InvalidField() {
+	_, err := execution.ProveFields(&consensus.LightClientHeaderDeneb{
+		Header:    &consensus.BeaconBlockHeader{},
+		Execution: &consensus.ExecutionPayloadHeaderDeneb{},
+	}, []string{"receipts"})
+
+	s.NotNil(err)
+}
+
+func (s *ProverTestSuite) Test_ProveFields_ValidBranches() {
+	executionHeader := &consensus.ExecutionPayloadHeaderDeneb{
+		ReceiptsRoot: [32]byte{1},
+		BlockHash:    [32]byte{2},
+		BlockNumber:  100,
+	}
+	executionRoot, err := executionHeader.HashTreeRoot()
+	s.Nil(err)
+	executionBranch := [4][32]byte{{3}, {4}, {5}, {6}}
+	header := &consensus.LightClientHeaderDeneb{
+		Header: &consensus.BeaconBlockHeader{
+			Slot:     64,
+			BodyRoot: branchRoot(executionRoot, executionBranch[:], execution.EXECUTION_PAYLOAD_INDEX),
+		},
+		Execution:       executionHeader,
+		ExecutionBranch: executionBranch,
+	}
+	headerRoot, err := header.Header.HashTreeRoot()
+	s.Nil(err)
+
+	fields, err := execution.ProveFields(header, []string{"receipts_root", "block_hash"})
+
+	s.Nil(err)
+	s.Len(fields, 2)
+	// field indices are nested under the execution payload of the body of the header
+	s.Equal(fields[0].Name, "receipts_root")
+	s.Equal(fields[0].Value, executionHeader.ReceiptsRoot)
+	s.True(verifyBranch(fields[0].Value, fields[0].Proof, 6435, headerRoot))
+	s.Equal(fields[1].Name, "block_hash")
+	s.Equal(fields[1].Value, executionHeader.BlockHash)
+	s.True(verifyBranch(fields[1].Value, fields[1].Proof, 6444, headerRoot))
+}
+
+func (s *ProverTestSuite) Test_ProveAccounts_RequestFails() {
+	s.mockRPCClient.EXPECT().CallContext(gomock.Any(), gomock.Any(), "eth_getProof", gomock.Any()).Return(fmt.Errorf("error"))
+
+	_, err := s.prover.ProveAccounts(100, []*execution.Account{{}})
+
+	s.NotNil(err)
+}
+
+func (s *ProverTestSuite) Test_ProveAccounts_MissingStorageProof() {
+	s.mockRPCClient.EXPECT().CallContext(gomock.Any(), gomock.Any(), "eth_getProof", gomock.Any()).DoAndReturn(
+		func(ctx context.Context, target interface{}, method string, args ...interface{}) error {
+			return json.Unmarshal([]byte(`{"accountProof":["0x01"],"storageProof":[]}`), target)
+		})
+
+	_, err := s.prover.ProveAccounts(100, []*execution.Account{{Slots: []common.Hash{common.HexToHash("0x1")}}})
+
+	s.NotNil(err)
+}
+
+func (s *ProverTestSuite) Test_ProveAccounts_ValidProofs() {
+	address := common.HexToAddress("0x5798a6d2a3a4d6e5a2b1e7d4e2d6b6c2d8c6a5b3")
+	slot := common.HexToHash("0x1")
+	s.mockRPCClient.EXPECT().CallContext(gomock.Any(), gomock.Any(), "eth_getProof", address, []common.Hash{slot}, "0x64").DoAndReturn(
+		func(ctx context.Context, target interface{}, method string, args ...interface{}) error {
+			return json.Unmarshal([]byte(`{
+				"accountProof":["0x0102","0x0304"],
+				"storageProof":[{"key":"0x1","value":"0x2a","proof":["0x05"]}]
+			}`), target)
+		})
+
+	proofs, err := s.prover.ProveAccounts(100, []*execution.Account{{Address: address, Slots: []common.Hash{slot}}})
+
+	s.Nil(err)
+	s.Equal(proofs, []evmMessage.AccountProof{
+		{
+			Account: address,
+			Proof:   [][]byte{{1, 2}, {3, 4}},
+			StorageProofs: []evmMessage.StorageProof{
+				{
+					Key:   slot,
+					Value: common.HexToHash("0x2a"),
+					Proof: [][]byte{{5}},
+				},
+			},
+		},
+	})
+}
+
+// verifyBranch checks the merkle branch of the leaf at the generalized index against the root
+func verifyBranch(leaf [32]byte, branch [][]byte, index uint64, root [32]byte) bool {
+	node := leaf
+	for _, sibling := range branch {
+		if index%2 == 0 {
+			node = sha256.Sum256(append(node[:], sibling...))
+		} else {
+			node = sha256.Sum256(append(sibling, node[:]...))
+		}
+		index /= 2
+	}
+	return index == 1 && node == root
+}
+
+func branchRoot(leaf [32]byte, branch [][32]byte, index uint64) [32]byte {
+	node := leaf
+	for _, sibling := range branch {
+		if index%2 == 0 {
+			node = sha256.Sum256(append(node[:], sibling[:]...))
+		} else {
+			node = sha256.Sum256(append(sibling[:], node[:]...))
+		}
+		index /= 2
+	}
+	return node
+}
@@ -0,0 +1,87 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package execution
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// FIELD_INDICES are the generalized indices of the deneb execution payload header fields
+var FIELD_INDICES = map[string]int{
+	"parent_hash":       32,
+	"fee_recipient":     33,
+	"state_root":        34,
+	"receipts_root":     35,
+	"logs_bloom":        36,
+	"prev_randao":       37,
+	"block_number":      38,
+	"gas_limit":         39,
+	"gas_used":          40,
+	"timestamp":         41,
+	"extra_data":        42,
+	"base_fee_per_gas":  43,
+	"block_hash":        44,
+	"transactions_root": 45,
+	"withdrawals_root":  46,
+	"blob_gas_used":     47,
+	"excess_blob_gas":   48,
+}
+
+// Account is a contract whose account and storage slots are proven
+// against the execution state root
+type Account struct {
+	Address common.Address
+	Slots   []common.Hash
+}
+
+// Targets are the execution payload fields and accounts proven
+// for a destination alongside a step
+type Targets struct {
+	Fields   []string
+	Accounts []*Account
+}
+
+// Empty returns true if there is nothing to prove besides the state root
+func (t *Targets) Empty() bool {
+	return len(t.Fields) == 0 && len(t.Accounts) == 0
+}
+
+// ParseTargets parses execution payload field names and storage proof targets.
+// Storage proof targets are formatted as "<address>" to prove only the account
+// or "<address>:<slot>" to prove a storage slot of the account.
+func ParseTargets(fields []string, storageProofs []string) (*Targets, error) {
+	targets := &Targets{}
+	for _, field := range fields {
+		if _, ok := FIELD_INDICES[field]; !ok {
+			return nil, fmt.Errorf("invalid execution payload field %s", field)
+		}
+		targets.Fields = append(targets.Fields, field)
+	}
+
+	accounts := make(map[common.Address]*Account)
+	for _, target := range storageProofs {
+		address, slot, hasSlot := strings.Cut(target, ":")
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("invalid storage proof address %s", address)
+		}
+
+		account, ok := accounts[common.HexToAddress(address)]
+		if !ok {
+			account = &Account{Address: common.HexToAddress(address)}
+			accounts[account.Address] = account
+			targets.Accounts = append(targets.Accounts, account)
+		}
+		if !hasSlot {
+			continue
+		}
+		if !strings.HasPrefix(slot, "0x") || len(slot) > 66 {
+			return nil, fmt.Errorf("invalid storage proof slot %s", slot)
+		}
+		account.Slots = append(account.Slots, common.HexToHash(slot))
+	}
+	return targets, nil
+}
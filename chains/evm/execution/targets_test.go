@@ -0,0 +1,70 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package execution_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/execution"
+)
+
+type TargetsTestSuite struct {
+	suite.Suite
+}
+
+func TestRunTargetsTestSuite(t *testing.T) {
+	suite.Run(t, new(TargetsTestSuite))
+}
+
+func (s *TargetsTestSuite) Test_ParseTargets_InvalidField() {
+	_, err := execution.ParseTargets([]string{"receipts"}, []string{})
+
+	s.NotNil(err)
+}
+
+func (s *TargetsTestSuite) Test_ParseTargets_InvalidAddress() {
+	_, err := execution.ParseTargets([]string{}, []string{"0x1234:0x1"})
+
+	s.NotNil(err)
+}
+
+func (s *TargetsTestSuite) Test_ParseTargets_InvalidSlot() {
+	_, err := execution.ParseTargets([]string{}, []string{"0x5798a6d2a3a4d6e5a2b1e7d4e2d6b6c2d8c6a5b3:1"})
+
+	s.NotNil(err)
+}
+
+func (s *TargetsTestSuite) Test_ParseTargets_Empty() {
+	targets, err := execution.ParseTargets(nil, nil)
+
+	s.Nil(err)
+	s.True(targets.Empty())
+}
+
+func (s *TargetsTestSuite) Test_ParseTargets_GroupsSlotsByAccount() {
+	targets, err := execution.ParseTargets(
+		[]string{"receipts_root", "block_hash"},
+		[]string{
+			"0x5798a6d2a3a4d6e5a2b1e7d4e2d6b6c2d8c6a5b3:0x1",
+			"0x1b2a8e7d4e2d6b6c2d8c6a5b35798a6d2a3a4d6e",
+			"0x5798a6d2a3a4d6e5a2b1e7d4e2d6b6c2d8c6a5b3:0x2",
+		})
+
+	s.Nil(err)
+	s.False(targets.Empty())
+	s.Equal(targets, &execution.Targets{
+		Fields: []string{"receipts_root", "block_hash"},
+		Accounts: []*execution.Account{
+			{
+				Address: common.HexToAddress("0x5798a6d2a3a4d6e5a2b1e7d4e2d6b6c2d8c6a5b3"),
+				Slots:   []common.Hash{common.HexToHash("0x1"), common.HexToHash("0x2")},
+			},
+			{
+				Address: common.HexToAddress("0x1b2a8e7d4e2d6b6c2d8c6a5b35798a6d2a3a4d6e"),
+			},
+		},
+	})
+}
@@ -4,15 +4,22 @@
 package executor
 
 import (
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rs/zerolog/log"
 	"github.com/sygmaprotocol/spectre-node/chains/evm/message"
+	"github.com/sygmaprotocol/spectre-node/store"
 	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor"
+	coreMessage "github.com/sygmaprotocol/sygma-core/relayer/message"
 	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
 )
 
+// BLOCK_HEADER_TRANSACTION is the transaction type of block headers stored on the Hashi adapter
+const BLOCK_HEADER_TRANSACTION proposal.ProposalType = "HashiBlockHeader"
+
 type ProofSubmitter interface {
 	Step(
 		domainID uint8,
@@ -31,16 +38,69 @@ type ProofSubmitter interface {
 	) (*common.Hash, error)
 }
 
+type ExecutionProofSubmitter interface {
+	ReceiveExecutionProofs(
+		domainID uint8,
+		slot uint64,
+		fields []message.ExecutionField,
+		accountProofs []message.AccountProof,
+		opts transactor.TransactOptions,
+	) (*common.Hash, error)
+}
+
+type BlockHeaderStorer interface {
+	StoreBlockHeader(
+		domainID uint8,
+		slot uint64,
+		header message.BlockHeader,
+		opts transactor.TransactOptions,
+	) (*common.Hash, error)
+}
+
+type MessageDeliverer interface {
+	DeliverMessages(sourceDomainID uint8, destinationDomainID uint8, delivery *store.Delivery) ([]*store.Message, error)
+}
+
+type SubmissionStorer interface {
+	StoreSubmission(destinationDomainID uint8, submission *store.Submission) error
+	Submission(destinationDomainID uint8, proofType store.ProofType, sourceDomainID uint8, slot uint64) (*store.Submission, error)
+}
+
+// MAX_TRANSIENT_ATTEMPTS is how many times a transaction is sent before a transient failure is returned
+const MAX_TRANSIENT_ATTEMPTS = 3
+
 type EVMExecutor struct {
-	domainID uint8
+	domainID      uint8
+	retryInterval time.Duration
 
-	proofSubmitter ProofSubmitter
+	proofSubmitter          ProofSubmitter
+	executionProofSubmitter ExecutionProofSubmitter
+	blockHeaderStorer       BlockHeaderStorer
+	submissionStorer        SubmissionStorer
+	messageDeliverer        MessageDeliverer
 }
 
-func NewEVMExecutor(domainID uint8, proofSubmitter ProofSubmitter) *EVMExecutor {
+// NewEVMExecutor creates the destination executor. The execution proof submitter
+// is optional and only required if the domain receives execution steps. The block
+// header storer is optional and stores finalized block headers on a Hashi adapter after steps.
+// Transactions failing with transient errors are retried with a backoff that doubles from the retry interval.
+func NewEVMExecutor(
+	domainID uint8,
+	proofSubmitter ProofSubmitter,
+	executionProofSubmitter ExecutionProofSubmitter,
+	blockHeaderStorer BlockHeaderStorer,
+	submissionStorer SubmissionStorer,
+	messageDeliverer MessageDeliverer,
+	retryInterval time.Duration,
+) *EVMExecutor {
 	return &EVMExecutor{
-		proofSubmitter: proofSubmitter,
-		domainID:       domainID,
+		proofSubmitter:          proofSubmitter,
+		executionProofSubmitter: executionProofSubmitter,
+		blockHeaderStorer:       blockHeaderStorer,
+		submissionStorer:        submissionStorer,
+		messageDeliverer:        messageDeliverer,
+		domainID:                domainID,
+		retryInterval:           retryInterval,
 	}
 }
 
@@ -51,39 +111,223 @@ func (e *EVMExecutor) Execute(props []*proposal.Proposal) error {
 		return e.rotate(prop.Source, rotateData)
 	case message.EVMStepProposal:
 		stepData := prop.Data.(message.StepData)
-		return e.step(prop.Source, stepData)
+		return e.step(prop.Source, stepData, message.EVMStepMessage)
+	case message.EVMExecutionStepProposal:
+		stepData := prop.Data.(message.StepData)
+		return e.executionStep(prop.Source, stepData)
 	default:
 		return fmt.Errorf("no executor configured for prop type %s", prop.Type)
 	}
 }
 
-func (e *EVMExecutor) step(domainID uint8, stepData message.StepData) error {
-	hash, err := e.proofSubmitter.Step(
-		domainID,
-		stepData.Args,
-		stepData.Proof,
-		stepData.StateRoot,
-		stepData.StateRootProof,
-		transactor.TransactOptions{})
+// step submits the step and stores the block header after it. A step that was
+// already sent for the slot isn't sent again, so a message retried because
+// a follow-up transaction failed only resends the follow-up.
+func (e *EVMExecutor) step(domainID uint8, stepData message.StepData, messageType coreMessage.MessageType) error {
+	if hash, ok := e.sentStep(domainID, stepData.Args.FinalizedSlot); ok {
+		log.Info().Uint8("domainID", e.domainID).Msgf("EVM step for slot %d already sent with hash: %s", stepData.Args.FinalizedSlot, hash)
+		return e.storeBlockHeader(domainID, stepData, hash)
+	}
+
+	hash, err := e.transact(message.EVMStepProposal, domainID, stepData.Args.FinalizedSlot, func() (*common.Hash, error) {
+		return e.proofSubmitter.Step(
+			domainID,
+			stepData.Args,
+			stepData.Proof,
+			stepData.StateRoot,
+			stepData.StateRootProof,
+			transactor.TransactOptions{})
+	})
 	if err != nil {
+		e.storeFailedSubmission(store.StepProofType, domainID, stepData.Args.FinalizedSlot, err)
 		return err
 	}
 
 	log.Info().Uint8("domainID", e.domainID).Msgf("Sent EVM step with hash: %s", hash)
+	e.storeSubmission(store.StepProofType, domainID, stepData.Args.FinalizedSlot, hash, messageType, stepData)
+	return e.storeBlockHeader(domainID, stepData, hash)
+}
+
+// sentStep returns the hash of the step sent for the slot unless it failed
+// or was retried after its transaction reverted or was dropped
+func (e *EVMExecutor) sentStep(sourceDomainID uint8, slot uint64) (*common.Hash, bool) {
+	submission, err := e.submissionStorer.Submission(e.domainID, store.StepProofType, sourceDomainID, slot)
+	if err != nil {
+		return nil, false
+	}
+	if submission.Status != store.PendingSubmission && submission.Status != store.ConfirmedSubmission {
+		return nil, false
+	}
+
+	hash := common.HexToHash(submission.TxHash)
+	return &hash, true
+}
+
+// storeBlockHeader stores the finalized block header on the Hashi adapter, if one
+// is configured, and records the delivery of messages dispatched up to the block
+func (e *EVMExecutor) storeBlockHeader(domainID uint8, stepData message.StepData, stepHash *common.Hash) error {
+	if stepData.BlockHeader == nil {
+		return nil
+	}
+
+	hash := stepHash
+	if e.blockHeaderStorer != nil {
+		var err error
+		hash, err = e.transact(BLOCK_HEADER_TRANSACTION, domainID, stepData.Args.FinalizedSlot, func() (*common.Hash, error) {
+			return e.blockHeaderStorer.StoreBlockHeader(
+				domainID,
+				stepData.Args.FinalizedSlot,
+				*stepData.BlockHeader,
+				transactor.TransactOptions{})
+		})
+		if err != nil {
+			return err
+		}
+
+		log.Info().Uint8("domainID", e.domainID).Msgf("Sent Hashi block header %d with hash: %s", stepData.BlockHeader.Number, hash)
+	}
+
+	messages, err := e.messageDeliverer.DeliverMessages(domainID, e.domainID, &store.Delivery{
+		Slot:        stepData.Args.FinalizedSlot,
+		BlockNumber: stepData.BlockHeader.Number,
+		TxHash:      hash.Hex(),
+		DeliveredAt: time.Now(),
+	})
+	if err != nil {
+		log.Warn().Err(err).Uint8("domainID", e.domainID).Msgf("Unable to record delivery of messages up to block %d", stepData.BlockHeader.Number)
+		return nil
+	}
+	for _, m := range messages {
+		if m.Type == store.DepositMessageType {
+			log.Info().Uint8("domainID", e.domainID).Msgf("Delivered deposit %s from domain %d", m.ID, domainID)
+			continue
+		}
+		log.Info().Uint8("domainID", e.domainID).Msgf("Delivered Hashi message %s with hash %s from domain %d", m.ID, m.Hash, domainID)
+	}
+	return nil
+}
+
+// executionStep submits the step and then the execution proofs that are
+// verified against the finalized header root stored by the step. Retries
+// of the message skip the step if it was already sent.
+func (e *EVMExecutor) executionStep(domainID uint8, stepData message.StepData) error {
+	if e.executionProofSubmitter == nil {
+		return fmt.Errorf("no execution receiver configured for domain %d", e.domainID)
+	}
+
+	err := e.step(domainID, stepData, message.EVMExecutionStepMessage)
+	if err != nil {
+		return err
+	}
+
+	hash, err := e.transact(message.EVMExecutionStepProposal, domainID, stepData.Args.FinalizedSlot, func() (*common.Hash, error) {
+		return e.executionProofSubmitter.ReceiveExecutionProofs(
+			domainID,
+			stepData.Args.FinalizedSlot,
+			stepData.ExecutionFields,
+			stepData.AccountProofs,
+			transactor.TransactOptions{})
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Info().Uint8("domainID", e.domainID).Msgf("Sent EVM execution proofs with hash: %s", hash)
 	return nil
 }
 
 func (e *EVMExecutor) rotate(domainID uint8, rotateData message.RotateData) error {
-	hash, err := e.proofSubmitter.Rotate(
-		domainID,
-		rotateData.RotateProof,
-		rotateData.StepInput,
-		rotateData.StepProof,
-		transactor.TransactOptions{})
+	hash, err := e.transact(message.EVMRotateProposal, domainID, rotateData.StepInput.FinalizedSlot, func() (*common.Hash, error) {
+		return e.proofSubmitter.Rotate(
+			domainID,
+			rotateData.RotateProof,
+			rotateData.StepInput,
+			rotateData.StepProof,
+			transactor.TransactOptions{})
+	})
 	if err != nil {
+		e.storeFailedSubmission(store.RotateProofType, domainID, rotateData.StepInput.FinalizedSlot, err)
 		return err
 	}
 
 	log.Info().Uint8("domainID", e.domainID).Msgf("Sent EVM rotate with hash: %s", hash)
+	e.storeSubmission(store.RotateProofType, domainID, rotateData.StepInput.FinalizedSlot, hash, message.EVMRotateMessage, rotateData)
 	return nil
 }
+
+// storeSubmission records the submitted proof so it can be reconciled
+// with the Spectre contract events and its receipt. The message data is
+// kept so the message can be delivered again if the transaction fails.
+func (e *EVMExecutor) storeSubmission(
+	proofType store.ProofType,
+	sourceDomainID uint8,
+	slot uint64,
+	hash *common.Hash,
+	messageType coreMessage.MessageType,
+	messageData interface{},
+) {
+	data, err := json.Marshal(messageData)
+	if err != nil {
+		log.Warn().Err(err).Uint8("domainID", e.domainID).Msgf("Unable to encode %s message for slot %d", proofType, slot)
+	}
+
+	err = e.submissionStorer.StoreSubmission(e.domainID, &store.Submission{
+		Type:         proofType,
+		SourceDomain: sourceDomainID,
+		Slot:         slot,
+		TxHash:       hash.Hex(),
+		SubmittedAt:  time.Now(),
+		Status:       store.PendingSubmission,
+		MessageType:  string(messageType),
+		Data:         data,
+	})
+	if err != nil {
+		log.Warn().Err(err).Uint8("domainID", e.domainID).Msgf("Unable to store %s submission for slot %d", proofType, slot)
+	}
+}
+
+// transact sends the transaction and retries transient failures with a backoff.
+// Failures are returned as classified execution errors.
+func (e *EVMExecutor) transact(txType proposal.ProposalType, sourceDomainID uint8, slot uint64, send func() (*common.Hash, error)) (*common.Hash, error) {
+	log := log.With().Uint8("domainID", e.domainID).Uint8("source", sourceDomainID).Uint64("slot", slot).Str("type", string(txType)).Logger()
+	backoff := e.retryInterval
+	for attempt := 1; ; attempt++ {
+		hash, err := send()
+		if err == nil {
+			return hash, nil
+		}
+
+		executionErr := ClassifyError(err)
+		if executionErr.Kind == TransientFailure && attempt < MAX_TRANSIENT_ATTEMPTS {
+			log.Warn().Err(err).Int("attempt", attempt).Msgf("Unable to send transaction, retrying in %s", backoff)
+			time.Sleep(backoff)
+			backoff *= 2
+			continue
+		}
+
+		log.Error().Err(executionErr.Err).Str("failure", string(executionErr.Kind)).Str("reason", executionErr.Reason).Int("attempts", attempt).Msgf("Failed sending transaction")
+		return nil, executionErr
+	}
+}
+
+// storeFailedSubmission records proofs that failed with a nonce, gas or revert
+// failure so operators can inspect them with the submissions
+func (e *EVMExecutor) storeFailedSubmission(proofType store.ProofType, sourceDomainID uint8, slot uint64, err error) {
+	executionErr := ClassifyError(err)
+	if executionErr.Kind == TransientFailure {
+		return
+	}
+
+	storeErr := e.submissionStorer.StoreSubmission(e.domainID, &store.Submission{
+		Type:         proofType,
+		SourceDomain: sourceDomainID,
+		Slot:         slot,
+		SubmittedAt:  time.Now(),
+		Status:       store.FailedSubmission,
+		Failure:      string(executionErr.Kind),
+		Error:        executionErr.Error(),
+	})
+	if storeErr != nil {
+		log.Warn().Err(storeErr).Uint8("domainID", e.domainID).Msgf("Unable to store failed %s submission for slot %d", proofType, slot)
+	}
+}
@@ -6,21 +6,28 @@ package executor_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/suite"
 	"github.com/sygmaprotocol/spectre-node/chains/evm/executor"
 	"github.com/sygmaprotocol/spectre-node/chains/evm/message"
 	"github.com/sygmaprotocol/spectre-node/mock"
+	"github.com/sygmaprotocol/spectre-node/store"
 	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
+	"github.com/syndtr/goleveldb/leveldb"
 	"go.uber.org/mock/gomock"
 )
 
 type ExecutorTestSuite struct {
 	suite.Suite
 
-	mockProofSubmitter *mock.MockProofSubmitter
-	executor           *executor.EVMExecutor
+	mockProofSubmitter          *mock.MockProofSubmitter
+	mockExecutionProofSubmitter *mock.MockExecutionProofSubmitter
+	mockBlockHeaderStorer       *mock.MockBlockHeaderStorer
+	mockSubmissionStorer        *mock.MockSubmissionStorer
+	mockMessageDeliverer        *mock.MockMessageDeliverer
+	executor                    *executor.EVMExecutor
 }
 
 func TestRunStepTestSuite(t *testing.T) {
@@ -30,7 +37,19 @@ func TestRunStepTestSuite(t *testing.T) {
 func (s *ExecutorTestSuite) SetupTest() {
 	ctrl := gomock.NewController(s.T())
 	s.mockProofSubmitter = mock.NewMockProofSubmitter(ctrl)
-	s.executor = executor.NewEVMExecutor(1, s.mockProofSubmitter)
+	s.mockExecutionProofSubmitter = mock.NewMockExecutionProofSubmitter(ctrl)
+	s.mockBlockHeaderStorer = mock.NewMockBlockHeaderStorer(ctrl)
+	s.mockSubmissionStorer = mock.NewMockSubmissionStorer(ctrl)
+	s.mockMessageDeliverer = mock.NewMockMessageDeliverer(ctrl)
+	s.executor = executor.NewEVMExecutor(
+		1,
+		s.mockProofSubmitter,
+		s.mockExecutionProofSubmitter,
+		s.mockBlockHeaderStorer,
+		s.mockSubmissionStorer,
+		s.mockMessageDeliverer,
+		time.Millisecond,
+	)
 }
 
 func (s *ExecutorTestSuite) Test_Execute_InvalidPropType() {
@@ -42,7 +61,8 @@ func (s *ExecutorTestSuite) Test_Execute_InvalidPropType() {
 }
 
 func (s *ExecutorTestSuite) Test_Execute_Step_SubmissionFails() {
-	s.mockProofSubmitter.EXPECT().Step(uint8(1), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+	s.mockSubmissionStorer.EXPECT().Submission(uint8(1), store.StepProofType, uint8(1), gomock.Any()).Return(nil, leveldb.ErrNotFound)
+	s.mockProofSubmitter.EXPECT().Step(uint8(1), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error")).Times(executor.MAX_TRANSIENT_ATTEMPTS)
 
 	err := s.executor.Execute([]*proposal.Proposal{{
 		Data:   message.StepData{},
@@ -54,10 +74,23 @@ func (s *ExecutorTestSuite) Test_Execute_Step_SubmissionFails() {
 }
 
 func (s *ExecutorTestSuite) Test_Execute_Step_Successful() {
+	s.mockSubmissionStorer.EXPECT().Submission(uint8(1), store.StepProofType, uint8(1), gomock.Any()).Return(nil, leveldb.ErrNotFound)
 	s.mockProofSubmitter.EXPECT().Step(uint8(1), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&common.Hash{}, nil)
+	s.mockSubmissionStorer.EXPECT().StoreSubmission(uint8(1), gomock.Any()).DoAndReturn(func(domainID uint8, submission *store.Submission) error {
+		s.Equal(submission.Type, store.StepProofType)
+		s.Equal(submission.Slot, uint64(100))
+		s.Equal(submission.Status, store.PendingSubmission)
+		s.Equal(submission.MessageType, string(message.EVMStepMessage))
+		s.NotEmpty(submission.Data)
+		return nil
+	})
 
 	err := s.executor.Execute([]*proposal.Proposal{{
-		Data:   message.StepData{},
+		Data: message.StepData{
+			Args: message.SyncStepInput{
+				FinalizedSlot: 100,
+			},
+		},
 		Type:   message.EVMStepProposal,
 		Source: 1,
 	}})
@@ -66,7 +99,7 @@ func (s *ExecutorTestSuite) Test_Execute_Step_Successful() {
 }
 
 func (s *ExecutorTestSuite) Test_Execute_Rotate_SubmissionFails() {
-	s.mockProofSubmitter.EXPECT().Rotate(uint8(1), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+	s.mockProofSubmitter.EXPECT().Rotate(uint8(1), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error")).Times(executor.MAX_TRANSIENT_ATTEMPTS)
 
 	err := s.executor.Execute([]*proposal.Proposal{{
 		Data:   message.RotateData{},
@@ -77,8 +110,70 @@ func (s *ExecutorTestSuite) Test_Execute_Rotate_SubmissionFails() {
 	s.NotNil(err)
 }
 
+func (s *ExecutorTestSuite) Test_Execute_Step_TransientFailureRetried() {
+	s.mockSubmissionStorer.EXPECT().Submission(uint8(1), store.StepProofType, uint8(1), gomock.Any()).Return(nil, leveldb.ErrNotFound)
+	s.mockProofSubmitter.EXPECT().Step(uint8(1), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("connection refused"))
+	s.mockProofSubmitter.EXPECT().Step(uint8(1), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&common.Hash{}, nil)
+	s.mockSubmissionStorer.EXPECT().StoreSubmission(uint8(1), gomock.Any()).Return(nil)
+
+	err := s.executor.Execute([]*proposal.Proposal{{
+		Data:   message.StepData{},
+		Type:   message.EVMStepProposal,
+		Source: 1,
+	}})
+
+	s.Nil(err)
+}
+
+func (s *ExecutorTestSuite) Test_Execute_Step_NonceFailure_RecordedWithoutRetry() {
+	s.mockSubmissionStorer.EXPECT().Submission(uint8(1), store.StepProofType, uint8(2), gomock.Any()).Return(nil, leveldb.ErrNotFound)
+	s.mockProofSubmitter.EXPECT().Step(uint8(2), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("nonce too low"))
+	s.mockSubmissionStorer.EXPECT().StoreSubmission(uint8(1), gomock.Any()).DoAndReturn(func(domainID uint8, submission *store.Submission) error {
+		s.Equal(submission.Type, store.StepProofType)
+		s.Equal(submission.SourceDomain, uint8(2))
+		s.Equal(submission.Slot, uint64(100))
+		s.Equal(submission.Status, store.FailedSubmission)
+		s.Equal(submission.Failure, string(executor.NonceGasFailure))
+		return nil
+	})
+
+	err := s.executor.Execute([]*proposal.Proposal{{
+		Data: message.StepData{
+			Args: message.SyncStepInput{FinalizedSlot: 100},
+		},
+		Type:   message.EVMStepProposal,
+		Source: 2,
+	}})
+
+	executionErr := &executor.ExecutionError{}
+	s.ErrorAs(err, &executionErr)
+	s.False(executionErr.Permanent())
+}
+
+func (s *ExecutorTestSuite) Test_Execute_Rotate_Revert_RecordedAsPermanent() {
+	s.mockProofSubmitter.EXPECT().Rotate(uint8(1), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("execution reverted"))
+	s.mockSubmissionStorer.EXPECT().StoreSubmission(uint8(1), gomock.Any()).DoAndReturn(func(domainID uint8, submission *store.Submission) error {
+		s.Equal(submission.Type, store.RotateProofType)
+		s.Equal(submission.Status, store.FailedSubmission)
+		s.Equal(submission.Failure, string(executor.RevertFailure))
+		s.Equal(submission.Error, "revert failure: execution reverted")
+		return nil
+	})
+
+	err := s.executor.Execute([]*proposal.Proposal{{
+		Data:   message.RotateData{},
+		Type:   message.EVMRotateProposal,
+		Source: 1,
+	}})
+
+	executionErr := &executor.ExecutionError{}
+	s.ErrorAs(err, &executionErr)
+	s.True(executionErr.Permanent())
+}
+
 func (s *ExecutorTestSuite) Test_Execute_Rotate_Successful() {
 	s.mockProofSubmitter.EXPECT().Rotate(uint8(1), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&common.Hash{}, nil)
+	s.mockSubmissionStorer.EXPECT().StoreSubmission(uint8(1), gomock.Any()).Return(nil)
 
 	err := s.executor.Execute([]*proposal.Proposal{{
 		Data:   message.RotateData{},
@@ -88,3 +183,189 @@ func (s *ExecutorTestSuite) Test_Execute_Rotate_Successful() {
 
 	s.Nil(err)
 }
+
+func (s *ExecutorTestSuite) Test_Execute_ExecutionStep_NoReceiver() {
+	e := executor.NewEVMExecutor(1, s.mockProofSubmitter, nil, nil, s.mockSubmissionStorer, s.mockMessageDeliverer, time.Millisecond)
+
+	err := e.Execute([]*proposal.Proposal{{
+		Data:   message.StepData{},
+		Type:   message.EVMExecutionStepProposal,
+		Source: 1,
+	}})
+
+	s.NotNil(err)
+}
+
+func (s *ExecutorTestSuite) Test_Execute_ExecutionStep_StepFails() {
+	s.mockSubmissionStorer.EXPECT().Submission(uint8(1), store.StepProofType, uint8(1), gomock.Any()).Return(nil, leveldb.ErrNotFound)
+	s.mockProofSubmitter.EXPECT().Step(uint8(1), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error")).Times(executor.MAX_TRANSIENT_ATTEMPTS)
+
+	err := s.executor.Execute([]*proposal.Proposal{{
+		Data:   message.StepData{},
+		Type:   message.EVMExecutionStepProposal,
+		Source: 1,
+	}})
+
+	s.NotNil(err)
+}
+
+func (s *ExecutorTestSuite) Test_Execute_ExecutionStep_Successful() {
+	fields := []message.ExecutionField{{Name: "receipts_root", Value: [32]byte{1}}}
+	accountProofs := []message.AccountProof{{Account: common.HexToAddress("0x5798a6d2a3a4d6e5a2b1e7d4e2d6b6c2d8c6a5b3")}}
+	s.mockSubmissionStorer.EXPECT().Submission(uint8(1), store.StepProofType, uint8(1), gomock.Any()).Return(nil, leveldb.ErrNotFound)
+	s.mockProofSubmitter.EXPECT().Step(uint8(1), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&common.Hash{}, nil)
+	s.mockSubmissionStorer.EXPECT().StoreSubmission(uint8(1), gomock.Any()).Return(nil)
+	s.mockExecutionProofSubmitter.EXPECT().ReceiveExecutionProofs(uint8(1), uint64(100), fields, accountProofs, gomock.Any()).Return(&common.Hash{}, nil)
+
+	err := s.executor.Execute([]*proposal.Proposal{{
+		Data: message.StepData{
+			Args: message.SyncStepInput{
+				FinalizedSlot: 100,
+			},
+			ExecutionFields: fields,
+			AccountProofs:   accountProofs,
+		},
+		Type:   message.EVMExecutionStepProposal,
+		Source: 1,
+	}})
+
+	s.Nil(err)
+}
+
+func (s *ExecutorTestSuite) Test_Execute_Step_StoreBlockHeaderFails() {
+	s.mockSubmissionStorer.EXPECT().Submission(uint8(1), store.StepProofType, uint8(1), gomock.Any()).Return(nil, leveldb.ErrNotFound)
+	s.mockProofSubmitter.EXPECT().Step(uint8(1), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&common.Hash{}, nil)
+	s.mockSubmissionStorer.EXPECT().StoreSubmission(uint8(1), gomock.Any()).Return(nil)
+	s.mockBlockHeaderStorer.EXPECT().StoreBlockHeader(uint8(1), uint64(100), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error")).Times(executor.MAX_TRANSIENT_ATTEMPTS)
+
+	err := s.executor.Execute([]*proposal.Proposal{{
+		Data: message.StepData{
+			Args: message.SyncStepInput{
+				FinalizedSlot: 100,
+			},
+			BlockHeader: &message.BlockHeader{Number: 1000},
+		},
+		Type:   message.EVMStepProposal,
+		Source: 1,
+	}})
+
+	s.NotNil(err)
+}
+
+func (s *ExecutorTestSuite) Test_Execute_Step_BlockHeaderStored() {
+	header := message.BlockHeader{Number: 1000, Hash: [32]byte{1}}
+	s.mockSubmissionStorer.EXPECT().Submission(uint8(1), store.StepProofType, uint8(1), gomock.Any()).Return(nil, leveldb.ErrNotFound)
+	s.mockProofSubmitter.EXPECT().Step(uint8(1), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&common.Hash{}, nil)
+	s.mockSubmissionStorer.EXPECT().StoreSubmission(uint8(1), gomock.Any()).Return(nil)
+	s.mockBlockHeaderStorer.EXPECT().StoreBlockHeader(uint8(1), uint64(100), header, gomock.Any()).Return(&common.Hash{2}, nil)
+	s.mockMessageDeliverer.EXPECT().DeliverMessages(uint8(1), uint8(1), gomock.Any()).DoAndReturn(func(sourceDomainID uint8, destinationDomainID uint8, delivery *store.Delivery) ([]*store.Message, error) {
+		s.Equal(delivery.BlockNumber, uint64(1000))
+		s.Equal(delivery.Slot, uint64(100))
+		s.Equal(delivery.TxHash, common.Hash{2}.Hex())
+		return []*store.Message{{ID: "1"}}, nil
+	})
+
+	err := s.executor.Execute([]*proposal.Proposal{{
+		Data: message.StepData{
+			Args: message.SyncStepInput{
+				FinalizedSlot: 100,
+			},
+			BlockHeader: &header,
+		},
+		Type:   message.EVMStepProposal,
+		Source: 1,
+	}})
+
+	s.Nil(err)
+}
+
+func (s *ExecutorTestSuite) Test_Execute_Step_NoHashiAdapter_MessagesDeliveredWithStep() {
+	e := executor.NewEVMExecutor(1, s.mockProofSubmitter, nil, nil, s.mockSubmissionStorer, s.mockMessageDeliverer, time.Millisecond)
+	s.mockSubmissionStorer.EXPECT().Submission(uint8(1), store.StepProofType, uint8(1), gomock.Any()).Return(nil, leveldb.ErrNotFound)
+	s.mockProofSubmitter.EXPECT().Step(uint8(1), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&common.Hash{3}, nil)
+	s.mockSubmissionStorer.EXPECT().StoreSubmission(uint8(1), gomock.Any()).Return(nil)
+	s.mockMessageDeliverer.EXPECT().DeliverMessages(uint8(1), uint8(1), gomock.Any()).DoAndReturn(func(sourceDomainID uint8, destinationDomainID uint8, delivery *store.Delivery) ([]*store.Message, error) {
+		s.Equal(delivery.TxHash, common.Hash{3}.Hex())
+		return []*store.Message{}, nil
+	})
+
+	err := e.Execute([]*proposal.Proposal{{
+		Data: message.StepData{
+			Args: message.SyncStepInput{
+				FinalizedSlot: 100,
+			},
+			BlockHeader: &message.BlockHeader{Number: 1000},
+		},
+		Type:   message.EVMStepProposal,
+		Source: 1,
+	}})
+
+	s.Nil(err)
+}
+
+func (s *ExecutorTestSuite) Test_Execute_Step_AlreadySent_OnlyBlockHeaderSent() {
+	header := message.BlockHeader{Number: 1000, Hash: [32]byte{1}}
+	s.mockSubmissionStorer.EXPECT().Submission(uint8(1), store.StepProofType, uint8(1), uint64(100)).Return(&store.Submission{
+		TxHash: common.Hash{3}.Hex(),
+		Status: store.PendingSubmission,
+	}, nil)
+	s.mockBlockHeaderStorer.EXPECT().StoreBlockHeader(uint8(1), uint64(100), header, gomock.Any()).Return(&common.Hash{2}, nil)
+	s.mockMessageDeliverer.EXPECT().DeliverMessages(uint8(1), uint8(1), gomock.Any()).Return([]*store.Message{}, nil)
+
+	err := s.executor.Execute([]*proposal.Proposal{{
+		Data: message.StepData{
+			Args: message.SyncStepInput{
+				FinalizedSlot: 100,
+			},
+			BlockHeader: &header,
+		},
+		Type:   message.EVMStepProposal,
+		Source: 1,
+	}})
+
+	s.Nil(err)
+}
+
+func (s *ExecutorTestSuite) Test_Execute_Step_RetriedSubmission_StepSentAgain() {
+	s.mockSubmissionStorer.EXPECT().Submission(uint8(1), store.StepProofType, uint8(1), uint64(100)).Return(&store.Submission{
+		TxHash: common.Hash{3}.Hex(),
+		Status: store.RetriedSubmission,
+	}, nil)
+	s.mockProofSubmitter.EXPECT().Step(uint8(1), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&common.Hash{4}, nil)
+	s.mockSubmissionStorer.EXPECT().StoreSubmission(uint8(1), gomock.Any()).DoAndReturn(func(domainID uint8, submission *store.Submission) error {
+		s.Equal(submission.TxHash, common.Hash{4}.Hex())
+		return nil
+	})
+
+	err := s.executor.Execute([]*proposal.Proposal{{
+		Data: message.StepData{
+			Args: message.SyncStepInput{
+				FinalizedSlot: 100,
+			},
+		},
+		Type:   message.EVMStepProposal,
+		Source: 1,
+	}})
+
+	s.Nil(err)
+}
+
+func (s *ExecutorTestSuite) Test_Execute_ExecutionStep_AlreadySent_OnlyExecutionProofsSent() {
+	s.mockSubmissionStorer.EXPECT().Submission(uint8(1), store.StepProofType, uint8(1), uint64(100)).Return(&store.Submission{
+		TxHash: common.Hash{3}.Hex(),
+		Status: store.ConfirmedSubmission,
+	}, nil)
+	s.mockExecutionProofSubmitter.EXPECT().ReceiveExecutionProofs(uint8(1), uint64(100), gomock.Any(), gomock.Any(), gomock.Any()).Return(&common.Hash{}, nil)
+
+	err := s.executor.Execute([]*proposal.Proposal{{
+		Data: message.StepData{
+			Args: message.SyncStepInput{
+				FinalizedSlot: 100,
+			},
+		},
+		Type:   message.EVMExecutionStepProposal,
+		Source: 1,
+	}})
+
+	s.Nil(err)
+}
@@ -0,0 +1,117 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	ethereumABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type FailureKind string
+
+const (
+	// TransientFailure is an RPC or network failure that is resolved by sending the transaction again
+	TransientFailure FailureKind = "transient"
+	// NonceGasFailure is a transaction rejected for its nonce, gas or fees
+	NonceGasFailure FailureKind = "nonce_gas"
+	// RevertFailure is a transaction reverted by the destination contract
+	RevertFailure FailureKind = "revert"
+)
+
+// nonceGasErrors are the node errors of transactions rejected for their nonce, gas or fees
+var nonceGasErrors = []string{
+	"nonce too low",
+	"nonce too high",
+	"already known",
+	"replacement transaction underpriced",
+	"transaction underpriced",
+	"insufficient funds",
+	"intrinsic gas too low",
+	"exceeds block gas limit",
+	"gas required exceeds allowance",
+	"max fee per gas less than block base fee",
+	"fee cap",
+}
+
+// revertErrors are the errors of transactions reverted during simulation or on chain
+var revertErrors = []string{
+	"execution reverted",
+	"transaction failed on chain",
+}
+
+// ExecutionError is a classified failure of a destination transaction
+type ExecutionError struct {
+	Kind FailureKind
+	// Reason is the decoded revert reason, if the contract returned one
+	Reason string
+	Err    error
+}
+
+func (e *ExecutionError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("%s failure: %s: %s", e.Kind, e.Err, e.Reason)
+	}
+	return fmt.Sprintf("%s failure: %s", e.Kind, e.Err)
+}
+
+func (e *ExecutionError) Unwrap() error {
+	return e.Err
+}
+
+// Permanent returns true if the same proposal fails again when it is retried.
+// Nonce and gas failures can succeed once the nonce or fees are updated.
+func (e *ExecutionError) Permanent() bool {
+	return e.Kind == RevertFailure
+}
+
+// ClassifyError classifies the transaction error and decodes the revert reason
+func ClassifyError(err error) *ExecutionError {
+	var executionErr *ExecutionError
+	if errors.As(err, &executionErr) {
+		return executionErr
+	}
+
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			revertData, decodeErr := hexutil.Decode(data)
+			if decodeErr == nil && len(revertData) > 0 {
+				return &ExecutionError{
+					Kind:   RevertFailure,
+					Reason: DecodeRevert(revertData),
+					Err:    err,
+				}
+			}
+		}
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, revertErr := range revertErrors {
+		if strings.Contains(msg, revertErr) {
+			return &ExecutionError{Kind: RevertFailure, Err: err}
+		}
+	}
+	for _, nonceGasErr := range nonceGasErrors {
+		if strings.Contains(msg, nonceGasErr) {
+			return &ExecutionError{Kind: NonceGasFailure, Err: err}
+		}
+	}
+	return &ExecutionError{Kind: TransientFailure, Err: err}
+}
+
+// DecodeRevert decodes revert data returned by a destination contract. The destination
+// contracts revert with require messages, so Error(string) and Panic(uint256) reverts are
+// decoded with their message and any other revert data is returned hex encoded.
+func DecodeRevert(data []byte) string {
+	reason, err := ethereumABI.UnpackRevert(data)
+	if err == nil {
+		return reason
+	}
+	return hexutil.Encode(data)
+}
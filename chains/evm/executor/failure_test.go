@@ -0,0 +1,94 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package executor_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	ethereumABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/executor"
+)
+
+// revertError is an RPC error with revert data like the errors returned by eth_call
+type revertError struct {
+	data string
+}
+
+func (e *revertError) Error() string {
+	return "execution reverted"
+}
+
+func (e *revertError) ErrorData() interface{} {
+	return e.data
+}
+
+func revertData(signature string, args ethereumABI.Arguments, values ...interface{}) []byte {
+	packed, err := args.Pack(values...)
+	if err != nil {
+		panic(err)
+	}
+	return append(crypto.Keccak256([]byte(signature))[:4], packed...)
+}
+
+type FailureTestSuite struct {
+	suite.Suite
+}
+
+func TestRunFailureTestSuite(t *testing.T) {
+	suite.Run(t, new(FailureTestSuite))
+}
+
+func (s *FailureTestSuite) Test_ClassifyError_Transient() {
+	err := executor.ClassifyError(fmt.Errorf("dial tcp: connection refused"))
+
+	s.Equal(err.Kind, executor.TransientFailure)
+	s.False(err.Permanent())
+}
+
+func (s *FailureTestSuite) Test_ClassifyError_NonceGas() {
+	for _, msg := range []string{"nonce too low", "replacement transaction underpriced", "insufficient funds for gas * price + value"} {
+		err := executor.ClassifyError(fmt.Errorf("%s", msg))
+
+		s.Equal(err.Kind, executor.NonceGasFailure)
+		s.False(err.Permanent())
+	}
+}
+
+func (s *FailureTestSuite) Test_ClassifyError_FailedOnChain() {
+	err := executor.ClassifyError(fmt.Errorf("transaction failed on chain. Receipt status 0"))
+
+	s.Equal(err.Kind, executor.RevertFailure)
+	s.True(err.Permanent())
+}
+
+func (s *FailureTestSuite) Test_ClassifyError_RevertReasonDecoded() {
+	stringType, _ := ethereumABI.NewType("string", "", nil)
+	data := revertData("Error(string)", ethereumABI.Arguments{{Type: stringType}}, "invalid proof")
+
+	err := executor.ClassifyError(fmt.Errorf("wrapped: %w", &revertError{data: hexutil.Encode(data)}))
+
+	s.Equal(err.Kind, executor.RevertFailure)
+	s.Equal(err.Reason, "invalid proof")
+	s.Equal(executor.ClassifyError(err), err)
+}
+
+func (s *FailureTestSuite) Test_DecodeRevert_Panic() {
+	uintType, _ := ethereumABI.NewType("uint256", "", nil)
+	data := revertData("Panic(uint256)", ethereumABI.Arguments{{Type: uintType}}, big.NewInt(0x11))
+
+	reason := executor.DecodeRevert(data)
+
+	s.Equal(reason, "arithmetic underflow or overflow")
+}
+
+func (s *FailureTestSuite) Test_DecodeRevert_UnknownError() {
+	reason := executor.DecodeRevert([]byte{1, 2, 3, 4, 5})
+
+	s.Equal(reason, "0x0102030405")
+}
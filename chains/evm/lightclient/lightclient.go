@@ -94,5 +94,9 @@ func (c *LightClient) decodeResp(resp *http.Response, out interface{}) error {
 		return err
 	}
 
+	data, err = padSyncCommittees(data)
+	if err != nil {
+		return err
+	}
 	return encoding.Unmarshal(data, &out, false)
 }
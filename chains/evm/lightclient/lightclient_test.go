@@ -0,0 +1,113 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package lightclient_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/lightclient"
+)
+
+type LightClientTestSuite struct {
+	suite.Suite
+
+	response string
+	server   *httptest.Server
+	client   *lightclient.LightClient
+}
+
+func TestRunLightClientTestSuite(t *testing.T) {
+	suite.Run(t, new(LightClientTestSuite))
+}
+
+func (s *LightClientTestSuite) SetupTest() {
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(s.response))
+	}))
+	s.client = lightclient.NewLightClient(s.server.URL)
+}
+
+func (s *LightClientTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func hexBytes(b byte, length int) string {
+	return "0x" + strings.Repeat(fmt.Sprintf("%02x", b), length)
+}
+
+func header(slot int) string {
+	return fmt.Sprintf(`{
+		"beacon": {"slot": "%d", "proposer_index": "1", "parent_root": "%s", "state_root": "%s", "body_root": "%s"},
+		"execution": {
+			"parent_hash": "%s", "fee_recipient": "%s", "state_root": "%s", "receipts_root": "%s",
+			"logs_bloom": "%s", "prev_randao": "%s", "block_number": "%d", "gas_limit": "1", "gas_used": "1",
+			"timestamp": "1", "extra_data": "0x", "base_fee_per_gas": "1", "block_hash": "%s",
+			"transactions_root": "%s", "withdrawals_root": "%s", "blob_gas_used": "0", "excess_blob_gas": "0"
+		},
+		"execution_branch": [%s]
+	}`, slot, hexBytes(1, 32), hexBytes(2, 32), hexBytes(3, 32),
+		hexBytes(4, 32), hexBytes(5, 20), hexBytes(6, 32), hexBytes(7, 32),
+		hexBytes(8, 256), hexBytes(9, 32), slot, hexBytes(10, 32),
+		hexBytes(11, 32), hexBytes(12, 32), branch(4))
+}
+
+func branch(depth int) string {
+	nodes := make([]string, depth)
+	for i := range nodes {
+		nodes[i] = fmt.Sprintf(`"%s"`, hexBytes(byte(i), 32))
+	}
+	return strings.Join(nodes, ",")
+}
+
+func (s *LightClientTestSuite) Test_FinalityUpdate_MinimalSyncCommitteeBits() {
+	s.response = fmt.Sprintf(`{"data": {
+		"attested_header": %s,
+		"finalized_header": %s,
+		"finality_branch": [%s],
+		"sync_aggregate": {"sync_committee_bits": "0xffffffff", "sync_committee_signature": "%s"},
+		"signature_slot": "17"
+	}}`, header(16), header(8), branch(6), hexBytes(1, 96))
+
+	update, err := s.client.FinalityUpdate()
+
+	s.Nil(err)
+	s.Equal(update.SignatureSlot, uint64(17))
+	s.Equal(update.SyncAggregate.SyncCommiteeBits[:4], []byte{0xff, 0xff, 0xff, 0xff})
+	s.Equal(update.SyncAggregate.SyncCommiteeBits[4:], make([]byte, 60))
+}
+
+func (s *LightClientTestSuite) Test_Bootstrap_MinimalSyncCommittee() {
+	pubkeys := make([]string, 32)
+	for i := range pubkeys {
+		pubkeys[i] = fmt.Sprintf(`"%s"`, hexBytes(byte(i+1), 48))
+	}
+	s.response = fmt.Sprintf(`{"data": {
+		"header": %s,
+		"current_sync_committee": {"pubkeys": [%s], "aggregate_pubkey": "%s"},
+		"current_sync_committee_branch": [%s]
+	}}`, header(8), strings.Join(pubkeys, ","), hexBytes(0xaa, 48), branch(5))
+
+	bootstrap, err := s.client.Bootstrap("0x01")
+
+	s.Nil(err)
+	s.Equal(bootstrap.CurrentSyncCommittee.PubKeys[31][0], byte(32))
+	s.Equal(bootstrap.CurrentSyncCommittee.PubKeys[32], [48]byte{})
+}
+
+func (s *LightClientTestSuite) Test_FinalityUpdate_InvalidStatus() {
+	s.server.Close()
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	s.client = lightclient.NewLightClient(s.server.URL)
+
+	_, err := s.client.FinalityUpdate()
+
+	s.NotNil(err)
+}
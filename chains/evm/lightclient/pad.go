@@ -0,0 +1,71 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package lightclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+const (
+	MAINNET_SYNC_COMMITTEE_SIZE = 512
+	PUBKEY_HEX_LENGTH           = 96
+)
+
+// padSyncCommittees pads sync committee bits and public keys of networks with
+// smaller sync committees to the mainnet sized consensus types. Padded bits
+// are unset and padded public keys are empty so the prover can trim them by
+// the committee size of the spec.
+func padSyncCommittees(data []byte) ([]byte, error) {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	err := decoder.Decode(&value)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(padValue(value))
+}
+
+func padValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			switch key {
+			case "sync_committee_bits":
+				if bits, ok := field.(string); ok {
+					v[key] = padHex(bits, MAINNET_SYNC_COMMITTEE_SIZE/8*2)
+				}
+			case "pubkeys":
+				if pubkeys, ok := field.([]interface{}); ok {
+					v[key] = padPubkeys(pubkeys)
+				}
+			default:
+				v[key] = padValue(field)
+			}
+		}
+	case []interface{}:
+		for i, element := range v {
+			v[i] = padValue(element)
+		}
+	}
+	return value
+}
+
+func padHex(value string, length int) string {
+	hex := strings.TrimPrefix(value, "0x")
+	if len(hex) >= length {
+		return value
+	}
+	return "0x" + hex + strings.Repeat("0", length-len(hex))
+}
+
+func padPubkeys(pubkeys []interface{}) []interface{} {
+	emptyPubkey := "0x" + strings.Repeat("0", PUBKEY_HEX_LENGTH)
+	for len(pubkeys) < MAINNET_SYNC_COMMITTEE_SIZE {
+		pubkeys = append(pubkeys, emptyPubkey)
+	}
+	return pubkeys
+}
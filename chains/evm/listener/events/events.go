@@ -4,6 +4,8 @@
 package events
 
 import (
+	"math/big"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 )
@@ -15,8 +17,10 @@ func (es EventSig) GetTopic() common.Hash {
 }
 
 const (
-	DepositSig           EventSig = "Deposit(uint8,uint8,bytes32,uint64,address,bytes)"
-	MessageDispatchedSig EventSig = "MessageDispatched(uint256,(uint256,uint256,uint256,address,address,bytes,address[],address[]))"
+	DepositSig            EventSig = "Deposit(uint8,uint8,bytes32,uint64,address,bytes)"
+	MessageDispatchedSig  EventSig = "MessageDispatched(uint256,(uint256,uint256,uint256,address,address,bytes,address[],address[]))"
+	StateRootSubmittedSig EventSig = "StateRootSubmitted(uint8,uint256,bytes32)"
+	CommitteeRotatedSig   EventSig = "CommitteeRotated(uint8,uint256)"
 )
 
 // Deposit struct holds event data raised by Deposit event on-chain
@@ -35,3 +39,34 @@ type Deposit struct {
 	// Additional data to be passed to specified handler
 	Data []byte
 }
+
+// HashiMessage is the message dispatched by the Hashi Yaho contract
+type HashiMessage struct {
+	Nonce         *big.Int
+	TargetChainId *big.Int
+	Threshold     *big.Int
+	Sender        common.Address
+	Receiver      common.Address
+	Data          []byte
+	Reporters     []common.Address
+	Adapters      []common.Address
+}
+
+// MessageDispatched struct holds event data raised by MessageDispatched event on the Yaho contract
+type MessageDispatched struct {
+	MessageId *big.Int
+	Message   HashiMessage
+}
+
+// StateRootSubmitted struct holds event data raised by StateRootSubmitted event on the Spectre contract
+type StateRootSubmitted struct {
+	SourceDomainID uint8
+	Slot           *big.Int
+	StateRoot      [32]byte
+}
+
+// CommitteeRotated struct holds event data raised by CommitteeRotated event on the Spectre contract
+type CommitteeRotated struct {
+	SourceDomainID uint8
+	Slot           *big.Int
+}
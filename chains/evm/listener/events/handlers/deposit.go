@@ -0,0 +1,107 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package handlers
+
+import (
+	"math/big"
+	"strings"
+	"time"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	ethereumABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/abi"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/listener/events"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/route"
+	"github.com/sygmaprotocol/spectre-node/store"
+)
+
+// DepositDomainCollector collects destination domains of Sygma Router deposits
+type DepositDomainCollector struct {
+	domainID      uint8
+	routerAddress common.Address
+	routerABI     ethereumABI.ABI
+	eventFetcher  EventFetcher
+	messageStorer MessageStorer
+	routes        route.Routes
+	blockRange    int64
+}
+
+func NewDepositDomainCollector(
+	domainID uint8,
+	routerAddress common.Address,
+	eventFetcher EventFetcher,
+	messageStorer MessageStorer,
+	routes route.Routes,
+	blockRange int64,
+) *DepositDomainCollector {
+	abi, _ := ethereumABI.JSON(strings.NewReader(abi.RouterABI))
+	return &DepositDomainCollector{
+		domainID:      domainID,
+		routerAddress: routerAddress,
+		routerABI:     abi,
+		eventFetcher:  eventFetcher,
+		messageStorer: messageStorer,
+		routes:        routes,
+		blockRange:    blockRange,
+	}
+}
+
+func (h *DepositDomainCollector) CollectDomains(startBlock *big.Int, endBlock *big.Int) ([]uint8, error) {
+	logs, err := FetchLogs(h.eventFetcher, startBlock, endBlock, h.routerAddress, string(events.DepositSig), h.blockRange)
+	if err != nil {
+		return []uint8{}, err
+	}
+
+	domains := mapset.NewSet[uint8]()
+	for _, l := range logs {
+		var d events.Deposit
+		err := h.routerABI.UnpackIntoInterface(&d, "Deposit", l.Data)
+		if err != nil {
+			return []uint8{}, err
+		}
+		route, ok := h.routes[d.DestinationDomainID]
+		if !ok || !route.Step {
+			log.Debug().Uint8("domainID", h.domainID).Msgf("Ignoring deposit with nonce %d to domain %d", d.DepositNonce, d.DestinationDomainID)
+			continue
+		}
+		if !route.AcceptsSecurityModel(d.SecurityModel) {
+			log.Debug().Uint8("domainID", h.domainID).Msgf(
+				"Ignoring deposit with nonce %d to domain %d with unsupported security model %d",
+				d.DepositNonce, d.DestinationDomainID, d.SecurityModel)
+			continue
+		}
+
+		message := h.trackDeposit(l, d)
+
+		log.Info().Uint8("domainID", h.domainID).Msgf(
+			"Found deposit with nonce %d to domain %d in block %d with hash %s",
+			message.Nonce, message.Destinations[0], l.BlockNumber, l.TxHash)
+		domains.Add(message.Destinations[0])
+	}
+	return domains.ToSlice(), nil
+}
+
+// trackDeposit stores the deposit so it can be tracked until its
+// block is delivered to the destination domain
+func (h *DepositDomainCollector) trackDeposit(l types.Log, d events.Deposit) *store.Message {
+	message := &store.Message{
+		Type:         store.DepositMessageType,
+		ID:           store.DepositID(d.DestinationDomainID, d.DepositNonce),
+		Nonce:        d.DepositNonce,
+		SourceDomain: h.domainID,
+		BlockNumber:  l.BlockNumber,
+		TxHash:       l.TxHash.Hex(),
+		DispatchedAt: time.Now(),
+		Destinations: []uint8{d.DestinationDomainID},
+		Status:       store.DispatchedMessage,
+	}
+	err := h.messageStorer.StoreMessage(message)
+	if err != nil {
+		log.Warn().Err(err).Uint8("domainID", h.domainID).Msgf("Unable to store deposit %s", message.ID)
+	}
+	return message
+}
@@ -0,0 +1,123 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package handlers_test
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	ethereumABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/abi"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/listener/events"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/listener/events/handlers"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/route"
+	"github.com/sygmaprotocol/spectre-node/mock"
+	"github.com/sygmaprotocol/spectre-node/store"
+	"go.uber.org/mock/gomock"
+)
+
+type DepositHandlerTestSuite struct {
+	suite.Suite
+
+	depositHandler *handlers.DepositDomainCollector
+
+	mockEventFetcher  *mock.MockEventFetcher
+	mockMessageStorer *mock.MockMessageStorer
+	sourceDomain      uint8
+	routes            route.Routes
+	routerAddress     common.Address
+}
+
+func TestRunDepositHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(DepositHandlerTestSuite))
+}
+
+func (s *DepositHandlerTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockEventFetcher = mock.NewMockEventFetcher(ctrl)
+	s.mockMessageStorer = mock.NewMockMessageStorer(ctrl)
+	s.sourceDomain = 1
+	s.routerAddress = common.HexToAddress("0x5798a6d2a3a4d6e5a2b1e7d4e2d6b6c2d8c6a5b3")
+	s.routes = route.NewRoutes([]uint8{2, 3})
+	s.depositHandler = handlers.NewDepositDomainCollector(
+		s.sourceDomain,
+		s.routerAddress,
+		s.mockEventFetcher,
+		s.mockMessageStorer,
+		s.routes,
+		1000,
+	)
+}
+
+func (s *DepositHandlerTestSuite) Test_CollectDomains_FetchingLogsFails() {
+	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.routerAddress, string(events.DepositSig), big.NewInt(100), big.NewInt(200)).Return(nil, fmt.Errorf("error"))
+
+	_, err := s.depositHandler.CollectDomains(big.NewInt(100), big.NewInt(200))
+
+	s.NotNil(err)
+}
+
+func (s *DepositHandlerTestSuite) Test_CollectDomains_ValidDeposits() {
+	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.routerAddress, string(events.DepositSig), big.NewInt(100), big.NewInt(200)).Return([]types.Log{
+		s.depositLog(2, 7, 150, 0),
+		s.depositLog(2, 8, 160, 0),
+		s.depositLog(4, 1, 170, 0),
+	}, nil)
+	var messages []*store.Message
+	s.mockMessageStorer.EXPECT().StoreMessage(gomock.Any()).DoAndReturn(func(message *store.Message) error {
+		messages = append(messages, message)
+		return nil
+	}).Times(2)
+
+	domains, err := s.depositHandler.CollectDomains(big.NewInt(100), big.NewInt(200))
+
+	s.Nil(err)
+	s.Equal(domains, []uint8{2})
+	s.Equal(messages[0].Type, store.DepositMessageType)
+	s.Equal(messages[0].ID, store.DepositID(2, 7))
+	s.Equal(messages[0].Nonce, uint64(7))
+	s.Equal(messages[0].BlockNumber, uint64(150))
+	s.Equal(messages[0].Destinations, []uint8{2})
+	s.Equal(messages[1].ID, store.DepositID(2, 8))
+}
+
+func (s *DepositHandlerTestSuite) Test_CollectDomains_RouteFiltersDeposits() {
+	s.routes[2].SecurityModels = []uint8{2}
+	s.routes[3].Step = false
+	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.routerAddress, string(events.DepositSig), big.NewInt(100), big.NewInt(200)).Return([]types.Log{
+		s.depositLog(2, 7, 150, 1),
+		s.depositLog(2, 8, 160, 2),
+		s.depositLog(3, 1, 170, 2),
+	}, nil)
+	var messages []*store.Message
+	s.mockMessageStorer.EXPECT().StoreMessage(gomock.Any()).DoAndReturn(func(message *store.Message) error {
+		messages = append(messages, message)
+		return nil
+	}).Times(1)
+
+	domains, err := s.depositHandler.CollectDomains(big.NewInt(100), big.NewInt(200))
+
+	s.Nil(err)
+	s.Equal(domains, []uint8{2})
+	s.Equal(messages[0].ID, store.DepositID(2, 8))
+}
+
+func (s *DepositHandlerTestSuite) depositLog(destination uint8, nonce uint64, blockNumber uint64, securityModel uint8) types.Log {
+	routerABI, err := ethereumABI.JSON(strings.NewReader(abi.RouterABI))
+	s.Nil(err)
+	data, err := routerABI.Events["Deposit"].Inputs.NonIndexed().Pack(destination, securityModel, [32]byte{}, nonce, []byte{})
+	s.Nil(err)
+
+	return types.Log{
+		Topics:      []common.Hash{events.DepositSig.GetTopic(), common.HexToHash("0x01")},
+		Data:        data,
+		BlockNumber: blockNumber,
+		Index:       uint(nonce),
+	}
+}
@@ -4,48 +4,75 @@
 package handlers
 
 import (
+	"fmt"
 	"math/big"
 	"strings"
+	"time"
 
 	ethereumABI "github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/rs/zerolog/log"
 	"github.com/sygmaprotocol/spectre-node/chains/evm/abi"
 	"github.com/sygmaprotocol/spectre-node/chains/evm/listener/events"
+	"github.com/sygmaprotocol/spectre-node/store"
 )
 
+type MessageHasher interface {
+	CalculateMessageHash(message events.HashiMessage) ([32]byte, error)
+}
+
+type MessageStorer interface {
+	StoreMessage(message *store.Message) error
+}
+
 type HashiDomainCollector struct {
-	domainID     uint8
-	yahoAddress  common.Address
-	yahoABI      ethereumABI.ABI
-	eventFetcher EventFetcher
-	domains      []uint8
+	domainID      uint8
+	yahoAddress   common.Address
+	yahoABI       ethereumABI.ABI
+	eventFetcher  EventFetcher
+	messageHasher MessageHasher
+	messageStorer MessageStorer
+	domains       []uint8
+	blockRange    int64
 }
 
 func NewHashiDomainCollector(
 	domainID uint8,
 	yahoAddress common.Address,
 	eventFetcher EventFetcher,
+	messageHasher MessageHasher,
+	messageStorer MessageStorer,
 	domains []uint8,
+	blockRange int64,
 ) *HashiDomainCollector {
 	abi, _ := ethereumABI.JSON(strings.NewReader(abi.YahoABI))
 	return &HashiDomainCollector{
-		domainID:     domainID,
-		yahoAddress:  yahoAddress,
-		yahoABI:      abi,
-		domains:      domains,
-		eventFetcher: eventFetcher,
+		domainID:      domainID,
+		yahoAddress:   yahoAddress,
+		yahoABI:       abi,
+		domains:       domains,
+		eventFetcher:  eventFetcher,
+		messageHasher: messageHasher,
+		messageStorer: messageStorer,
+		blockRange:    blockRange,
 	}
 }
 
 func (h *HashiDomainCollector) CollectDomains(startBlock *big.Int, endBlock *big.Int) ([]uint8, error) {
-	logs, err := fetchLogs(h.eventFetcher, startBlock, endBlock, h.yahoAddress, string(events.MessageDispatchedSig))
+	logs, err := FetchLogs(h.eventFetcher, startBlock, endBlock, h.yahoAddress, string(events.MessageDispatchedSig), h.blockRange)
 	if err != nil {
 		return []uint8{}, err
 	}
 
 	for _, l := range logs {
 		log.Info().Msgf("Found yaho MessageDispatched log in block %d with hash %s", l.BlockNumber, l.TxHash)
+
+		message, err := h.trackMessage(l)
+		if err != nil {
+			return []uint8{}, err
+		}
+		log.Info().Uint8("domainID", h.domainID).Msgf("Tracking Hashi message %s with hash %s", message.ID, message.Hash)
 	}
 
 	if len(logs) == 0 {
@@ -53,3 +80,40 @@ func (h *HashiDomainCollector) CollectDomains(startBlock *big.Int, endBlock *big
 	}
 	return h.domains, nil
 }
+
+// trackMessage stores the dispatched message so it can be tracked until
+// its block is delivered to the destination domains
+func (h *HashiDomainCollector) trackMessage(l types.Log) (*store.Message, error) {
+	if len(l.Topics) < 2 {
+		return nil, fmt.Errorf("missing message ID in log of tx %s", l.TxHash)
+	}
+	var e events.MessageDispatched
+	err := h.yahoABI.UnpackIntoInterface(&e, "MessageDispatched", l.Data)
+	if err != nil {
+		return nil, err
+	}
+	e.MessageId = l.Topics[1].Big()
+
+	hash, err := h.messageHasher.CalculateMessageHash(e.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	message := &store.Message{
+		Type:          store.HashiMessageType,
+		ID:            e.MessageId.String(),
+		Hash:          common.Hash(hash).Hex(),
+		TargetChainID: e.Message.TargetChainId.String(),
+		SourceDomain:  h.domainID,
+		BlockNumber:   l.BlockNumber,
+		TxHash:        l.TxHash.Hex(),
+		DispatchedAt:  time.Now(),
+		Destinations:  h.domains,
+		Status:        store.DispatchedMessage,
+	}
+	err = h.messageStorer.StoreMessage(message)
+	if err != nil {
+		log.Warn().Err(err).Uint8("domainID", h.domainID).Msgf("Unable to store Hashi message %s", message.ID)
+	}
+	return message, nil
+}
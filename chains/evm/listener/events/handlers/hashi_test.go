@@ -6,14 +6,18 @@ package handlers_test
 import (
 	"fmt"
 	"math/big"
+	"strings"
 	"testing"
 
+	ethereumABI "github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/abi"
 	"github.com/sygmaprotocol/spectre-node/chains/evm/listener/events"
 	"github.com/sygmaprotocol/spectre-node/chains/evm/listener/events/handlers"
 	"github.com/sygmaprotocol/spectre-node/mock"
+	"github.com/sygmaprotocol/spectre-node/store"
 	evmMessage "github.com/sygmaprotocol/sygma-core/relayer/message"
 	"go.uber.org/mock/gomock"
 )
@@ -23,11 +27,13 @@ type HashiHandlerTestSuite struct {
 
 	hashiHandler *handlers.HashiDomainCollector
 
-	msgChan          chan []*evmMessage.Message
-	mockEventFetcher *mock.MockEventFetcher
-	domains          []uint8
-	sourceDomain     uint8
-	yahoAddress      common.Address
+	msgChan           chan []*evmMessage.Message
+	mockEventFetcher  *mock.MockEventFetcher
+	mockMessageHasher *mock.MockMessageHasher
+	mockMessageStorer *mock.MockMessageStorer
+	domains           []uint8
+	sourceDomain      uint8
+	yahoAddress       common.Address
 }
 
 func TestRunHashiHandlerTestSuite(t *testing.T) {
@@ -37,33 +43,95 @@ func TestRunHashiHandlerTestSuite(t *testing.T) {
 func (s *HashiHandlerTestSuite) SetupTest() {
 	ctrl := gomock.NewController(s.T())
 	s.mockEventFetcher = mock.NewMockEventFetcher(ctrl)
+	s.mockMessageHasher = mock.NewMockMessageHasher(ctrl)
+	s.mockMessageStorer = mock.NewMockMessageStorer(ctrl)
 	s.msgChan = make(chan []*evmMessage.Message, 2)
 	s.sourceDomain = 1
+	s.domains = []uint8{2, 3}
 	s.yahoAddress = common.HexToAddress("0xa83114A443dA1CecEFC50368531cACE9F37fCCcb")
 	s.hashiHandler = handlers.NewHashiDomainCollector(
 		s.sourceDomain,
 		s.yahoAddress,
 		s.mockEventFetcher,
+		s.mockMessageHasher,
+		s.mockMessageStorer,
 		s.domains,
+		1000,
 	)
 }
 
 func (s *HashiHandlerTestSuite) Test_CollectDomains_FetchingLogFails() {
-	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.yahoAddress, string(events.MessageDispatchedSig), big.NewInt(100), big.NewInt(1100)).Return([]types.Log{}, nil)
-	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.yahoAddress, string(events.MessageDispatchedSig), big.NewInt(1101), big.NewInt(2101)).Return([]types.Log{{}}, fmt.Errorf("error"))
+	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.yahoAddress, string(events.MessageDispatchedSig), big.NewInt(100), big.NewInt(1099)).Return([]types.Log{}, nil)
+	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.yahoAddress, string(events.MessageDispatchedSig), big.NewInt(1100), big.NewInt(2099)).Return([]types.Log{{}}, fmt.Errorf("error"))
 
 	_, err := s.hashiHandler.CollectDomains(big.NewInt(100), big.NewInt(2568))
 
 	s.NotNil(err)
 }
 
+func (s *HashiHandlerTestSuite) Test_CollectDomains_NoMessages() {
+	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.yahoAddress, string(events.MessageDispatchedSig), big.NewInt(100), big.NewInt(200)).Return([]types.Log{}, nil)
+
+	domains, err := s.hashiHandler.CollectDomains(big.NewInt(100), big.NewInt(200))
+
+	s.Nil(err)
+	s.Equal(domains, []uint8{})
+}
+
+func (s *HashiHandlerTestSuite) Test_CollectDomains_HashingFails() {
+	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.yahoAddress, string(events.MessageDispatchedSig), big.NewInt(100), big.NewInt(200)).Return([]types.Log{s.messageLog(big.NewInt(5), 150)}, nil)
+	s.mockMessageHasher.EXPECT().CalculateMessageHash(gomock.Any()).Return([32]byte{}, fmt.Errorf("error"))
+
+	_, err := s.hashiHandler.CollectDomains(big.NewInt(100), big.NewInt(200))
+
+	s.NotNil(err)
+}
+
 func (s *HashiHandlerTestSuite) Test_CollectDomains_ValidMessage() {
-	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.yahoAddress, string(events.MessageDispatchedSig), big.NewInt(100), big.NewInt(1100)).Return([]types.Log{}, nil)
-	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.yahoAddress, string(events.MessageDispatchedSig), big.NewInt(1101), big.NewInt(2101)).Return([]types.Log{{}}, nil)
-	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.yahoAddress, string(events.MessageDispatchedSig), big.NewInt(2102), big.NewInt(2568)).Return([]types.Log{}, nil)
+	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.yahoAddress, string(events.MessageDispatchedSig), big.NewInt(100), big.NewInt(1099)).Return([]types.Log{}, nil)
+	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.yahoAddress, string(events.MessageDispatchedSig), big.NewInt(1100), big.NewInt(2099)).Return([]types.Log{s.messageLog(big.NewInt(5), 1500)}, nil)
+	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.yahoAddress, string(events.MessageDispatchedSig), big.NewInt(2100), big.NewInt(2568)).Return([]types.Log{}, nil)
+	s.mockMessageHasher.EXPECT().CalculateMessageHash(s.hashiMessage()).Return([32]byte{1}, nil)
+	s.mockMessageStorer.EXPECT().StoreMessage(gomock.Any()).DoAndReturn(func(message *store.Message) error {
+		s.Equal(message.Type, store.HashiMessageType)
+		s.Equal(message.ID, "5")
+		s.Equal(message.TargetChainID, "100")
+		s.Equal(message.Hash, common.Hash{1}.Hex())
+		s.Equal(message.SourceDomain, s.sourceDomain)
+		s.Equal(message.BlockNumber, uint64(1500))
+		s.Equal(message.Destinations, s.domains)
+		s.Equal(message.Status, store.DispatchedMessage)
+		return nil
+	})
 
 	domains, err := s.hashiHandler.CollectDomains(big.NewInt(100), big.NewInt(2568))
 
 	s.Nil(err)
 	s.Equal(domains, s.domains)
 }
+
+func (s *HashiHandlerTestSuite) hashiMessage() events.HashiMessage {
+	return events.HashiMessage{
+		Nonce:         big.NewInt(1),
+		TargetChainId: big.NewInt(100),
+		Threshold:     big.NewInt(1),
+		Sender:        common.HexToAddress("0x5798a6d2a3a4d6e5a2b1e7d4e2d6b6c2d8c6a5b3"),
+		Receiver:      common.HexToAddress("0x1b2a8e7d4e2d6b6c2d8c6a5b35798a6d2a3a4d6e"),
+		Data:          []byte{1, 2, 3},
+		Reporters:     []common.Address{common.HexToAddress("0x01")},
+		Adapters:      []common.Address{common.HexToAddress("0x02")},
+	}
+}
+
+func (s *HashiHandlerTestSuite) messageLog(messageID *big.Int, blockNumber uint64) types.Log {
+	yahoABI, err := ethereumABI.JSON(strings.NewReader(abi.YahoABI))
+	s.Nil(err)
+	data, err := yahoABI.Events["MessageDispatched"].Inputs.NonIndexed().Pack(s.hashiMessage())
+	s.Nil(err)
+
+	return types.Log{
+		Topics:      []common.Hash{events.MessageDispatchedSig.GetTopic(), common.BigToHash(messageID)},
+		Data:        data,
+		BlockNumber: blockNumber,
+	}
+}
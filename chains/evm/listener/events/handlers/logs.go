@@ -5,37 +5,82 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"math/big"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rs/zerolog/log"
 )
 
-const (
-	MAX_BLOCK_RANGE int64 = 1000
-)
+// Error messages RPC providers return when the log query block range or result is too
+// large. Rate limit errors such as "limit exceeded" must not match as they aren't
+// resolved by a smaller range.
+var rangeTooLargeErrors = []string{
+	"range too large",
+	"block range is too wide",
+	"block range is too large",
+	"query returned more than",
+	"too many results",
+	"response size exceeded",
+}
 
 type EventFetcher interface {
 	FetchEventLogs(ctx context.Context, contractAddress common.Address, event string, startBlock *big.Int, endBlock *big.Int) ([]types.Log, error)
 }
 
-// fetchLogs calls fetch event logs multiple times with a predefined block range to prevent
-// rpc errors when the block range is too large
-func fetchLogs(eventFetcher EventFetcher, startBlock, endBlock *big.Int, contract common.Address, eventSignature string) ([]types.Log, error) {
+// FetchLogs fetches event logs in the inclusive [startBlock, endBlock] range in chunks of
+// the block range to prevent rpc errors when the range is too large. The block range is
+// halved if the RPC rejects a chunk as too large and logs returned more than once are dropped.
+func FetchLogs(eventFetcher EventFetcher, startBlock, endBlock *big.Int, contract common.Address, eventSignature string, blockRange int64) ([]types.Log, error) {
+	if blockRange < 1 {
+		return nil, fmt.Errorf("invalid log block range %d", blockRange)
+	}
+
 	allLogs := make([]types.Log, 0)
-	for startBlock.Cmp(endBlock) < 0 {
-		rangeEnd := new(big.Int).Add(startBlock, big.NewInt(MAX_BLOCK_RANGE))
+	seen := make(map[logID]bool)
+	for startBlock.Cmp(endBlock) <= 0 {
+		rangeEnd := new(big.Int).Add(startBlock, big.NewInt(blockRange-1))
 		if rangeEnd.Cmp(endBlock) > 0 {
 			rangeEnd = endBlock
 		}
 
 		logs, err := eventFetcher.FetchEventLogs(context.Background(), contract, eventSignature, startBlock, rangeEnd)
 		if err != nil {
-			return nil, err
+			if !isRangeTooLarge(err) || blockRange == 1 {
+				return nil, err
+			}
+
+			blockRange /= 2
+			log.Debug().Err(err).Msgf("Log block range too large, retrying with range %d", blockRange)
+			continue
+		}
+		for _, l := range logs {
+			id := logID{txHash: l.TxHash, index: l.Index}
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			allLogs = append(allLogs, l)
 		}
-		allLogs = append(allLogs, logs...)
 		startBlock = new(big.Int).Add(rangeEnd, big.NewInt(1))
 	}
 
 	return allLogs, nil
 }
+
+type logID struct {
+	txHash common.Hash
+	index  uint
+}
+
+func isRangeTooLarge(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, rangeErr := range rangeTooLargeErrors {
+		if strings.Contains(msg, rangeErr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package handlers_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/listener/events/handlers"
+	"github.com/sygmaprotocol/spectre-node/mock"
+	"go.uber.org/mock/gomock"
+)
+
+type FetchLogsTestSuite struct {
+	suite.Suite
+
+	mockEventFetcher *mock.MockEventFetcher
+	contract         common.Address
+}
+
+func TestRunFetchLogsTestSuite(t *testing.T) {
+	suite.Run(t, new(FetchLogsTestSuite))
+}
+
+func (s *FetchLogsTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockEventFetcher = mock.NewMockEventFetcher(ctrl)
+	s.contract = common.HexToAddress("0x5798a6d2a3a4d6e5a2b1e7d4e2d6b6c2d8c6a5b3")
+}
+
+func (s *FetchLogsTestSuite) Test_FetchLogs_InvalidBlockRange() {
+	_, err := handlers.FetchLogs(s.mockEventFetcher, big.NewInt(1), big.NewInt(10), s.contract, "event", 0)
+
+	s.NotNil(err)
+}
+
+func (s *FetchLogsTestSuite) Test_FetchLogs_SingleBlock() {
+	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.contract, "event", big.NewInt(10), big.NewInt(10)).Return([]types.Log{{Index: 1}}, nil)
+
+	logs, err := handlers.FetchLogs(s.mockEventFetcher, big.NewInt(10), big.NewInt(10), s.contract, "event", 100)
+
+	s.Nil(err)
+	s.Len(logs, 1)
+}
+
+func (s *FetchLogsTestSuite) Test_FetchLogs_RangesDontOverlap() {
+	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.contract, "event", big.NewInt(1), big.NewInt(10)).Return([]types.Log{}, nil)
+	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.contract, "event", big.NewInt(11), big.NewInt(20)).Return([]types.Log{}, nil)
+	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.contract, "event", big.NewInt(21), big.NewInt(25)).Return([]types.Log{}, nil)
+
+	_, err := handlers.FetchLogs(s.mockEventFetcher, big.NewInt(1), big.NewInt(25), s.contract, "event", 10)
+
+	s.Nil(err)
+}
+
+func (s *FetchLogsTestSuite) Test_FetchLogs_RangeTooLarge_RangeHalved() {
+	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.contract, "event", big.NewInt(1), big.NewInt(20)).Return(nil, fmt.Errorf("query returned more than 10000 results"))
+	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.contract, "event", big.NewInt(1), big.NewInt(10)).Return([]types.Log{{Index: 1}}, nil)
+	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.contract, "event", big.NewInt(11), big.NewInt(20)).Return([]types.Log{{Index: 2}}, nil)
+
+	logs, err := handlers.FetchLogs(s.mockEventFetcher, big.NewInt(1), big.NewInt(20), s.contract, "event", 20)
+
+	s.Nil(err)
+	s.Len(logs, 2)
+}
+
+func (s *FetchLogsTestSuite) Test_FetchLogs_RangeTooLargeForSingleBlock() {
+	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.contract, "event", big.NewInt(1), big.NewInt(2)).Return(nil, fmt.Errorf("block range too large"))
+	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.contract, "event", big.NewInt(1), big.NewInt(1)).Return(nil, fmt.Errorf("block range too large"))
+
+	_, err := handlers.FetchLogs(s.mockEventFetcher, big.NewInt(1), big.NewInt(2), s.contract, "event", 2)
+
+	s.NotNil(err)
+}
+
+func (s *FetchLogsTestSuite) Test_FetchLogs_OtherErrorNotRetried() {
+	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.contract, "event", big.NewInt(1), big.NewInt(20)).Return(nil, fmt.Errorf("connection refused"))
+
+	_, err := handlers.FetchLogs(s.mockEventFetcher, big.NewInt(1), big.NewInt(20), s.contract, "event", 20)
+
+	s.NotNil(err)
+}
+
+func (s *FetchLogsTestSuite) Test_FetchLogs_RateLimitNotHalved() {
+	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.contract, "event", big.NewInt(1), big.NewInt(20)).Return(nil, fmt.Errorf("429 Too Many Requests: rate limit exceeded"))
+
+	_, err := handlers.FetchLogs(s.mockEventFetcher, big.NewInt(1), big.NewInt(20), s.contract, "event", 20)
+
+	s.ErrorContains(err, "rate limit exceeded")
+}
+
+func (s *FetchLogsTestSuite) Test_FetchLogs_BlockRangeTooWide_RangeHalved() {
+	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.contract, "event", big.NewInt(1), big.NewInt(20)).Return(nil, fmt.Errorf("eth_getLogs block range is too wide"))
+	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.contract, "event", big.NewInt(1), big.NewInt(10)).Return([]types.Log{}, nil)
+	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.contract, "event", big.NewInt(11), big.NewInt(20)).Return([]types.Log{}, nil)
+
+	_, err := handlers.FetchLogs(s.mockEventFetcher, big.NewInt(1), big.NewInt(20), s.contract, "event", 20)
+
+	s.Nil(err)
+}
+
+func (s *FetchLogsTestSuite) Test_FetchLogs_DuplicateLogsDropped() {
+	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.contract, "event", big.NewInt(1), big.NewInt(10)).Return([]types.Log{
+		{TxHash: common.Hash{1}, Index: 1},
+		{TxHash: common.Hash{1}, Index: 1},
+		{TxHash: common.Hash{1}, Index: 2},
+	}, nil)
+	s.mockEventFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.contract, "event", big.NewInt(11), big.NewInt(20)).Return([]types.Log{
+		{TxHash: common.Hash{1}, Index: 2},
+		{TxHash: common.Hash{2}, Index: 1},
+	}, nil)
+
+	logs, err := handlers.FetchLogs(s.mockEventFetcher, big.NewInt(1), big.NewInt(20), s.contract, "event", 10)
+
+	s.Nil(err)
+	s.Len(logs, 3)
+}
@@ -0,0 +1,71 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package handlers
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog/log"
+	evmMessage "github.com/sygmaprotocol/spectre-node/chains/evm/message"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/prover"
+	"github.com/sygmaprotocol/spectre-node/store"
+)
+
+type ProofStorer interface {
+	StoreProof(record *store.ProofRecord) (common.Hash, error)
+}
+
+// archiveStepProof stores the step proof with its prover arguments so it
+// can be replayed later. Archiving failures are logged and do not stop the relaying.
+func archiveStepProof(proofStorer ProofStorer, domainID uint8, args *prover.StepArgs, stepData evmMessage.StepData) {
+	update, err := args.UpdateSSZ()
+	if err != nil {
+		log.Warn().Err(err).Uint8("domainID", domainID).Msgf("Unable to encode step update for archiving")
+		return
+	}
+
+	hash, err := proofStorer.StoreProof(&store.ProofRecord{
+		Type:         store.StepProofType,
+		SourceDomain: domainID,
+		Slot:         args.Update.FinalizedHeader.Header.Slot,
+		Spec:         string(args.Spec.Name),
+		Domain:       args.Domain,
+		Pubkeys:      prover.PubkeysSSZ(args.Pubkeys),
+		Update:       update,
+		Step:         &stepData,
+	})
+	if err != nil {
+		log.Warn().Err(err).Uint8("domainID", domainID).Msgf("Unable to archive step proof")
+		return
+	}
+
+	log.Debug().Uint8("domainID", domainID).Uint64("slot", args.Update.FinalizedHeader.Header.Slot).Msgf("Archived step proof %s", hash)
+}
+
+// archiveRotateProof stores the rotate proof with its prover arguments so it
+// can be replayed later. Archiving failures are logged and do not stop the relaying.
+func archiveRotateProof(proofStorer ProofStorer, domainID uint8, period uint64, args *prover.RotateArgs, rotateData evmMessage.RotateData) {
+	update, err := args.UpdateSSZ()
+	if err != nil {
+		log.Warn().Err(err).Uint8("domainID", domainID).Msgf("Unable to encode rotate update for archiving")
+		return
+	}
+
+	hash, err := proofStorer.StoreProof(&store.ProofRecord{
+		Type:         store.RotateProofType,
+		SourceDomain: domainID,
+		Slot:         args.Update.FinalizedHeader.Header.Slot,
+		Period:       period,
+		Spec:         string(args.Spec.Name),
+		Domain:       args.Domain,
+		Pubkeys:      prover.PubkeysSSZ(args.Pubkeys),
+		Update:       update,
+		Rotate:       &rotateData,
+	})
+	if err != nil {
+		log.Warn().Err(err).Uint8("domainID", domainID).Msgf("Unable to archive rotate proof")
+		return
+	}
+
+	log.Debug().Uint8("domainID", domainID).Uint64("period", period).Msgf("Archived rotate proof %s", hash)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/attestantio/go-eth2-client/api"
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/rs/zerolog/log"
 	evmMessage "github.com/sygmaprotocol/spectre-node/chains/evm/message"
 	"github.com/sygmaprotocol/spectre-node/chains/evm/prover"
@@ -32,39 +33,73 @@ type RotateHandler struct {
 
 	prover       Prover
 	periodStorer PeriodStorer
+	proofStorer  ProofStorer
 	latestPeriod *big.Int
 
 	committeePeriodLength uint64
+	rotationLead          uint64
 }
 
 func NewRotateHandler(
 	msgChan chan []*message.Message,
 	periodStorer PeriodStorer,
+	proofStorer ProofStorer,
 	prover Prover,
 	domainID uint8,
 	domains []uint8,
 	committeePeriodLenght uint64,
+	rotationLead uint64,
 	latestPeriod *big.Int,
 ) *RotateHandler {
 	return &RotateHandler{
 		prover:                prover,
 		periodStorer:          periodStorer,
+		proofStorer:           proofStorer,
 		domainID:              domainID,
 		domains:               domains,
 		msgChan:               msgChan,
 		committeePeriodLength: committeePeriodLenght,
+		rotationLead:          rotationLead,
 		latestPeriod:          latestPeriod,
 	}
 }
 
-// HandleEvents checks if the current period is newer than the last stored
-// period and rotates the committee if it is
+// HandleEvents checks if the rotation of the period after the last stored
+// period is due and rotates the committee if it is
 func (h *RotateHandler) HandleEvents(checkpoint *apiv1.Finality) error {
-	currentPeriod := uint64(checkpoint.Finalized.Epoch) / h.committeePeriodLength
-	if currentPeriod <= h.latestPeriod.Uint64() {
+	if !h.rotationDue(checkpoint.Finalized.Epoch) {
 		return nil
 	}
 
+	return h.rotate()
+}
+
+// HandleGap rotates the committee for every period that started within the
+// skipped epochs so rotations stay sequential
+func (h *RotateHandler) HandleGap(from phase0.Epoch, to phase0.Epoch) error {
+	for h.rotationDue(to) {
+		log.Info().Uint8("domainID", h.domainID).Msgf("Rotating committee for period skipped in epochs %d-%d", from, to)
+
+		err := h.rotate()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rotationDue returns true if the committee update of the period after the latest
+// rotated period should be submitted at the finalized epoch. The update is proven from
+// the target period and sets the committee that starts signing when the target period
+// ends, so it is due the rotation lead number of epochs before that boundary. A lead of 0
+// rotates at the boundary and a lead of a full period as soon as the target period starts.
+func (h *RotateHandler) rotationDue(epoch phase0.Epoch) bool {
+	boundary := (h.latestPeriod.Uint64() + 2) * h.committeePeriodLength
+	return uint64(epoch)+h.rotationLead >= boundary
+}
+
+// rotate submits the committee update of the period after the latest rotated period
+func (h *RotateHandler) rotate() error {
 	targetPeriod := new(big.Int).Add(h.latestPeriod, big.NewInt(1))
 	args, err := h.prover.RotateArgs(targetPeriod.Uint64())
 	if err != nil {
@@ -94,6 +129,13 @@ func (h *RotateHandler) HandleEvents(checkpoint *apiv1.Finality) error {
 		return err
 	}
 
+	rotateData := evmMessage.RotateData{
+		RotateProof: rotateProof.Proof,
+		StepProof:   stepProof.Proof,
+		StepInput:   stepProof.Input,
+	}
+	archiveRotateProof(h.proofStorer, h.domainID, targetPeriod.Uint64(), args, rotateData)
+
 	for _, domain := range h.domains {
 		if domain == h.domainID {
 			continue
@@ -101,11 +143,7 @@ func (h *RotateHandler) HandleEvents(checkpoint *apiv1.Finality) error {
 
 		log.Debug().Uint8("domainID", h.domainID).Msgf("Sending rotate message to domain %d", domain)
 		h.msgChan <- []*message.Message{
-			evmMessage.NewEvmRotateMessage(h.domainID, domain, evmMessage.RotateData{
-				RotateProof: rotateProof.Proof,
-				StepProof:   stepProof.Proof,
-				StepInput:   stepProof.Input,
-			}),
+			evmMessage.NewEvmRotateMessage(h.domainID, domain, rotateData),
 		}
 	}
 
@@ -10,11 +10,13 @@ import (
 
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/suite"
 	"github.com/sygmaprotocol/spectre-node/chains/evm/listener/handlers"
 	evmMessage "github.com/sygmaprotocol/spectre-node/chains/evm/message"
 	"github.com/sygmaprotocol/spectre-node/chains/evm/prover"
 	"github.com/sygmaprotocol/spectre-node/mock"
+	"github.com/sygmaprotocol/spectre-node/store"
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
 	consensus "github.com/umbracle/go-eth-consensus"
 	"go.uber.org/mock/gomock"
@@ -37,6 +39,7 @@ type RotateHandlerTestSuite struct {
 	msgChan          chan []*message.Message
 	mockProver       *mock.MockProver
 	mockPeriodStorer *mock.MockPeriodStorer
+	mockProofStorer  *mock.MockProofStorer
 }
 
 func TestRunRotateTestSuite(t *testing.T) {
@@ -47,14 +50,17 @@ func (s *RotateHandlerTestSuite) SetupTest() {
 	ctrl := gomock.NewController(s.T())
 	s.mockProver = mock.NewMockProver(ctrl)
 	s.mockPeriodStorer = mock.NewMockPeriodStorer(ctrl)
+	s.mockProofStorer = mock.NewMockProofStorer(ctrl)
 	s.msgChan = make(chan []*message.Message, 2)
 	s.handler = handlers.NewRotateHandler(
 		s.msgChan,
 		s.mockPeriodStorer,
+		s.mockProofStorer,
 		s.mockProver,
 		1,
 		[]uint8{2, 3},
 		256,
+		256,
 		big.NewInt(3),
 	)
 }
@@ -74,10 +80,17 @@ func (s *RotateHandlerTestSuite) Test_HandleEvents_CurrentPeriodOlderThanLatest(
 func (s *RotateHandlerTestSuite) Test_HandleEvents_ValidPeriod() {
 	s.mockPeriodStorer.EXPECT().StorePeriod(uint8(1), big.NewInt(4)).Return(nil)
 	s.mockProver.EXPECT().RotateArgs(uint64(4)).Return(&prover.RotateArgs{
-		Update:  &consensus.LightClientUpdateDeneb{},
+		Update: &consensus.LightClientUpdateDeneb{
+			AttestedHeader:          &consensus.LightClientHeaderDeneb{},
+			FinalizedHeader:         &consensus.LightClientHeaderDeneb{},
+			NextSyncCommittee:       &consensus.SyncCommittee{},
+			NextSyncCommitteeBranch: make([][32]byte, 5),
+			FinalityBranch:          make([][32]byte, 6),
+			SyncAggregate:           &consensus.SyncAggregate{},
+		},
 		Domain:  phase0.Domain{},
-		Spec:    "mainnet",
-		Pubkeys: [512][48]byte{},
+		Spec:    &prover.ChainSpec{Name: prover.MAINNET_SPEC, Preset: prover.PRESETS[prover.MAINNET_SPEC]},
+		Pubkeys: [][48]byte{},
 	}, nil)
 	s.mockProver.EXPECT().RotateProof(gomock.Any()).Return(&prover.EvmProof[struct{}]{
 		Proof: []byte{},
@@ -87,6 +100,11 @@ func (s *RotateHandlerTestSuite) Test_HandleEvents_ValidPeriod() {
 		Proof: []byte{},
 		Input: evmMessage.SyncStepInput{},
 	}, nil)
+	s.mockProofStorer.EXPECT().StoreProof(gomock.Any()).DoAndReturn(func(record *store.ProofRecord) (common.Hash, error) {
+		s.Equal(record.Type, store.RotateProofType)
+		s.Equal(record.Period, uint64(4))
+		return common.Hash{}, nil
+	})
 
 	err := s.handler.HandleEvents(&apiv1.Finality{
 		Finalized: &phase0.Checkpoint{
@@ -102,3 +120,186 @@ func (s *RotateHandlerTestSuite) Test_HandleEvents_ValidPeriod() {
 	s.Nil(err)
 	s.Equal(len(msg2), 1)
 }
+
+func (s *RotateHandlerTestSuite) Test_HandleGap_NoSkippedPeriod() {
+	err := s.handler.HandleGap(phase0.Epoch(800), phase0.Epoch(900))
+	s.Nil(err)
+
+	_, err = readFromChannel(s.msgChan)
+	s.NotNil(err)
+}
+
+func (s *RotateHandlerTestSuite) Test_HandleGap_RotatesSkippedPeriods() {
+	s.msgChan = make(chan []*message.Message, 4)
+	s.handler = handlers.NewRotateHandler(
+		s.msgChan,
+		s.mockPeriodStorer,
+		s.mockProofStorer,
+		s.mockProver,
+		1,
+		[]uint8{2, 3},
+		256,
+		256,
+		big.NewInt(3),
+	)
+	for _, period := range []int64{4, 5} {
+		s.mockProver.EXPECT().RotateArgs(uint64(period)).Return(&prover.RotateArgs{
+			Update: &consensus.LightClientUpdateDeneb{
+				AttestedHeader:          &consensus.LightClientHeaderDeneb{},
+				FinalizedHeader:         &consensus.LightClientHeaderDeneb{},
+				NextSyncCommittee:       &consensus.SyncCommittee{},
+				NextSyncCommitteeBranch: make([][32]byte, 5),
+				FinalityBranch:          make([][32]byte, 6),
+				SyncAggregate:           &consensus.SyncAggregate{},
+			},
+			Domain:  phase0.Domain{},
+			Spec:    &prover.ChainSpec{Name: prover.MAINNET_SPEC, Preset: prover.PRESETS[prover.MAINNET_SPEC]},
+			Pubkeys: [][48]byte{},
+		}, nil)
+		s.mockProver.EXPECT().RotateProof(gomock.Any()).Return(&prover.EvmProof[struct{}]{
+			Proof: []byte{},
+			Input: struct{}{},
+		}, nil)
+		s.mockProver.EXPECT().StepProof(gomock.Any()).Return(&prover.EvmProof[evmMessage.SyncStepInput]{
+			Proof: []byte{},
+			Input: evmMessage.SyncStepInput{},
+		}, nil)
+		s.mockProofStorer.EXPECT().StoreProof(gomock.Any()).Return(common.Hash{}, nil)
+		s.mockPeriodStorer.EXPECT().StorePeriod(uint8(1), big.NewInt(period)).Return(nil)
+	}
+
+	err := s.handler.HandleGap(phase0.Epoch(1000), phase0.Epoch(1300))
+	s.Nil(err)
+
+	s.Equal(len(s.msgChan), 4)
+	// the checkpoint after the gap is in an already rotated period
+	err = s.handler.HandleEvents(&apiv1.Finality{
+		Finalized: &phase0.Checkpoint{
+			Epoch: phase0.Epoch(1301),
+		},
+	})
+	s.Nil(err)
+	s.Equal(len(s.msgChan), 4)
+}
+
+func (s *RotateHandlerTestSuite) Test_HandleEvents_RotationLead() {
+	s.handler = handlers.NewRotateHandler(
+		s.msgChan,
+		s.mockPeriodStorer,
+		s.mockProofStorer,
+		s.mockProver,
+		1,
+		[]uint8{2, 3},
+		256,
+		8,
+		big.NewInt(3),
+	)
+
+	// the target period started but the rotation is only due 8 epochs before it ends
+	err := s.handler.HandleEvents(&apiv1.Finality{
+		Finalized: &phase0.Checkpoint{
+			Epoch: phase0.Epoch(1024),
+		},
+	})
+	s.Nil(err)
+	_, err = readFromChannel(s.msgChan)
+	s.NotNil(err)
+
+	s.mockPeriodStorer.EXPECT().StorePeriod(uint8(1), big.NewInt(4)).Return(nil)
+	s.mockProver.EXPECT().RotateArgs(uint64(4)).Return(&prover.RotateArgs{
+		Update: &consensus.LightClientUpdateDeneb{
+			AttestedHeader:          &consensus.LightClientHeaderDeneb{},
+			FinalizedHeader:         &consensus.LightClientHeaderDeneb{},
+			NextSyncCommittee:       &consensus.SyncCommittee{},
+			NextSyncCommitteeBranch: make([][32]byte, 5),
+			FinalityBranch:          make([][32]byte, 6),
+			SyncAggregate:           &consensus.SyncAggregate{},
+		},
+		Domain:  phase0.Domain{},
+		Spec:    &prover.ChainSpec{Name: prover.MAINNET_SPEC, Preset: prover.PRESETS[prover.MAINNET_SPEC]},
+		Pubkeys: [][48]byte{},
+	}, nil)
+	s.mockProver.EXPECT().RotateProof(gomock.Any()).Return(&prover.EvmProof[struct{}]{
+		Proof: []byte{},
+		Input: struct{}{},
+	}, nil)
+	s.mockProver.EXPECT().StepProof(gomock.Any()).Return(&prover.EvmProof[evmMessage.SyncStepInput]{
+		Proof: []byte{},
+		Input: evmMessage.SyncStepInput{},
+	}, nil)
+	s.mockProofStorer.EXPECT().StoreProof(gomock.Any()).Return(common.Hash{}, nil)
+
+	err = s.handler.HandleEvents(&apiv1.Finality{
+		Finalized: &phase0.Checkpoint{
+			Epoch: phase0.Epoch(1272),
+		},
+	})
+	s.Nil(err)
+	msg1, err := readFromChannel(s.msgChan)
+	s.Nil(err)
+	s.Equal(len(msg1), 1)
+	msg2, err := readFromChannel(s.msgChan)
+	s.Nil(err)
+	s.Equal(len(msg2), 1)
+}
+
+func (s *RotateHandlerTestSuite) Test_HandleEvents_NoRotationLead() {
+	s.handler = handlers.NewRotateHandler(
+		s.msgChan,
+		s.mockPeriodStorer,
+		s.mockProofStorer,
+		s.mockProver,
+		1,
+		[]uint8{2, 3},
+		256,
+		0,
+		big.NewInt(3),
+	)
+
+	// without a lead the rotation is due when the target period ends
+	err := s.handler.HandleEvents(&apiv1.Finality{
+		Finalized: &phase0.Checkpoint{
+			Epoch: phase0.Epoch(1279),
+		},
+	})
+	s.Nil(err)
+	_, err = readFromChannel(s.msgChan)
+	s.NotNil(err)
+
+	s.mockPeriodStorer.EXPECT().StorePeriod(uint8(1), big.NewInt(4)).Return(nil)
+	s.mockProver.EXPECT().RotateArgs(uint64(4)).Return(&prover.RotateArgs{
+		Update: &consensus.LightClientUpdateDeneb{
+			AttestedHeader:          &consensus.LightClientHeaderDeneb{},
+			FinalizedHeader:         &consensus.LightClientHeaderDeneb{},
+			NextSyncCommittee:       &consensus.SyncCommittee{},
+			NextSyncCommitteeBranch: make([][32]byte, 5),
+			FinalityBranch:          make([][32]byte, 6),
+			SyncAggregate:           &consensus.SyncAggregate{},
+		},
+		Domain:  phase0.Domain{},
+		Spec:    &prover.ChainSpec{Name: prover.MAINNET_SPEC, Preset: prover.PRESETS[prover.MAINNET_SPEC]},
+		Pubkeys: [][48]byte{},
+	}, nil)
+	s.mockProver.EXPECT().RotateProof(gomock.Any()).Return(&prover.EvmProof[struct{}]{
+		Proof: []byte{},
+		Input: struct{}{},
+	}, nil)
+	s.mockProver.EXPECT().StepProof(gomock.Any()).Return(&prover.EvmProof[evmMessage.SyncStepInput]{
+		Proof: []byte{},
+		Input: evmMessage.SyncStepInput{},
+	}, nil)
+	s.mockProofStorer.EXPECT().StoreProof(gomock.Any()).Return(common.Hash{}, nil)
+
+	err = s.handler.HandleEvents(&apiv1.Finality{
+		Finalized: &phase0.Checkpoint{
+			Epoch: phase0.Epoch(1280),
+		},
+	})
+	s.Nil(err)
+	msg1, err := readFromChannel(s.msgChan)
+	s.Nil(err)
+	s.Equal(len(msg1), 1)
+	msg2, err := readFromChannel(s.msgChan)
+	s.Nil(err)
+	s.Equal(len(msg2), 1)
+}
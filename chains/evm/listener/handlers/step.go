@@ -7,14 +7,17 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/attestantio/go-eth2-client/api"
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec"
 	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/execution"
 	evmMessage "github.com/sygmaprotocol/spectre-node/chains/evm/message"
 	"github.com/sygmaprotocol/spectre-node/chains/evm/prover"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/route"
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
 )
 
@@ -31,6 +34,10 @@ type BlockFetcher interface {
 	SignedBeaconBlock(ctx context.Context, opts *api.SignedBeaconBlockOpts) (*api.Response[*spec.VersionedSignedBeaconBlock], error)
 }
 
+type ExecutionProver interface {
+	ProveAccounts(blockNumber uint64, accounts []*execution.Account) ([]evmMessage.AccountProof, error)
+}
+
 type DomainCollector interface {
 	CollectDomains(startBlock *big.Int, endBlock *big.Int) ([]uint8, error)
 }
@@ -41,11 +48,18 @@ type StepEventHandler struct {
 	blockFetcher     BlockFetcher
 	domainCollectors []DomainCollector
 	prover           Prover
+	proofStorer      ProofStorer
+	executionProver  ExecutionProver
 
 	domainID uint8
-	domains  []uint8
+	routes   route.Routes
+	// execution fields and accounts proven per destination domain
+	executionTargets map[uint8]*execution.Targets
 
 	latestBlock uint64
+	// destinations with collected messages waiting for their minimum step interval
+	pendingDomains mapset.Set[uint8]
+	lastSteps      map[uint8]time.Time
 }
 
 func NewStepEventHandler(
@@ -53,17 +67,25 @@ func NewStepEventHandler(
 	domainCollectors []DomainCollector,
 	blockFetcher BlockFetcher,
 	prover Prover,
+	proofStorer ProofStorer,
+	executionProver ExecutionProver,
 	domainID uint8,
-	domains []uint8,
+	routes route.Routes,
+	executionTargets map[uint8]*execution.Targets,
 ) *StepEventHandler {
 	return &StepEventHandler{
 		blockFetcher:     blockFetcher,
 		prover:           prover,
+		proofStorer:      proofStorer,
+		executionProver:  executionProver,
 		domainCollectors: domainCollectors,
 		msgChan:          msgChan,
 		domainID:         domainID,
-		domains:          domains,
+		routes:           routes,
+		executionTargets: executionTargets,
 		latestBlock:      0,
+		pendingDomains:   mapset.NewSet[uint8](),
+		lastSteps:        make(map[uint8]time.Time),
 	}
 }
 
@@ -73,10 +95,12 @@ func (h *StepEventHandler) HandleEvents(checkpoint *apiv1.Finality) error {
 	if err != nil {
 		return err
 	}
-	domains, latestBlock, err := h.destinationDomains(args.Update.FinalizedHeader.Header.Slot)
+	collectedDomains, latestBlock, err := h.destinationDomains(args.Update.FinalizedHeader.Header.Slot)
 	if err != nil {
 		return err
 	}
+	h.pendingDomains.Append(collectedDomains...)
+	domains := h.dueDomains(time.Now())
 	if len(domains) == 0 {
 		h.latestBlock = latestBlock
 		log.Debug().Uint8("domainID", h.domainID).Uint64("slot", args.Update.FinalizedHeader.Header.Slot).Msgf("Skipping step...")
@@ -98,29 +122,89 @@ func (h *StepEventHandler) HandleEvents(checkpoint *apiv1.Finality) error {
 		return err
 	}
 
+	blockHeader, err := h.blockHeader(args)
+	if err != nil {
+		return err
+	}
+
+	stepData := evmMessage.StepData{
+		Proof:          proof.Proof,
+		Args:           proof.Input,
+		StateRoot:      args.Update.FinalizedHeader.Execution.StateRoot,
+		StateRootProof: stateRootProof.Hashes,
+		BlockHeader:    blockHeader,
+	}
+	archiveStepProof(h.proofStorer, h.domainID, args, stepData)
+
+	// execution proofs are generated before sending any message so a failure
+	// doesn't send the step to only some of the destinations
+	msgs := make([]*message.Message, 0, len(domains))
 	for _, destDomain := range domains {
 		if destDomain == h.domainID {
 			continue
 		}
 
-		log.Debug().Uint8("domainID", h.domainID).Msgf("Sending step message to domain %d", destDomain)
-		h.msgChan <- []*message.Message{
-			evmMessage.NewEvmStepMessage(
-				h.domainID,
-				destDomain,
-				evmMessage.StepData{
-					Proof:          proof.Proof,
-					Args:           proof.Input,
-					StateRoot:      args.Update.FinalizedHeader.Execution.StateRoot,
-					StateRootProof: stateRootProof.Hashes,
-				},
-			),
+		targets, ok := h.executionTargets[destDomain]
+		if !ok || targets.Empty() {
+			msgs = append(msgs, evmMessage.NewEvmStepMessage(h.domainID, destDomain, stepData))
+			continue
+		}
+
+		executionStepData, err := h.executionStepData(args, stepData, targets)
+		if err != nil {
+			return err
 		}
+		msgs = append(msgs, evmMessage.NewEvmExecutionStepMessage(h.domainID, destDomain, executionStepData))
+	}
+	for _, msg := range msgs {
+		log.Debug().Uint8("domainID", h.domainID).Msgf("Sending %s to domain %d", msg.Type, msg.Destination)
+		h.msgChan <- []*message.Message{msg}
+	}
+	now := time.Now()
+	for _, domain := range domains {
+		h.pendingDomains.Remove(domain)
+		h.lastSteps[domain] = now
 	}
 	h.latestBlock = latestBlock
 	return nil
 }
 
+// blockHeader proves the finalized execution block number and hash
+// so they can be stored on Hashi adapters after the step
+func (h *StepEventHandler) blockHeader(args *prover.StepArgs) (*evmMessage.BlockHeader, error) {
+	fields, err := execution.ProveFields(args.Update.FinalizedHeader, []string{"block_number", "block_hash"})
+	if err != nil {
+		return nil, err
+	}
+
+	return &evmMessage.BlockHeader{
+		Number:      args.Update.FinalizedHeader.Execution.BlockNumber,
+		NumberProof: fields[0].Proof,
+		Hash:        fields[1].Value,
+		HashProof:   fields[1].Proof,
+	}, nil
+}
+
+// executionStepData extends the step with the execution payload fields and
+// account proofs of the finalized execution block
+func (h *StepEventHandler) executionStepData(args *prover.StepArgs, stepData evmMessage.StepData, targets *execution.Targets) (evmMessage.StepData, error) {
+	fields, err := execution.ProveFields(args.Update.FinalizedHeader, targets.Fields)
+	if err != nil {
+		return stepData, err
+	}
+	stepData.ExecutionFields = fields
+
+	if len(targets.Accounts) == 0 {
+		return stepData, nil
+	}
+	accountProofs, err := h.executionProver.ProveAccounts(args.Update.FinalizedHeader.Execution.BlockNumber, targets.Accounts)
+	if err != nil {
+		return stepData, err
+	}
+	stepData.AccountProofs = accountProofs
+	return stepData, nil
+}
+
 func (h *StepEventHandler) destinationDomains(slot uint64) ([]uint8, uint64, error) {
 	domains := mapset.NewSet[uint8]()
 	block, err := h.blockFetcher.SignedBeaconBlock(context.Background(), &api.SignedBeaconBlockOpts{
@@ -131,15 +215,44 @@ func (h *StepEventHandler) destinationDomains(slot uint64) ([]uint8, uint64, err
 	}
 	endBlock := block.Data.Deneb.Message.Body.ExecutionPayload.BlockNumber
 	if h.latestBlock == 0 {
-		return h.domains, endBlock, nil
+		return h.routes.StepDomains(), endBlock, nil
+	}
+	// logs are only collected up to the finalized block so they can't be reorged
+	// and each block is collected once
+	if endBlock <= h.latestBlock {
+		return domains.ToSlice(), h.latestBlock, nil
 	}
 
 	for _, collector := range h.domainCollectors {
-		collectedDomains, err := collector.CollectDomains(new(big.Int).SetUint64(h.latestBlock), new(big.Int).SetUint64(endBlock))
+		collectedDomains, err := collector.CollectDomains(new(big.Int).SetUint64(h.latestBlock+1), new(big.Int).SetUint64(endBlock))
 		if err != nil {
 			return domains.ToSlice(), 0, err
 		}
-		domains.Append(collectedDomains...)
+		for _, domain := range collectedDomains {
+			route, ok := h.routes[domain]
+			if !ok || !route.Step {
+				log.Debug().Uint8("domainID", h.domainID).Msgf("Step to domain %d is disabled", domain)
+				continue
+			}
+			domains.Add(domain)
+		}
 	}
 	return domains.ToSlice(), endBlock, nil
 }
+
+// dueDomains returns sorted pending destinations whose minimum step interval elapsed.
+// Destinations stay pending until stepped so collected messages aren't dropped.
+func (h *StepEventHandler) dueDomains(now time.Time) []uint8 {
+	domains := make([]uint8, 0)
+	for _, domain := range h.routes.StepDomains() {
+		if !h.pendingDomains.Contains(domain) {
+			continue
+		}
+		if lastStep, ok := h.lastSteps[domain]; ok && now.Sub(lastStep) < h.routes[domain].MinStepInterval {
+			log.Debug().Uint8("domainID", h.domainID).Msgf("Delaying step to domain %d until its minimum step interval elapses", domain)
+			continue
+		}
+		domains = append(domains, domain)
+	}
+	return domains
+}
@@ -8,16 +8,20 @@ import (
 	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/attestantio/go-eth2-client/api"
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/deneb"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/execution"
 	"github.com/sygmaprotocol/spectre-node/chains/evm/listener/handlers"
 	evmMessage "github.com/sygmaprotocol/spectre-node/chains/evm/message"
 	"github.com/sygmaprotocol/spectre-node/chains/evm/prover"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/route"
 	"github.com/sygmaprotocol/spectre-node/mock"
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
 	consensus "github.com/umbracle/go-eth-consensus"
@@ -39,8 +43,12 @@ type StepHandlerTestSuite struct {
 	mockDomainCollector *mock.MockDomainCollector
 	mockStepProver      *mock.MockProver
 	mockBlockFetcher    *mock.MockBlockFetcher
+	mockProofStorer     *mock.MockProofStorer
+	mockExecutionProver *mock.MockExecutionProver
 
-	sourceDomain uint8
+	sourceDomain     uint8
+	routes           route.Routes
+	executionTargets map[uint8]*execution.Targets
 }
 
 func TestRunConfigTestSuite(t *testing.T) {
@@ -52,15 +60,23 @@ func (s *StepHandlerTestSuite) SetupTest() {
 	s.mockDomainCollector = mock.NewMockDomainCollector(ctrl)
 	s.mockStepProver = mock.NewMockProver(ctrl)
 	s.mockBlockFetcher = mock.NewMockBlockFetcher(ctrl)
+	s.mockProofStorer = mock.NewMockProofStorer(ctrl)
+	s.mockExecutionProver = mock.NewMockExecutionProver(ctrl)
+	s.mockProofStorer.EXPECT().StoreProof(gomock.Any()).Return(common.Hash{}, nil).AnyTimes()
 	s.msgChan = make(chan []*message.Message, 10)
 	s.sourceDomain = 1
+	s.routes = route.NewRoutes([]uint8{1, 2, 3})
+	s.executionTargets = make(map[uint8]*execution.Targets)
 	s.depositHandler = handlers.NewStepEventHandler(
 		s.msgChan,
 		[]handlers.DomainCollector{s.mockDomainCollector, s.mockDomainCollector},
 		s.mockBlockFetcher,
 		s.mockStepProver,
+		s.mockProofStorer,
+		s.mockExecutionProver,
 		s.sourceDomain,
-		[]uint8{1, 2, 3})
+		s.routes,
+		s.executionTargets)
 }
 
 func (s *StepHandlerTestSuite) Test_HandleEvents_FetchingArgsFails() {
@@ -79,6 +95,7 @@ func (s *StepHandlerTestSuite) Test_HandleEvents_FetchingArgsFails() {
 
 func (s *StepHandlerTestSuite) Test_HandleEvents_FetchingLogsFails() {
 	s.mockStepProver.EXPECT().StepArgs().Return(&prover.StepArgs{
+		Spec: &prover.ChainSpec{Name: prover.MAINNET_SPEC, Preset: prover.PRESETS[prover.MAINNET_SPEC]},
 		Update: &consensus.LightClientFinalityUpdateDeneb{
 			FinalizedHeader: &consensus.LightClientHeaderDeneb{
 				Header: &consensus.BeaconBlockHeader{
@@ -104,6 +121,7 @@ func (s *StepHandlerTestSuite) Test_HandleEvents_FetchingLogsFails() {
 
 func (s *StepHandlerTestSuite) Test_HandleEvents_FirstStep_StepExecuted() {
 	s.mockStepProver.EXPECT().StepArgs().Return(&prover.StepArgs{
+		Spec: &prover.ChainSpec{Name: prover.MAINNET_SPEC, Preset: prover.PRESETS[prover.MAINNET_SPEC]},
 		Update: &consensus.LightClientFinalityUpdateDeneb{
 			FinalizedHeader: &consensus.LightClientHeaderDeneb{
 				Header: &consensus.BeaconBlockHeader{
@@ -148,8 +166,9 @@ func (s *StepHandlerTestSuite) Test_HandleEvents_FirstStep_StepExecuted() {
 }
 
 func (s *StepHandlerTestSuite) Test_HandleEvents_SecondStep_MissingDeposits() {
-	s.mockDomainCollector.EXPECT().CollectDomains(big.NewInt(100), big.NewInt(110)).Return([]uint8{}, nil).Times(2)
+	s.mockDomainCollector.EXPECT().CollectDomains(big.NewInt(101), big.NewInt(110)).Return([]uint8{}, nil).Times(2)
 	s.mockStepProver.EXPECT().StepArgs().Return(&prover.StepArgs{
+		Spec: &prover.ChainSpec{Name: prover.MAINNET_SPEC, Preset: prover.PRESETS[prover.MAINNET_SPEC]},
 		Update: &consensus.LightClientFinalityUpdateDeneb{
 			FinalizedHeader: &consensus.LightClientHeaderDeneb{
 				Header: &consensus.BeaconBlockHeader{
@@ -187,6 +206,7 @@ func (s *StepHandlerTestSuite) Test_HandleEvents_SecondStep_MissingDeposits() {
 	s.Nil(err)
 
 	s.mockStepProver.EXPECT().StepArgs().Return(&prover.StepArgs{
+		Spec: &prover.ChainSpec{Name: prover.MAINNET_SPEC, Preset: prover.PRESETS[prover.MAINNET_SPEC]},
 		Update: &consensus.LightClientFinalityUpdateDeneb{
 			FinalizedHeader: &consensus.LightClientHeaderDeneb{
 				Header: &consensus.BeaconBlockHeader{
@@ -224,9 +244,10 @@ func (s *StepHandlerTestSuite) Test_HandleEvents_SecondStep_MissingDeposits() {
 }
 
 func (s *StepHandlerTestSuite) Test_HandleEvents_SecondStep_ValidDeposits() {
-	s.mockDomainCollector.EXPECT().CollectDomains(big.NewInt(100), big.NewInt(110)).Return([]uint8{2}, nil)
-	s.mockDomainCollector.EXPECT().CollectDomains(big.NewInt(100), big.NewInt(110)).Return([]uint8{3}, nil)
+	s.mockDomainCollector.EXPECT().CollectDomains(big.NewInt(101), big.NewInt(110)).Return([]uint8{2}, nil)
+	s.mockDomainCollector.EXPECT().CollectDomains(big.NewInt(101), big.NewInt(110)).Return([]uint8{3}, nil)
 	s.mockStepProver.EXPECT().StepArgs().Return(&prover.StepArgs{
+		Spec: &prover.ChainSpec{Name: prover.MAINNET_SPEC, Preset: prover.PRESETS[prover.MAINNET_SPEC]},
 		Update: &consensus.LightClientFinalityUpdateDeneb{
 			FinalizedHeader: &consensus.LightClientHeaderDeneb{
 				Header: &consensus.BeaconBlockHeader{
@@ -264,6 +285,7 @@ func (s *StepHandlerTestSuite) Test_HandleEvents_SecondStep_ValidDeposits() {
 	s.Nil(err)
 
 	s.mockStepProver.EXPECT().StepArgs().Return(&prover.StepArgs{
+		Spec: &prover.ChainSpec{Name: prover.MAINNET_SPEC, Preset: prover.PRESETS[prover.MAINNET_SPEC]},
 		Update: &consensus.LightClientFinalityUpdateDeneb{
 			FinalizedHeader: &consensus.LightClientHeaderDeneb{
 				Header: &consensus.BeaconBlockHeader{
@@ -306,3 +328,232 @@ func (s *StepHandlerTestSuite) Test_HandleEvents_SecondStep_ValidDeposits() {
 	_, err = readFromChannel(s.msgChan)
 	s.NotNil(err)
 }
+
+func (s *StepHandlerTestSuite) mockFirstStep(execution *consensus.ExecutionPayloadHeaderDeneb) {
+	s.mockStepProver.EXPECT().StepArgs().Return(&prover.StepArgs{
+		Spec: &prover.ChainSpec{Name: prover.MAINNET_SPEC, Preset: prover.PRESETS[prover.MAINNET_SPEC]},
+		Update: &consensus.LightClientFinalityUpdateDeneb{
+			FinalizedHeader: &consensus.LightClientHeaderDeneb{
+				Header: &consensus.BeaconBlockHeader{
+					Slot: 10,
+				},
+				Execution: execution,
+			},
+		},
+	}, nil)
+	s.mockBlockFetcher.EXPECT().SignedBeaconBlock(context.Background(), &api.SignedBeaconBlockOpts{
+		Block: "10",
+	}).Return(&api.Response[*spec.VersionedSignedBeaconBlock]{
+		Data: &spec.VersionedSignedBeaconBlock{
+			Deneb: &deneb.SignedBeaconBlock{
+				Message: &deneb.BeaconBlock{
+					Body: &deneb.BeaconBlockBody{
+						ExecutionPayload: &deneb.ExecutionPayload{
+							BlockNumber: 100,
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+	s.mockStepProver.EXPECT().StepProof(gomock.Any()).Return(&prover.EvmProof[evmMessage.SyncStepInput]{}, nil)
+}
+
+func (s *StepHandlerTestSuite) Test_HandleEvents_ExecutionTargets_ExecutionStepSent() {
+	account := &execution.Account{
+		Address: common.HexToAddress("0x5798a6d2a3a4d6e5a2b1e7d4e2d6b6c2d8c6a5b3"),
+		Slots:   []common.Hash{common.HexToHash("0x1")},
+	}
+	s.executionTargets[3] = &execution.Targets{
+		Fields:   []string{"receipts_root", "block_hash"},
+		Accounts: []*execution.Account{account},
+	}
+	header := &consensus.ExecutionPayloadHeaderDeneb{
+		BlockNumber:  150,
+		ReceiptsRoot: [32]byte{1},
+		BlockHash:    [32]byte{2},
+	}
+	s.mockFirstStep(header)
+	accountProofs := []evmMessage.AccountProof{{Account: account.Address}}
+	s.mockExecutionProver.EXPECT().ProveAccounts(uint64(150), []*execution.Account{account}).Return(accountProofs, nil)
+
+	err := s.depositHandler.HandleEvents(&apiv1.Finality{
+		Finalized: &phase0.Checkpoint{
+			Epoch: phase0.Epoch(1024),
+		},
+	})
+	s.Nil(err)
+
+	msgs, err := readFromChannel(s.msgChan)
+	s.Nil(err)
+	s.Equal(msgs[0].Destination, uint8(2))
+	s.Equal(msgs[0].Type, evmMessage.EVMStepMessage)
+	msgs, err = readFromChannel(s.msgChan)
+	s.Nil(err)
+	s.Equal(msgs[0].Destination, uint8(3))
+	s.Equal(msgs[0].Type, evmMessage.EVMExecutionStepMessage)
+	stepData := msgs[0].Data.(evmMessage.StepData)
+	s.Equal(stepData.AccountProofs, accountProofs)
+	s.Equal(stepData.BlockHeader.Number, header.BlockNumber)
+	s.Equal(stepData.BlockHeader.Hash, header.BlockHash)
+	s.Len(stepData.ExecutionFields, 2)
+	s.Equal(stepData.ExecutionFields[0].Name, "receipts_root")
+	s.Equal(stepData.ExecutionFields[0].Value, header.ReceiptsRoot)
+	s.Equal(stepData.ExecutionFields[1].Name, "block_hash")
+	s.Equal(stepData.ExecutionFields[1].Value, header.BlockHash)
+}
+
+func (s *StepHandlerTestSuite) Test_HandleEvents_ExecutionProofFails_NoStepSent() {
+	s.executionTargets[3] = &execution.Targets{
+		Accounts: []*execution.Account{{Address: common.HexToAddress("0x5798a6d2a3a4d6e5a2b1e7d4e2d6b6c2d8c6a5b3")}},
+	}
+	s.mockFirstStep(&consensus.ExecutionPayloadHeaderDeneb{})
+	s.mockExecutionProver.EXPECT().ProveAccounts(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+
+	err := s.depositHandler.HandleEvents(&apiv1.Finality{
+		Finalized: &phase0.Checkpoint{
+			Epoch: phase0.Epoch(1024),
+		},
+	})
+	s.NotNil(err)
+
+	_, err = readFromChannel(s.msgChan)
+	s.NotNil(err)
+}
+
+func (s *StepHandlerTestSuite) Test_HandleEvents_SecondStep_SameFinalizedBlock_LogsNotCollected() {
+	s.mockFirstStep(&consensus.ExecutionPayloadHeaderDeneb{})
+	err := s.depositHandler.HandleEvents(&apiv1.Finality{
+		Finalized: &phase0.Checkpoint{
+			Epoch: phase0.Epoch(1024),
+		},
+	})
+	s.Nil(err)
+	_, err = readFromChannel(s.msgChan)
+	s.Nil(err)
+	_, err = readFromChannel(s.msgChan)
+	s.Nil(err)
+
+	s.mockStepProver.EXPECT().StepArgs().Return(&prover.StepArgs{
+		Spec: &prover.ChainSpec{Name: prover.MAINNET_SPEC, Preset: prover.PRESETS[prover.MAINNET_SPEC]},
+		Update: &consensus.LightClientFinalityUpdateDeneb{
+			FinalizedHeader: &consensus.LightClientHeaderDeneb{
+				Header: &consensus.BeaconBlockHeader{
+					Slot: 10,
+				},
+				Execution: &consensus.ExecutionPayloadHeaderDeneb{},
+			},
+		},
+	}, nil)
+	s.mockBlockFetcher.EXPECT().SignedBeaconBlock(context.Background(), &api.SignedBeaconBlockOpts{
+		Block: "10",
+	}).Return(&api.Response[*spec.VersionedSignedBeaconBlock]{
+		Data: &spec.VersionedSignedBeaconBlock{
+			Deneb: &deneb.SignedBeaconBlock{
+				Message: &deneb.BeaconBlock{
+					Body: &deneb.BeaconBlockBody{
+						ExecutionPayload: &deneb.ExecutionPayload{
+							BlockNumber: 100,
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+
+	err = s.depositHandler.HandleEvents(&apiv1.Finality{
+		Finalized: &phase0.Checkpoint{
+			Epoch: phase0.Epoch(1024),
+		},
+	})
+	s.Nil(err)
+
+	_, err = readFromChannel(s.msgChan)
+	s.NotNil(err)
+}
+
+func (s *StepHandlerTestSuite) mockSecondStep(blockNumber uint64) {
+	s.mockStepProver.EXPECT().StepArgs().Return(&prover.StepArgs{
+		Spec: &prover.ChainSpec{Name: prover.MAINNET_SPEC, Preset: prover.PRESETS[prover.MAINNET_SPEC]},
+		Update: &consensus.LightClientFinalityUpdateDeneb{
+			FinalizedHeader: &consensus.LightClientHeaderDeneb{
+				Header: &consensus.BeaconBlockHeader{
+					Slot: 20,
+				},
+				Execution: &consensus.ExecutionPayloadHeaderDeneb{},
+			},
+		},
+	}, nil)
+	s.mockBlockFetcher.EXPECT().SignedBeaconBlock(context.Background(), &api.SignedBeaconBlockOpts{
+		Block: "20",
+	}).Return(&api.Response[*spec.VersionedSignedBeaconBlock]{
+		Data: &spec.VersionedSignedBeaconBlock{
+			Deneb: &deneb.SignedBeaconBlock{
+				Message: &deneb.BeaconBlock{
+					Body: &deneb.BeaconBlockBody{
+						ExecutionPayload: &deneb.ExecutionPayload{
+							BlockNumber: blockNumber,
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+}
+
+func (s *StepHandlerTestSuite) Test_HandleEvents_StepDisabled_StepNotSentToDomain() {
+	s.routes[2].Step = false
+	s.mockFirstStep(&consensus.ExecutionPayloadHeaderDeneb{})
+
+	err := s.depositHandler.HandleEvents(&apiv1.Finality{})
+	s.Nil(err)
+	msgs, err := readFromChannel(s.msgChan)
+	s.Nil(err)
+	s.Equal(msgs[0].Destination, uint8(3))
+	_, err = readFromChannel(s.msgChan)
+	s.NotNil(err)
+
+	s.mockSecondStep(110)
+	s.mockDomainCollector.EXPECT().CollectDomains(big.NewInt(101), big.NewInt(110)).Return([]uint8{2}, nil).Times(2)
+
+	err = s.depositHandler.HandleEvents(&apiv1.Finality{})
+	s.Nil(err)
+	_, err = readFromChannel(s.msgChan)
+	s.NotNil(err)
+}
+
+func (s *StepHandlerTestSuite) Test_HandleEvents_MinStepIntervalNotElapsed_StepDelayed() {
+	s.routes[2].MinStepInterval = time.Hour
+	s.mockFirstStep(&consensus.ExecutionPayloadHeaderDeneb{})
+
+	err := s.depositHandler.HandleEvents(&apiv1.Finality{})
+	s.Nil(err)
+	_, err = readFromChannel(s.msgChan)
+	s.Nil(err)
+	_, err = readFromChannel(s.msgChan)
+	s.Nil(err)
+
+	s.mockSecondStep(110)
+	s.mockDomainCollector.EXPECT().CollectDomains(big.NewInt(101), big.NewInt(110)).Return([]uint8{2}, nil)
+	s.mockDomainCollector.EXPECT().CollectDomains(big.NewInt(101), big.NewInt(110)).Return([]uint8{3}, nil)
+	s.mockStepProver.EXPECT().StepProof(gomock.Any()).Return(&prover.EvmProof[evmMessage.SyncStepInput]{}, nil)
+
+	err = s.depositHandler.HandleEvents(&apiv1.Finality{})
+	s.Nil(err)
+	msgs, err := readFromChannel(s.msgChan)
+	s.Nil(err)
+	s.Equal(msgs[0].Destination, uint8(3))
+	_, err = readFromChannel(s.msgChan)
+	s.NotNil(err)
+
+	s.routes[2].MinStepInterval = 0
+	s.mockSecondStep(120)
+	s.mockDomainCollector.EXPECT().CollectDomains(big.NewInt(111), big.NewInt(120)).Return([]uint8{}, nil).Times(2)
+	s.mockStepProver.EXPECT().StepProof(gomock.Any()).Return(&prover.EvmProof[evmMessage.SyncStepInput]{}, nil)
+
+	err = s.depositHandler.HandleEvents(&apiv1.Finality{})
+	s.Nil(err)
+	msgs, err = readFromChannel(s.msgChan)
+	s.Nil(err)
+	s.Equal(msgs[0].Destination, uint8(2))
+}
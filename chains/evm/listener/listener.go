@@ -5,13 +5,17 @@ package listener
 
 import (
 	"context"
+	"fmt"
 	"math/big"
+	"strings"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/api"
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/beacon"
 )
 
 type EventHandler interface {
@@ -22,76 +26,295 @@ type BeaconProvider interface {
 	Finality(ctx context.Context, opts *api.FinalityOpts) (*api.Response[*apiv1.Finality], error)
 }
 
+// GapHandler is implemented by event handlers that need to process finalized
+// epochs skipped between two handled checkpoints
+type GapHandler interface {
+	HandleGap(from phase0.Epoch, to phase0.Epoch) error
+}
+
+// NamedHandler is implemented by event handlers named differently than their type in logs and metrics
+type NamedHandler interface {
+	Name() string
+}
+
+type CheckpointStorer interface {
+	StoreCheckpoint(domainID uint8, checkpoint *phase0.Checkpoint) error
+	Checkpoint(domainID uint8) (*phase0.Checkpoint, error)
+}
+
+type Metrics interface {
+	SetGauge(name string, labels map[string]string, value float64)
+}
+
+type EventStream interface {
+	Subscribe(ctx context.Context, events chan<- *beacon.Event)
+	Connected() bool
+}
+
 type BlockStorer interface {
 	StoreBlock(epoch *big.Int, domainID uint8) error
 }
 
+// Maximum delay between retries of a failing event handler
+const MAX_RETRY_BACKOFF = time.Minute * 10
+
+// handlerState tracks the last checkpoint an event handler processed and its retry
+// backoff, so a failing handler doesn't block the others
+type handlerState struct {
+	handler    EventHandler
+	name       string
+	checkpoint *phase0.Checkpoint
+	failures   uint64
+	retryAt    time.Time
+}
+
 type EVMListener struct {
-	beaconProvider BeaconProvider
+	beaconProvider   BeaconProvider
+	eventStream      EventStream
+	checkpointStorer CheckpointStorer
+	metrics          Metrics
 
-	eventHandlers []EventHandler
+	handlers []*handlerState
 
-	domainID      uint8
-	retryInterval time.Duration
+	domainID          uint8
+	retryInterval     time.Duration
+	eventPollInterval time.Duration
+	latestCheckpoint  *phase0.Checkpoint
+	handledCheckpoint *phase0.Checkpoint
+	skippedEpochs     uint64
 
 	log zerolog.Logger
 }
 
 // NewEVMListener creates an EVMListener that listens to deposit events on chain
-// and calls event handler when one occurs
+// and calls event handler when one occurs.
+// Finality is polled every retry interval unless the event stream is connected,
+// in which case it is handled as events arrive and polled every event poll interval.
 func NewEVMListener(
 	beaconProvider BeaconProvider,
+	eventStream EventStream,
+	checkpointStorer CheckpointStorer,
+	metrics Metrics,
 	eventHandlers []EventHandler,
 	domainID uint8,
 	retryInterval time.Duration,
+	eventPollInterval time.Duration,
 ) *EVMListener {
 	logger := log.With().Uint8("domainID", domainID).Logger()
+	handlers := make([]*handlerState, len(eventHandlers))
+	for i, handler := range eventHandlers {
+		handlers[i] = &handlerState{
+			handler:    handler,
+			name:       HandlerName(handler),
+			checkpoint: &phase0.Checkpoint{},
+		}
+	}
 	return &EVMListener{
-		log:            logger,
-		beaconProvider: beaconProvider,
-		eventHandlers:  eventHandlers,
-		domainID:       domainID,
-		retryInterval:  retryInterval,
+		log:               logger,
+		beaconProvider:    beaconProvider,
+		eventStream:       eventStream,
+		checkpointStorer:  checkpointStorer,
+		metrics:           metrics,
+		handlers:          handlers,
+		domainID:          domainID,
+		retryInterval:     retryInterval,
+		eventPollInterval: eventPollInterval,
+		latestCheckpoint:  &phase0.Checkpoint{},
+		handledCheckpoint: &phase0.Checkpoint{},
+	}
+}
+
+// HandlerName returns the name of the event handler used in logs and metrics
+func HandlerName(handler EventHandler) string {
+	named, ok := handler.(NamedHandler)
+	if ok {
+		return named.Name()
 	}
+	return strings.TrimPrefix(fmt.Sprintf("%T", handler), "*")
 }
 
 // ListenToEvents waits for new finality checkpoints and calls event handlers
 // with the finalized epoch block range
 func (l *EVMListener) ListenToEvents(ctx context.Context, epoch *big.Int) {
-	latestCheckpoint := "0x0000000000000000000000000000000000000000000000000000000000000000"
-loop:
+	checkpoint, err := l.checkpointStorer.Checkpoint(l.domainID)
+	if err != nil {
+		l.log.Warn().Err(err).Msgf("Unable to fetch last handled checkpoint")
+	} else if checkpoint != nil {
+		l.log.Info().Msgf("Resuming from checkpoint on epoch %d", checkpoint.Epoch)
+		l.latestCheckpoint = checkpoint
+		l.handledCheckpoint = checkpoint
+		for _, state := range l.handlers {
+			state.checkpoint = checkpoint
+		}
+	}
+
+	events := make(chan *beacon.Event, 1)
+	if l.eventStream != nil {
+		go l.eventStream.Subscribe(ctx, events)
+	}
+
 	for {
+		handled, err := l.handleFinality(ctx)
+		if handled {
+			continue
+		}
+
+		interval := l.retryInterval
+		if err == nil {
+			if l.eventStream != nil && l.eventStream.Connected() {
+				interval = l.eventPollInterval
+			}
+			if retry, ok := l.nextRetry(); ok && retry < interval {
+				interval = retry
+			}
+		}
 		select {
 		case <-ctx.Done():
 			return
-		default:
-			finalityCheckpoint, err := l.beaconProvider.Finality(ctx, &api.FinalityOpts{
-				State: "finalized",
-			})
-			if err != nil {
-				l.log.Warn().Err(err).Msgf("Unable to fetch finalized checkpoint")
-				time.Sleep(l.retryInterval)
-				continue
-			}
-			if finalityCheckpoint.Data.Finalized.Root.String() == latestCheckpoint {
-				time.Sleep(l.retryInterval)
-				continue
-			}
+		case event := <-events:
+			l.log.Debug().Msgf("Received beacon %s event", event.Topic)
+		case <-time.After(interval):
+		}
+	}
+}
 
-			l.log.Debug().Msgf("Handling events for checkpoint on epoch %d", finalityCheckpoint.Data.Finalized.Epoch)
+// handleFinality calls every event handler that didn't process the finalized checkpoint
+// yet and isn't backing off. Returns true if all handlers processed a new checkpoint.
+func (l *EVMListener) handleFinality(ctx context.Context) (bool, error) {
+	finalityCheckpoint, err := l.beaconProvider.Finality(ctx, &api.FinalityOpts{
+		State: "finalized",
+	})
+	if err != nil {
+		l.log.Warn().Err(err).Msgf("Unable to fetch finalized checkpoint")
+		return false, err
+	}
+	finalized := finalityCheckpoint.Data.Finalized
+	l.detectGap(finalized)
 
-			for _, handler := range l.eventHandlers {
-				err := handler.HandleEvents(finalityCheckpoint.Data)
-				if err != nil {
-					l.log.Warn().Err(err).Msgf("Unable to handle events")
-					time.Sleep(l.retryInterval)
-					continue loop
-				}
-			}
+	handled := false
+	pending := false
+	for _, state := range l.handlers {
+		if state.checkpoint.Root == finalized.Root {
+			continue
+		}
+		if time.Now().Before(state.retryAt) {
+			pending = true
+			continue
+		}
+
+		err := l.handle(state, finalityCheckpoint.Data)
+		if err != nil {
+			state.failures++
+			backoff := l.backoff(state.failures)
+			state.retryAt = time.Now().Add(backoff)
+			l.log.Warn().Err(err).Str("handler", state.name).Uint64("failures", state.failures).Msgf("Unable to handle events, retrying in %s", backoff)
+			l.metrics.SetGauge("spectre_handler_failures", l.handlerLabels(state), float64(state.failures))
+			pending = true
+			continue
+		}
+
+		if state.failures > 0 {
+			state.failures = 0
+			state.retryAt = time.Time{}
+			l.metrics.SetGauge("spectre_handler_failures", l.handlerLabels(state), 0)
+		}
+		state.checkpoint = finalized
+		handled = true
+	}
+	if pending {
+		return false, nil
+	}
+
+	if finalized.Root != l.handledCheckpoint.Root {
+		l.handledCheckpoint = finalized
+		err = l.checkpointStorer.StoreCheckpoint(l.domainID, finalized)
+		if err != nil {
+			l.log.Warn().Err(err).Msgf("Unable to store checkpoint on epoch %d", finalized.Epoch)
+		}
+		l.metrics.SetGauge("spectre_handled_finalized_epoch", map[string]string{"source": fmt.Sprint(l.domainID)}, float64(finalized.Epoch))
+	}
+	return handled, nil
+}
+
+// handle calls the event handler with the epochs skipped since its last processed
+// checkpoint and the finalized checkpoint
+func (l *EVMListener) handle(state *handlerState, finality *apiv1.Finality) error {
+	finalized := finality.Finalized
+	gapHandler, ok := state.handler.(GapHandler)
+	if ok && state.checkpoint.Root != (phase0.Root{}) && finalized.Epoch > state.checkpoint.Epoch+1 {
+		err := gapHandler.HandleGap(state.checkpoint.Epoch+1, finalized.Epoch-1)
+		if err != nil {
+			return err
+		}
+	}
+
+	l.log.Debug().Str("handler", state.name).Msgf("Handling events for checkpoint on epoch %d", finalized.Epoch)
+
+	err := state.handler.HandleEvents(finality)
+	if err != nil {
+		return err
+	}
+
+	l.log.Debug().Str("handler", state.name).Msgf("Handled events for checkpoint on epoch %d", finalized.Epoch)
+	return nil
+}
 
-			l.log.Debug().Msgf("Handled events for checkpoint on epoch %d", finalityCheckpoint.Data.Finalized.Epoch)
+// detectGap logs and exposes finalized epochs skipped since the latest observed checkpoint.
+// Gaps are only detected once a checkpoint was observed.
+func (l *EVMListener) detectGap(finalized *phase0.Checkpoint) {
+	if finalized.Root == l.latestCheckpoint.Root {
+		return
+	}
+	latest := l.latestCheckpoint
+	l.latestCheckpoint = finalized
+	if latest.Root == (phase0.Root{}) || finalized.Epoch <= latest.Epoch+1 {
+		return
+	}
 
-			latestCheckpoint = finalityCheckpoint.Data.Finalized.Root.String()
+	from := latest.Epoch + 1
+	to := finalized.Epoch - 1
+	l.log.Warn().Msgf("Skipped finalized epochs %d-%d", from, to)
+
+	labels := map[string]string{"source": fmt.Sprint(l.domainID)}
+	l.skippedEpochs += uint64(to-from) + 1
+	l.metrics.SetGauge("spectre_skipped_epochs", labels, float64(l.skippedEpochs))
+	l.metrics.SetGauge("spectre_last_skipped_epoch_from", labels, float64(from))
+	l.metrics.SetGauge("spectre_last_skipped_epoch_to", labels, float64(to))
+}
+
+// nextRetry returns the time until the earliest retry of a failing handler
+func (l *EVMListener) nextRetry() (time.Duration, bool) {
+	var next time.Duration
+	found := false
+	for _, state := range l.handlers {
+		if state.failures == 0 {
+			continue
 		}
+
+		retry := time.Until(state.retryAt)
+		if !found || retry < next {
+			next = retry
+			found = true
+		}
+	}
+	return next, found
+}
+
+// backoff doubles the retry interval for every consecutive failure up to the maximum backoff
+func (l *EVMListener) backoff(failures uint64) time.Duration {
+	backoff := l.retryInterval
+	for i := uint64(1); i < failures && backoff < MAX_RETRY_BACKOFF; i++ {
+		backoff *= 2
+	}
+	if backoff > MAX_RETRY_BACKOFF {
+		return MAX_RETRY_BACKOFF
+	}
+	return backoff
+}
+
+func (l *EVMListener) handlerLabels(state *handlerState) map[string]string {
+	return map[string]string{
+		"source":  fmt.Sprint(l.domainID),
+		"handler": state.name,
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/beacon"
 	"github.com/sygmaprotocol/spectre-node/chains/evm/listener"
 	"github.com/sygmaprotocol/spectre-node/mock"
 	"go.uber.org/mock/gomock"
@@ -22,9 +24,22 @@ import (
 type ListenerTestSuite struct {
 	suite.Suite
 
+	ctrl               *gomock.Controller
 	listener           *listener.EVMListener
 	mockBeaconProvider *mock.MockBeaconProvider
 	mockEventHandler   *mock.MockEventHandler
+	mockEventStream    *mock.MockEventStream
+	mockCheckpoints    *mock.MockCheckpointStorer
+	mockMetrics        *mock.MockMetrics
+	mockGapHandler     *mock.MockGapHandler
+
+	storedCheckpoint atomic.Pointer[phase0.Checkpoint]
+}
+
+// gapEventHandler is an event handler that also handles skipped epochs
+type gapEventHandler struct {
+	*mock.MockEventHandler
+	*mock.MockGapHandler
 }
 
 func TestRunListenerTestSuite(t *testing.T) {
@@ -33,14 +48,34 @@ func TestRunListenerTestSuite(t *testing.T) {
 
 func (s *ListenerTestSuite) SetupTest() {
 	ctrl := gomock.NewController(s.T())
+	s.ctrl = ctrl
 	s.mockBeaconProvider = mock.NewMockBeaconProvider(ctrl)
 	s.mockEventHandler = mock.NewMockEventHandler(ctrl)
+	s.mockEventStream = mock.NewMockEventStream(ctrl)
+	s.mockCheckpoints = mock.NewMockCheckpointStorer(ctrl)
+	s.mockMetrics = mock.NewMockMetrics(ctrl)
+	s.mockGapHandler = mock.NewMockGapHandler(ctrl)
+
+	s.storedCheckpoint.Store(nil)
+	s.mockCheckpoints.EXPECT().Checkpoint(uint8(1)).DoAndReturn(func(domainID uint8) (*phase0.Checkpoint, error) {
+		return s.storedCheckpoint.Load(), nil
+	}).AnyTimes()
+	s.mockCheckpoints.EXPECT().StoreCheckpoint(uint8(1), gomock.Any()).DoAndReturn(func(domainID uint8, checkpoint *phase0.Checkpoint) error {
+		s.storedCheckpoint.Store(checkpoint)
+		return nil
+	}).AnyTimes()
+	s.mockMetrics.EXPECT().SetGauge("spectre_handled_finalized_epoch", map[string]string{"source": "1"}, gomock.Any()).AnyTimes()
+	s.mockMetrics.EXPECT().SetGauge("spectre_handler_failures", gomock.Any(), gomock.Any()).AnyTimes()
 
 	s.listener = listener.NewEVMListener(
 		s.mockBeaconProvider,
+		nil,
+		s.mockCheckpoints,
+		s.mockMetrics,
 		[]listener.EventHandler{s.mockEventHandler, s.mockEventHandler},
 		1,
 		time.Millisecond*50,
+		time.Hour,
 	)
 }
 
@@ -115,3 +150,185 @@ func (s *ListenerTestSuite) Test_ListenToEvents_ValidCheckpoint() {
 	time.Sleep(time.Millisecond * 75)
 	cancel()
 }
+
+func (s *ListenerTestSuite) Test_ListenToEvents_EventStreamConnected() {
+	s.listener = listener.NewEVMListener(
+		s.mockBeaconProvider,
+		s.mockEventStream,
+		s.mockCheckpoints,
+		s.mockMetrics,
+		[]listener.EventHandler{s.mockEventHandler},
+		1,
+		time.Millisecond*50,
+		time.Hour,
+	)
+	s.mockEventStream.EXPECT().Subscribe(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, events chan<- *beacon.Event) {
+		time.Sleep(time.Millisecond * 20)
+		events <- &beacon.Event{Topic: beacon.FINALIZED_CHECKPOINT_TOPIC}
+	})
+	s.mockEventStream.EXPECT().Connected().Return(true).Times(2)
+	s.mockBeaconProvider.EXPECT().Finality(gomock.Any(), gomock.Any()).Return(&api.Response[*apiv1.Finality]{
+		Data: &apiv1.Finality{
+			Finalized: &phase0.Checkpoint{
+				Root: phase0.Root([32]byte{1}),
+			},
+		},
+	}, nil)
+	s.mockEventHandler.EXPECT().HandleEvents(gomock.Any()).Return(nil)
+	s.mockBeaconProvider.EXPECT().Finality(gomock.Any(), gomock.Any()).Return(&api.Response[*apiv1.Finality]{
+		Data: &apiv1.Finality{
+			Finalized: &phase0.Checkpoint{
+				Root: phase0.Root([32]byte{1}),
+			},
+		},
+	}, nil)
+	// handled on event before the retry interval
+	s.mockBeaconProvider.EXPECT().Finality(gomock.Any(), gomock.Any()).Return(&api.Response[*apiv1.Finality]{
+		Data: &apiv1.Finality{
+			Finalized: &phase0.Checkpoint{
+				Root: phase0.Root([32]byte{2}),
+			},
+		},
+	}, nil).Times(2)
+	s.mockEventHandler.EXPECT().HandleEvents(gomock.Any()).Return(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go s.listener.ListenToEvents(ctx, big.NewInt(0))
+
+	time.Sleep(time.Millisecond * 40)
+	cancel()
+}
+
+func (s *ListenerTestSuite) Test_ListenToEvents_EventStreamDisconnected() {
+	s.listener = listener.NewEVMListener(
+		s.mockBeaconProvider,
+		s.mockEventStream,
+		s.mockCheckpoints,
+		s.mockMetrics,
+		[]listener.EventHandler{s.mockEventHandler},
+		1,
+		time.Millisecond*50,
+		time.Hour,
+	)
+	s.mockEventStream.EXPECT().Subscribe(gomock.Any(), gomock.Any())
+	s.mockEventStream.EXPECT().Connected().Return(false).Times(2)
+	// falls back to polling every retry interval
+	s.mockBeaconProvider.EXPECT().Finality(gomock.Any(), gomock.Any()).Return(&api.Response[*apiv1.Finality]{
+		Data: &apiv1.Finality{
+			Finalized: &phase0.Checkpoint{
+				Root: phase0.Root([32]byte{1}),
+			},
+		},
+	}, nil).Times(3)
+	s.mockEventHandler.EXPECT().HandleEvents(gomock.Any()).Return(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go s.listener.ListenToEvents(ctx, big.NewInt(0))
+
+	time.Sleep(time.Millisecond * 75)
+	cancel()
+}
+
+func (s *ListenerTestSuite) Test_ListenToEvents_ResumesFromStoredCheckpoint() {
+	s.storedCheckpoint.Store(&phase0.Checkpoint{
+		Epoch: 10,
+		Root:  phase0.Root([32]byte{1}),
+	})
+	s.mockBeaconProvider.EXPECT().Finality(gomock.Any(), gomock.Any()).Return(&api.Response[*apiv1.Finality]{
+		Data: &apiv1.Finality{
+			Finalized: &phase0.Checkpoint{
+				Epoch: 10,
+				Root:  phase0.Root([32]byte{1}),
+			},
+		},
+	}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go s.listener.ListenToEvents(ctx, big.NewInt(0))
+
+	time.Sleep(time.Millisecond * 25)
+	cancel()
+}
+
+func (s *ListenerTestSuite) Test_ListenToEvents_SkippedEpochs() {
+	s.listener = listener.NewEVMListener(
+		s.mockBeaconProvider,
+		nil,
+		s.mockCheckpoints,
+		s.mockMetrics,
+		[]listener.EventHandler{&gapEventHandler{s.mockEventHandler, s.mockGapHandler}},
+		1,
+		time.Millisecond*50,
+		time.Hour,
+	)
+	s.storedCheckpoint.Store(&phase0.Checkpoint{
+		Epoch: 10,
+		Root:  phase0.Root([32]byte{1}),
+	})
+	finalized := &phase0.Checkpoint{
+		Epoch: 15,
+		Root:  phase0.Root([32]byte{2}),
+	}
+	s.mockBeaconProvider.EXPECT().Finality(gomock.Any(), gomock.Any()).Return(&api.Response[*apiv1.Finality]{
+		Data: &apiv1.Finality{
+			Finalized: finalized,
+		},
+	}, nil).Times(3)
+	// failed gap handling is retried before the checkpoint is handled
+	s.mockGapHandler.EXPECT().HandleGap(phase0.Epoch(11), phase0.Epoch(14)).Return(fmt.Errorf("error"))
+	s.mockGapHandler.EXPECT().HandleGap(phase0.Epoch(11), phase0.Epoch(14)).Return(nil)
+	s.mockEventHandler.EXPECT().HandleEvents(gomock.Any()).Return(nil)
+	labels := map[string]string{"source": "1"}
+	s.mockMetrics.EXPECT().SetGauge("spectre_skipped_epochs", labels, float64(4))
+	s.mockMetrics.EXPECT().SetGauge("spectre_last_skipped_epoch_from", labels, float64(11))
+	s.mockMetrics.EXPECT().SetGauge("spectre_last_skipped_epoch_to", labels, float64(14))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go s.listener.ListenToEvents(ctx, big.NewInt(0))
+
+	time.Sleep(time.Millisecond * 75)
+	cancel()
+
+	s.Equal(s.storedCheckpoint.Load(), finalized)
+}
+
+func (s *ListenerTestSuite) Test_ListenToEvents_FailingHandlerIsolated() {
+	mockFailingHandler := mock.NewMockEventHandler(s.ctrl)
+	s.listener = listener.NewEVMListener(
+		s.mockBeaconProvider,
+		nil,
+		s.mockCheckpoints,
+		s.mockMetrics,
+		[]listener.EventHandler{mockFailingHandler, s.mockEventHandler},
+		1,
+		time.Millisecond*50,
+		time.Hour,
+	)
+	s.mockBeaconProvider.EXPECT().Finality(gomock.Any(), gomock.Any()).Return(&api.Response[*apiv1.Finality]{
+		Data: &apiv1.Finality{
+			Finalized: &phase0.Checkpoint{
+				Epoch: 1,
+				Root:  phase0.Root([32]byte{1}),
+			},
+		},
+	}, nil)
+	s.mockBeaconProvider.EXPECT().Finality(gomock.Any(), gomock.Any()).Return(&api.Response[*apiv1.Finality]{
+		Data: &apiv1.Finality{
+			Finalized: &phase0.Checkpoint{
+				Epoch: 2,
+				Root:  phase0.Root([32]byte{2}),
+			},
+		},
+	}, nil)
+	mockFailingHandler.EXPECT().HandleEvents(gomock.Any()).Return(fmt.Errorf("error")).Times(2)
+	s.mockEventHandler.EXPECT().HandleEvents(gomock.Any()).Return(nil).Times(2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go s.listener.ListenToEvents(ctx, big.NewInt(0))
+
+	time.Sleep(time.Millisecond * 75)
+	cancel()
+
+	// the checkpoint is stored only once every handler processed it
+	s.Nil(s.storedCheckpoint.Load())
+}
@@ -0,0 +1,60 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package message
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
+)
+
+const (
+	EVMExecutionStepMessage  message.MessageType   = "EVMExecutionStepMessage"
+	EVMExecutionStepProposal proposal.ProposalType = "EVMExecutionStepProposal"
+)
+
+// ExecutionField is an execution payload header field with its SSZ branch
+// to the finalized beacon block header root
+type ExecutionField struct {
+	Name  string
+	Value [32]byte
+	Proof [][]byte
+}
+
+// StorageProof is the merkle patricia proof of a storage slot against the account storage root
+type StorageProof struct {
+	Key   [32]byte
+	Value [32]byte
+	Proof [][]byte
+}
+
+// AccountProof is the merkle patricia proof of an account against the execution state root
+type AccountProof struct {
+	Account       common.Address
+	Proof         [][]byte
+	StorageProofs []StorageProof
+}
+
+func NewEvmExecutionStepMessage(source uint8, destination uint8, stepData StepData) *message.Message {
+	return &message.Message{
+		Source:      source,
+		Destination: destination,
+		Data:        stepData,
+		Type:        EVMExecutionStepMessage,
+	}
+}
+
+type EvmExecutionStepHandler struct{}
+
+func (h *EvmExecutionStepHandler) HandleMessage(m *message.Message) (*proposal.Proposal, error) {
+	log.Debug().Uint8("domainID", m.Destination).Msgf("Received execution step message from domain %d", m.Source)
+
+	return &proposal.Proposal{
+		Source:      m.Source,
+		Destination: m.Destination,
+		Data:        m.Data,
+		Type:        EVMExecutionStepProposal,
+	}, nil
+}
@@ -22,11 +22,26 @@ type SyncStepInput struct {
 	ExecutionPayloadRoot [32]byte
 }
 
+// BlockHeader is the finalized execution block number and hash with their
+// SSZ branches to the finalized beacon block header root
+type BlockHeader struct {
+	Number      uint64
+	NumberProof [][]byte
+	Hash        [32]byte
+	HashProof   [][]byte
+}
+
 type StepData struct {
 	Proof          []byte
 	Args           SyncStepInput
 	StateRoot      [32]byte
 	StateRootProof [][]byte
+	BlockHeader    *BlockHeader `json:",omitempty"`
+
+	// Execution payload fields and account proofs requested by the destination,
+	// only sent with execution step messages
+	ExecutionFields []ExecutionField `json:",omitempty"`
+	AccountProofs   []AccountProof   `json:",omitempty"`
 }
 
 func NewEvmStepMessage(source uint8, destination uint8, stepData StepData) *message.Message {
@@ -0,0 +1,52 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package monitor
+
+import (
+	"context"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+)
+
+type FinalityProvider interface {
+	Finality(ctx context.Context, opts *api.FinalityOpts) (*api.Response[*apiv1.Finality], error)
+}
+
+type SourceFinality interface {
+	FinalizedSlot(ctx context.Context) (uint64, error)
+}
+
+// Source is a source beacon chain destination domains are monitored against
+type Source struct {
+	Finality       SourceFinality
+	SlotsPerPeriod uint64
+	SlotDuration   time.Duration
+}
+
+// BeaconFinality returns the latest finalized slot of the source beacon chain
+type BeaconFinality struct {
+	beaconProvider FinalityProvider
+	slotsPerEpoch  uint64
+}
+
+func NewBeaconFinality(beaconProvider FinalityProvider, slotsPerEpoch uint64) *BeaconFinality {
+	return &BeaconFinality{
+		beaconProvider: beaconProvider,
+		slotsPerEpoch:  slotsPerEpoch,
+	}
+}
+
+// FinalizedSlot returns the first slot of the latest finalized epoch
+func (f *BeaconFinality) FinalizedSlot(ctx context.Context) (uint64, error) {
+	finality, err := f.beaconProvider.Finality(ctx, &api.FinalityOpts{
+		State: "finalized",
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(finality.Data.Finalized.Epoch) * f.slotsPerEpoch, nil
+}
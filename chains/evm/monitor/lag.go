@@ -0,0 +1,210 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/spectre-node/alert"
+)
+
+const (
+	STATE_ROOT_LAG_ALERT   = "StateRootLag"
+	ROTATION_OVERDUE_ALERT = "RotationOverdue"
+)
+
+type LatestSlotFetcher interface {
+	LatestStateRootSlot(destinationDomainID uint8, sourceDomainID uint8) (uint64, error)
+	LatestRotationSlot(destinationDomainID uint8, sourceDomainID uint8) (uint64, error)
+}
+
+type StateRootFetcher interface {
+	GetStateRoot(domainID uint8, slot uint64) ([32]byte, error)
+	Head(domainID uint8) (uint64, error)
+}
+
+type GaugeSetter interface {
+	SetGauge(name string, labels map[string]string, value float64)
+}
+
+type Alerter interface {
+	Fire(alert *alert.Alert)
+	Resolve(alert *alert.Alert)
+}
+
+// LagMonitor measures how far the state roots on the destination domain are
+// behind the source finalized slot and alerts when the SLO or the slot lag
+// threshold is breached or the committee rotation for the current period is overdue
+type LagMonitor struct {
+	domainID uint8
+
+	slotFetcher      LatestSlotFetcher
+	stateRootFetcher StateRootFetcher
+	sources          map[uint8]*Source
+	metrics          GaugeSetter
+	alerter          Alerter
+
+	maxStateRootAge     time.Duration
+	lagThreshold        uint64
+	rotationGracePeriod time.Duration
+	interval            time.Duration
+
+	log zerolog.Logger
+}
+
+func NewLagMonitor(
+	domainID uint8,
+	slotFetcher LatestSlotFetcher,
+	stateRootFetcher StateRootFetcher,
+	sources map[uint8]*Source,
+	metrics GaugeSetter,
+	alerter Alerter,
+	maxStateRootAge time.Duration,
+	lagThreshold uint64,
+	rotationGracePeriod time.Duration,
+	interval time.Duration,
+) *LagMonitor {
+	return &LagMonitor{
+		domainID:            domainID,
+		slotFetcher:         slotFetcher,
+		stateRootFetcher:    stateRootFetcher,
+		sources:             sources,
+		metrics:             metrics,
+		alerter:             alerter,
+		maxStateRootAge:     maxStateRootAge,
+		lagThreshold:        lagThreshold,
+		rotationGracePeriod: rotationGracePeriod,
+		interval:            interval,
+		log:                 log.With().Uint8("domainID", domainID).Logger(),
+	}
+}
+
+// Monitor periodically checks the state root lag for every source domain
+func (m *LagMonitor) Monitor(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for sourceDomainID, source := range m.sources {
+				if sourceDomainID == m.domainID {
+					continue
+				}
+
+				err := m.Check(ctx, sourceDomainID, source)
+				if err != nil {
+					m.log.Warn().Err(err).Msgf("Unable to check state root lag for domain %d", sourceDomainID)
+				}
+			}
+		}
+	}
+}
+
+// Check compares the source finalized slot with the latest state root and
+// rotation on the destination domain. The latest slot is read from the
+// light client if no state root has been indexed yet, as history isn't
+// indexed on the first run and the destination might already be stalled.
+func (m *LagMonitor) Check(ctx context.Context, sourceDomainID uint8, source *Source) error {
+	finalizedSlot, err := source.Finality.FinalizedSlot(ctx)
+	if err != nil {
+		return err
+	}
+	latestSlot, err := m.slotFetcher.LatestStateRootSlot(m.domainID, sourceDomainID)
+	if err != nil {
+		return err
+	}
+	if latestSlot == 0 {
+		latestSlot, err = m.stateRootFetcher.Head(sourceDomainID)
+		if err != nil {
+			return err
+		}
+	}
+	if latestSlot == 0 {
+		return nil
+	}
+	stateRoot, err := m.stateRootFetcher.GetStateRoot(sourceDomainID, latestSlot)
+	if err != nil {
+		return err
+	}
+	if stateRoot == [32]byte{} {
+		return fmt.Errorf("state root for slot %d missing on-chain", latestSlot)
+	}
+
+	labels := map[string]string{
+		"source":      fmt.Sprint(sourceDomainID),
+		"destination": fmt.Sprint(m.domainID),
+	}
+	var lagSlots uint64
+	if finalizedSlot > latestSlot {
+		lagSlots = finalizedSlot - latestSlot
+	}
+	lag := time.Duration(lagSlots) * source.SlotDuration
+	m.metrics.SetGauge("spectre_state_root_lag_slots", labels, float64(lagSlots))
+	m.metrics.SetGauge("spectre_state_root_lag_seconds", labels, lag.Seconds())
+
+	lagAlert := &alert.Alert{
+		Key:               fmt.Sprintf("%s:%d:%d", STATE_ROOT_LAG_ALERT, sourceDomainID, m.domainID),
+		Name:              STATE_ROOT_LAG_ALERT,
+		Severity:          "critical",
+		SourceDomain:      sourceDomainID,
+		DestinationDomain: m.domainID,
+		Message: fmt.Sprintf(
+			"State root from domain %d on domain %d is %s (%d slots) behind finalized slot %d",
+			sourceDomainID, m.domainID, lag, lagSlots, finalizedSlot,
+		),
+	}
+	// a lag threshold of 0 disables the slot lag alert
+	if lag > m.maxStateRootAge || (m.lagThreshold != 0 && lagSlots > m.lagThreshold) {
+		m.alerter.Fire(lagAlert)
+	} else {
+		m.alerter.Resolve(lagAlert)
+	}
+
+	return m.checkRotation(sourceDomainID, source, finalizedSlot, labels)
+}
+
+// checkRotation alerts if the destination domain doesn't have the committee for the current
+// period after the grace period. Rotation events are emitted with a slot from the period
+// before the committee period they enable.
+func (m *LagMonitor) checkRotation(sourceDomainID uint8, source *Source, finalizedSlot uint64, labels map[string]string) error {
+	rotationSlot, err := m.slotFetcher.LatestRotationSlot(m.domainID, sourceDomainID)
+	if err != nil {
+		return err
+	}
+	if rotationSlot == 0 {
+		return nil
+	}
+
+	currentPeriod := finalizedSlot / source.SlotsPerPeriod
+	rotatedPeriod := rotationSlot/source.SlotsPerPeriod + 1
+	elapsed := time.Duration(finalizedSlot-currentPeriod*source.SlotsPerPeriod) * source.SlotDuration
+	overdue := currentPeriod > rotatedPeriod && elapsed > m.rotationGracePeriod
+
+	rotationAlert := &alert.Alert{
+		Key:               fmt.Sprintf("%s:%d:%d", ROTATION_OVERDUE_ALERT, sourceDomainID, m.domainID),
+		Name:              ROTATION_OVERDUE_ALERT,
+		Severity:          "critical",
+		SourceDomain:      sourceDomainID,
+		DestinationDomain: m.domainID,
+		Message: fmt.Sprintf(
+			"Committee from domain %d on domain %d is for period %d while the current period is %d",
+			sourceDomainID, m.domainID, rotatedPeriod, currentPeriod,
+		),
+	}
+	if overdue {
+		m.metrics.SetGauge("spectre_rotation_overdue", labels, 1)
+		m.alerter.Fire(rotationAlert)
+	} else {
+		m.metrics.SetGauge("spectre_rotation_overdue", labels, 0)
+		m.alerter.Resolve(rotationAlert)
+	}
+	return nil
+}
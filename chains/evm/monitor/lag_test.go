@@ -0,0 +1,162 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package monitor_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/monitor"
+	"github.com/sygmaprotocol/spectre-node/mock"
+	"go.uber.org/mock/gomock"
+)
+
+type LagMonitorTestSuite struct {
+	suite.Suite
+
+	lagMonitor *monitor.LagMonitor
+
+	mockSlotFetcher      *mock.MockLatestSlotFetcher
+	mockStateRootFetcher *mock.MockStateRootFetcher
+	mockMetrics          *mock.MockGaugeSetter
+	mockAlerter          *mock.MockAlerter
+	mockSourceFinality   *mock.MockSourceFinality
+	source               *monitor.Source
+	destinationDomainID  uint8
+	sourceDomainID       uint8
+}
+
+func TestRunLagMonitorTestSuite(t *testing.T) {
+	suite.Run(t, new(LagMonitorTestSuite))
+}
+
+func (s *LagMonitorTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockSlotFetcher = mock.NewMockLatestSlotFetcher(ctrl)
+	s.mockStateRootFetcher = mock.NewMockStateRootFetcher(ctrl)
+	s.mockMetrics = mock.NewMockGaugeSetter(ctrl)
+	s.mockAlerter = mock.NewMockAlerter(ctrl)
+	s.mockSourceFinality = mock.NewMockSourceFinality(ctrl)
+	s.destinationDomainID = 2
+	s.sourceDomainID = 1
+	s.source = &monitor.Source{
+		Finality:       s.mockSourceFinality,
+		SlotsPerPeriod: 8192,
+		SlotDuration:   time.Second * 12,
+	}
+	s.lagMonitor = monitor.NewLagMonitor(
+		s.destinationDomainID,
+		s.mockSlotFetcher,
+		s.mockStateRootFetcher,
+		map[uint8]*monitor.Source{s.sourceDomainID: s.source},
+		s.mockMetrics,
+		s.mockAlerter,
+		time.Hour,
+		256,
+		time.Hour,
+		time.Second,
+	)
+}
+
+func (s *LagMonitorTestSuite) Test_Check_FetchingFinalityFails() {
+	s.mockSourceFinality.EXPECT().FinalizedSlot(gomock.Any()).Return(uint64(0), fmt.Errorf("error"))
+
+	err := s.lagMonitor.Check(context.Background(), s.sourceDomainID, s.source)
+
+	s.NotNil(err)
+}
+
+func (s *LagMonitorTestSuite) Test_Check_NoStateRoots() {
+	s.mockSourceFinality.EXPECT().FinalizedSlot(gomock.Any()).Return(uint64(10000), nil)
+	s.mockSlotFetcher.EXPECT().LatestStateRootSlot(s.destinationDomainID, s.sourceDomainID).Return(uint64(0), nil)
+	s.mockStateRootFetcher.EXPECT().Head(s.sourceDomainID).Return(uint64(0), nil)
+
+	err := s.lagMonitor.Check(context.Background(), s.sourceDomainID, s.source)
+
+	s.Nil(err)
+}
+
+func (s *LagMonitorTestSuite) Test_Check_FetchingHeadFails() {
+	s.mockSourceFinality.EXPECT().FinalizedSlot(gomock.Any()).Return(uint64(10000), nil)
+	s.mockSlotFetcher.EXPECT().LatestStateRootSlot(s.destinationDomainID, s.sourceDomainID).Return(uint64(0), nil)
+	s.mockStateRootFetcher.EXPECT().Head(s.sourceDomainID).Return(uint64(0), fmt.Errorf("error"))
+
+	err := s.lagMonitor.Check(context.Background(), s.sourceDomainID, s.source)
+
+	s.NotNil(err)
+}
+
+func (s *LagMonitorTestSuite) Test_Check_NothingIndexed_StalledDestinationAlerts() {
+	s.mockSourceFinality.EXPECT().FinalizedSlot(gomock.Any()).Return(uint64(8192*3+1000), nil)
+	s.mockSlotFetcher.EXPECT().LatestStateRootSlot(s.destinationDomainID, s.sourceDomainID).Return(uint64(0), nil)
+	s.mockStateRootFetcher.EXPECT().Head(s.sourceDomainID).Return(uint64(8192*3), nil)
+	s.mockStateRootFetcher.EXPECT().GetStateRoot(s.sourceDomainID, uint64(8192*3)).Return([32]byte{1}, nil)
+	s.mockMetrics.EXPECT().SetGauge("spectre_state_root_lag_slots", gomock.Any(), float64(1000))
+	s.mockMetrics.EXPECT().SetGauge("spectre_state_root_lag_seconds", gomock.Any(), float64(12000))
+	s.mockAlerter.EXPECT().Fire(gomock.Any())
+	s.mockSlotFetcher.EXPECT().LatestRotationSlot(s.destinationDomainID, s.sourceDomainID).Return(uint64(0), nil)
+
+	err := s.lagMonitor.Check(context.Background(), s.sourceDomainID, s.source)
+
+	s.Nil(err)
+}
+
+func (s *LagMonitorTestSuite) Test_Check_StateRootMissingOnChain() {
+	s.mockSourceFinality.EXPECT().FinalizedSlot(gomock.Any()).Return(uint64(10000), nil)
+	s.mockSlotFetcher.EXPECT().LatestStateRootSlot(s.destinationDomainID, s.sourceDomainID).Return(uint64(9990), nil)
+	s.mockStateRootFetcher.EXPECT().GetStateRoot(s.sourceDomainID, uint64(9990)).Return([32]byte{}, nil)
+
+	err := s.lagMonitor.Check(context.Background(), s.sourceDomainID, s.source)
+
+	s.NotNil(err)
+}
+
+func (s *LagMonitorTestSuite) Test_Check_WithinSLO() {
+	s.mockSourceFinality.EXPECT().FinalizedSlot(gomock.Any()).Return(uint64(10000), nil)
+	s.mockSlotFetcher.EXPECT().LatestStateRootSlot(s.destinationDomainID, s.sourceDomainID).Return(uint64(9990), nil)
+	s.mockStateRootFetcher.EXPECT().GetStateRoot(s.sourceDomainID, uint64(9990)).Return([32]byte{1}, nil)
+	s.mockMetrics.EXPECT().SetGauge("spectre_state_root_lag_slots", gomock.Any(), float64(10))
+	s.mockMetrics.EXPECT().SetGauge("spectre_state_root_lag_seconds", gomock.Any(), float64(120))
+	s.mockAlerter.EXPECT().Resolve(gomock.Any()).Times(2)
+	s.mockSlotFetcher.EXPECT().LatestRotationSlot(s.destinationDomainID, s.sourceDomainID).Return(uint64(8000), nil)
+	s.mockMetrics.EXPECT().SetGauge("spectre_rotation_overdue", gomock.Any(), float64(0))
+
+	err := s.lagMonitor.Check(context.Background(), s.sourceDomainID, s.source)
+
+	s.Nil(err)
+}
+
+func (s *LagMonitorTestSuite) Test_Check_LagThresholdExceeded() {
+	s.mockSourceFinality.EXPECT().FinalizedSlot(gomock.Any()).Return(uint64(10000), nil)
+	s.mockSlotFetcher.EXPECT().LatestStateRootSlot(s.destinationDomainID, s.sourceDomainID).Return(uint64(9700), nil)
+	s.mockStateRootFetcher.EXPECT().GetStateRoot(s.sourceDomainID, uint64(9700)).Return([32]byte{1}, nil)
+	s.mockMetrics.EXPECT().SetGauge("spectre_state_root_lag_slots", gomock.Any(), float64(300))
+	s.mockMetrics.EXPECT().SetGauge("spectre_state_root_lag_seconds", gomock.Any(), float64(3600))
+	s.mockAlerter.EXPECT().Fire(gomock.Any())
+	s.mockAlerter.EXPECT().Resolve(gomock.Any())
+	s.mockSlotFetcher.EXPECT().LatestRotationSlot(s.destinationDomainID, s.sourceDomainID).Return(uint64(8000), nil)
+	s.mockMetrics.EXPECT().SetGauge("spectre_rotation_overdue", gomock.Any(), float64(0))
+
+	err := s.lagMonitor.Check(context.Background(), s.sourceDomainID, s.source)
+
+	s.Nil(err)
+}
+
+func (s *LagMonitorTestSuite) Test_Check_LagAndRotationOverdue() {
+	s.mockSourceFinality.EXPECT().FinalizedSlot(gomock.Any()).Return(uint64(8192*3+1000), nil)
+	s.mockSlotFetcher.EXPECT().LatestStateRootSlot(s.destinationDomainID, s.sourceDomainID).Return(uint64(8192*3), nil)
+	s.mockStateRootFetcher.EXPECT().GetStateRoot(s.sourceDomainID, uint64(8192*3)).Return([32]byte{1}, nil)
+	s.mockMetrics.EXPECT().SetGauge("spectre_state_root_lag_slots", gomock.Any(), float64(1000))
+	s.mockMetrics.EXPECT().SetGauge("spectre_state_root_lag_seconds", gomock.Any(), float64(12000))
+	s.mockAlerter.EXPECT().Fire(gomock.Any()).Times(2)
+	s.mockSlotFetcher.EXPECT().LatestRotationSlot(s.destinationDomainID, s.sourceDomainID).Return(uint64(8000), nil)
+	s.mockMetrics.EXPECT().SetGauge("spectre_rotation_overdue", gomock.Any(), float64(1))
+
+	err := s.lagMonitor.Check(context.Background(), s.sourceDomainID, s.source)
+
+	s.Nil(err)
+}
@@ -0,0 +1,248 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package monitor
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/spectre-node/store"
+)
+
+type ReceiptFetcher interface {
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	LatestBlock() (*big.Int, error)
+	From() common.Address
+}
+
+type MessageRequeuer interface {
+	Requeue(msg *store.QueuedMessage) error
+}
+
+type DeliveryRevoker interface {
+	RevokeDeliveries(sourceDomainID uint8, destinationDomainID uint8, slot uint64) ([]*store.Message, error)
+}
+
+// ReceiptTracker follows submitted proofs until their transaction has a receipt
+// with enough confirmations, or is dropped, and records the outcome. Successful
+// submissions are marked complete and the messages of reverted or dropped
+// submissions are queued again until the maximum number of retries.
+// Message deliveries recorded for failed steps are revoked.
+// Transactions resent with a higher gas price by the transactor get a new hash,
+// so a submission is only dropped if no transaction with its nonce was mined.
+type ReceiptTracker struct {
+	domainID uint8
+
+	receiptFetcher   ReceiptFetcher
+	submissionStorer PendingSubmissionStorer
+	requeuer         MessageRequeuer
+	deliveryRevoker  DeliveryRevoker
+
+	confirmations uint64
+	dropTimeout   time.Duration
+	maxRetries    int
+	interval      time.Duration
+
+	log zerolog.Logger
+}
+
+func NewReceiptTracker(
+	domainID uint8,
+	receiptFetcher ReceiptFetcher,
+	submissionStorer PendingSubmissionStorer,
+	requeuer MessageRequeuer,
+	deliveryRevoker DeliveryRevoker,
+	confirmations uint64,
+	dropTimeout time.Duration,
+	maxRetries int,
+	interval time.Duration,
+) *ReceiptTracker {
+	return &ReceiptTracker{
+		domainID:         domainID,
+		receiptFetcher:   receiptFetcher,
+		submissionStorer: submissionStorer,
+		requeuer:         requeuer,
+		deliveryRevoker:  deliveryRevoker,
+		confirmations:    confirmations,
+		dropTimeout:      dropTimeout,
+		maxRetries:       maxRetries,
+		interval:         interval,
+		log:              log.With().Uint8("domainID", domainID).Logger(),
+	}
+}
+
+// Track periodically checks the receipts of pending submissions
+func (t *ReceiptTracker) Track(ctx context.Context) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := t.CheckReceipts()
+			if err != nil {
+				t.log.Warn().Err(err).Msgf("Unable to check submission receipts")
+			}
+		}
+	}
+}
+
+// CheckReceipts records the outcome of pending submissions whose transaction
+// has enough confirmations or was dropped
+func (t *ReceiptTracker) CheckReceipts() error {
+	latestBlock, err := t.receiptFetcher.LatestBlock()
+	if err != nil {
+		return err
+	}
+	submissions, err := t.submissionStorer.PendingSubmissions(t.domainID)
+	if err != nil {
+		return err
+	}
+
+	for _, submission := range submissions {
+		if submission.Receipt != nil && submission.Receipt.Outcome == store.ReplacedOutcome {
+			// replaced submissions are confirmed or marked missing from the Spectre events
+			continue
+		}
+
+		receipt, err := t.receiptFetcher.TransactionReceipt(context.Background(), common.HexToHash(submission.TxHash))
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			return err
+		}
+		if receipt == nil {
+			err = t.trackNonce(submission)
+			if err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case receipt == nil && time.Since(submission.SubmittedAt) > t.dropTimeout:
+			replaced, err := t.replaced(submission)
+			if err != nil {
+				return err
+			}
+			if replaced {
+				t.log.Info().Uint8("source", submission.SourceDomain).Uint64("slot", submission.Slot).Str("type", string(submission.Type)).Msgf("Submission %s replaced by a transaction with nonce %d", submission.TxHash, *submission.Nonce)
+				submission.Receipt = &store.Receipt{Outcome: store.ReplacedOutcome}
+				err = t.submissionStorer.StoreSubmission(t.domainID, submission)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			submission.Receipt = &store.Receipt{Outcome: store.DroppedOutcome}
+		case receipt == nil:
+			continue
+		case latestBlock.Uint64()+1 < receipt.BlockNumber.Uint64()+t.confirmations:
+			// the receipt block counts as the first confirmation
+			continue
+		default:
+			submission.Receipt = &store.Receipt{
+				Outcome:           store.SuccessOutcome,
+				BlockNumber:       receipt.BlockNumber.Uint64(),
+				GasUsed:           receipt.GasUsed,
+				EffectiveGasPrice: receipt.EffectiveGasPrice,
+			}
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				submission.Receipt.Outcome = store.RevertOutcome
+			}
+		}
+
+		err = t.handleOutcome(submission)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// trackNonce records the nonce of the submission transaction while the node still knows it
+func (t *ReceiptTracker) trackNonce(submission *store.Submission) error {
+	if submission.Nonce != nil {
+		return nil
+	}
+
+	tx, _, err := t.receiptFetcher.TransactionByHash(context.Background(), common.HexToHash(submission.TxHash))
+	if err != nil {
+		if errors.Is(err, ethereum.NotFound) {
+			return nil
+		}
+		return err
+	}
+
+	nonce := tx.Nonce()
+	submission.Nonce = &nonce
+	return t.submissionStorer.StoreSubmission(t.domainID, submission)
+}
+
+// replaced returns true if a transaction with the submission nonce was mined
+// under another hash, which happens when the transactor resends it with a higher gas price
+func (t *ReceiptTracker) replaced(submission *store.Submission) (bool, error) {
+	if submission.Nonce == nil {
+		return false, nil
+	}
+
+	nonce, err := t.receiptFetcher.NonceAt(context.Background(), t.receiptFetcher.From(), nil)
+	if err != nil {
+		return false, err
+	}
+	return nonce > *submission.Nonce, nil
+}
+
+// handleOutcome marks successful submissions as confirmed and retries failed ones
+func (t *ReceiptTracker) handleOutcome(submission *store.Submission) error {
+	log := t.log.With().Uint8("source", submission.SourceDomain).Uint64("slot", submission.Slot).Str("type", string(submission.Type)).Str("hash", submission.TxHash).Logger()
+	receipt := submission.Receipt
+
+	switch {
+	case receipt.Outcome == store.SuccessOutcome:
+		log.Info().Uint64("gasUsed", receipt.GasUsed).Str("effectiveGasPrice", receipt.EffectiveGasPrice.String()).Msgf("Submission confirmed in block %d", receipt.BlockNumber)
+		submission.Status = store.ConfirmedSubmission
+	case submission.Retries >= t.maxRetries || submission.Data == nil:
+		log.Error().Int("retries", submission.Retries).Msgf("Submission failed with outcome %s", receipt.Outcome)
+		submission.Status = store.FailedSubmission
+		submission.Failure = string(receipt.Outcome)
+	default:
+		log.Warn().Int("retries", submission.Retries).Msgf("Submission failed with outcome %s, delivering it again", receipt.Outcome)
+		submission.Status = store.RetriedSubmission
+		submission.Retries++
+	}
+
+	err := t.submissionStorer.StoreSubmission(t.domainID, submission)
+	if err != nil {
+		return err
+	}
+	if receipt.Outcome != store.SuccessOutcome && submission.Type == store.StepProofType {
+		revoked, err := t.deliveryRevoker.RevokeDeliveries(submission.SourceDomain, t.domainID, submission.Slot)
+		if err != nil {
+			return err
+		}
+		if len(revoked) > 0 {
+			log.Warn().Msgf("Revoked delivery of %d messages", len(revoked))
+		}
+	}
+	if submission.Status != store.RetriedSubmission {
+		return nil
+	}
+
+	return t.requeuer.Requeue(&store.QueuedMessage{
+		Source:      submission.SourceDomain,
+		Destination: t.domainID,
+		Type:        submission.MessageType,
+		Data:        submission.Data,
+	})
+}
@@ -0,0 +1,300 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package monitor_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/monitor"
+	"github.com/sygmaprotocol/spectre-node/mock"
+	"github.com/sygmaprotocol/spectre-node/store"
+	"go.uber.org/mock/gomock"
+)
+
+type ReceiptTrackerTestSuite struct {
+	suite.Suite
+
+	tracker *monitor.ReceiptTracker
+
+	mockReceiptFetcher   *mock.MockReceiptFetcher
+	mockSubmissionStorer *mock.MockPendingSubmissionStorer
+	mockRequeuer         *mock.MockMessageRequeuer
+	mockDeliveryRevoker  *mock.MockDeliveryRevoker
+	domainID             uint8
+}
+
+func TestRunReceiptTrackerTestSuite(t *testing.T) {
+	suite.Run(t, new(ReceiptTrackerTestSuite))
+}
+
+func (s *ReceiptTrackerTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockReceiptFetcher = mock.NewMockReceiptFetcher(ctrl)
+	s.mockSubmissionStorer = mock.NewMockPendingSubmissionStorer(ctrl)
+	s.mockRequeuer = mock.NewMockMessageRequeuer(ctrl)
+	s.mockDeliveryRevoker = mock.NewMockDeliveryRevoker(ctrl)
+	s.domainID = 2
+	s.tracker = monitor.NewReceiptTracker(
+		s.domainID,
+		s.mockReceiptFetcher,
+		s.mockSubmissionStorer,
+		s.mockRequeuer,
+		s.mockDeliveryRevoker,
+		5,
+		time.Minute*15,
+		2,
+		time.Minute,
+	)
+}
+
+func (s *ReceiptTrackerTestSuite) submission(retries int) *store.Submission {
+	return &store.Submission{
+		Type:         store.RotateProofType,
+		SourceDomain: 1,
+		Slot:         100,
+		TxHash:       "0x01",
+		SubmittedAt:  time.Now(),
+		Status:       store.PendingSubmission,
+		MessageType:  "EVMRotateMessage",
+		Data:         json.RawMessage(`{"RotateProof":"AQ=="}`),
+		Retries:      retries,
+	}
+}
+
+func (s *ReceiptTrackerTestSuite) Test_CheckReceipts_FetchingLatestBlockFails() {
+	s.mockReceiptFetcher.EXPECT().LatestBlock().Return(nil, fmt.Errorf("error"))
+
+	err := s.tracker.CheckReceipts()
+
+	s.NotNil(err)
+}
+
+func (s *ReceiptTrackerTestSuite) Test_CheckReceipts_FetchingReceiptFails() {
+	s.mockReceiptFetcher.EXPECT().LatestBlock().Return(big.NewInt(100), nil)
+	s.mockSubmissionStorer.EXPECT().PendingSubmissions(s.domainID).Return([]*store.Submission{s.submission(0)}, nil)
+	s.mockReceiptFetcher.EXPECT().TransactionReceipt(gomock.Any(), common.HexToHash("0x01")).Return(nil, fmt.Errorf("error"))
+
+	err := s.tracker.CheckReceipts()
+
+	s.NotNil(err)
+}
+
+func (s *ReceiptTrackerTestSuite) Test_CheckReceipts_NotEnoughConfirmations_Skipped() {
+	s.mockReceiptFetcher.EXPECT().LatestBlock().Return(big.NewInt(99), nil)
+	s.mockSubmissionStorer.EXPECT().PendingSubmissions(s.domainID).Return([]*store.Submission{s.submission(0)}, nil)
+	s.mockReceiptFetcher.EXPECT().TransactionReceipt(gomock.Any(), common.HexToHash("0x01")).Return(&types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		BlockNumber: big.NewInt(96),
+	}, nil)
+
+	err := s.tracker.CheckReceipts()
+
+	s.Nil(err)
+}
+
+func (s *ReceiptTrackerTestSuite) Test_CheckReceipts_PendingTransaction_NonceRecorded() {
+	s.mockReceiptFetcher.EXPECT().LatestBlock().Return(big.NewInt(100), nil)
+	s.mockSubmissionStorer.EXPECT().PendingSubmissions(s.domainID).Return([]*store.Submission{s.submission(0)}, nil)
+	s.mockReceiptFetcher.EXPECT().TransactionReceipt(gomock.Any(), common.HexToHash("0x01")).Return(nil, ethereum.NotFound)
+	s.mockReceiptFetcher.EXPECT().TransactionByHash(gomock.Any(), common.HexToHash("0x01")).Return(types.NewTx(&types.LegacyTx{Nonce: 5}), true, nil)
+	s.mockSubmissionStorer.EXPECT().StoreSubmission(s.domainID, gomock.Any()).DoAndReturn(func(domainID uint8, submission *store.Submission) error {
+		s.Equal(submission.Status, store.PendingSubmission)
+		s.Equal(*submission.Nonce, uint64(5))
+		return nil
+	})
+
+	err := s.tracker.CheckReceipts()
+
+	s.Nil(err)
+}
+
+func (s *ReceiptTrackerTestSuite) Test_CheckReceipts_ReplacedAfterTimeout_NotRequeued() {
+	nonce := uint64(5)
+	submission := s.submission(0)
+	submission.SubmittedAt = time.Now().Add(-time.Minute * 20)
+	submission.Nonce = &nonce
+	s.mockReceiptFetcher.EXPECT().LatestBlock().Return(big.NewInt(100), nil)
+	s.mockSubmissionStorer.EXPECT().PendingSubmissions(s.domainID).Return([]*store.Submission{submission}, nil)
+	s.mockReceiptFetcher.EXPECT().TransactionReceipt(gomock.Any(), common.HexToHash("0x01")).Return(nil, ethereum.NotFound)
+	s.mockReceiptFetcher.EXPECT().From().Return(common.HexToAddress("0x02"))
+	s.mockReceiptFetcher.EXPECT().NonceAt(gomock.Any(), common.HexToAddress("0x02"), nil).Return(uint64(6), nil)
+	s.mockSubmissionStorer.EXPECT().StoreSubmission(s.domainID, gomock.Any()).DoAndReturn(func(domainID uint8, submission *store.Submission) error {
+		s.Equal(submission.Status, store.PendingSubmission)
+		s.Equal(submission.Receipt, &store.Receipt{Outcome: store.ReplacedOutcome})
+		s.Equal(submission.Retries, 0)
+		return nil
+	})
+
+	err := s.tracker.CheckReceipts()
+
+	s.Nil(err)
+}
+
+func (s *ReceiptTrackerTestSuite) Test_CheckReceipts_Replaced_Skipped() {
+	submission := s.submission(0)
+	submission.Receipt = &store.Receipt{Outcome: store.ReplacedOutcome}
+	s.mockReceiptFetcher.EXPECT().LatestBlock().Return(big.NewInt(100), nil)
+	s.mockSubmissionStorer.EXPECT().PendingSubmissions(s.domainID).Return([]*store.Submission{submission}, nil)
+
+	err := s.tracker.CheckReceipts()
+
+	s.Nil(err)
+}
+
+func (s *ReceiptTrackerTestSuite) Test_CheckReceipts_NonceNotMinedAfterTimeout_Requeued() {
+	nonce := uint64(5)
+	submission := s.submission(0)
+	submission.SubmittedAt = time.Now().Add(-time.Minute * 20)
+	submission.Nonce = &nonce
+	s.mockReceiptFetcher.EXPECT().LatestBlock().Return(big.NewInt(100), nil)
+	s.mockSubmissionStorer.EXPECT().PendingSubmissions(s.domainID).Return([]*store.Submission{submission}, nil)
+	s.mockReceiptFetcher.EXPECT().TransactionReceipt(gomock.Any(), common.HexToHash("0x01")).Return(nil, ethereum.NotFound)
+	s.mockReceiptFetcher.EXPECT().From().Return(common.HexToAddress("0x02"))
+	s.mockReceiptFetcher.EXPECT().NonceAt(gomock.Any(), common.HexToAddress("0x02"), nil).Return(uint64(5), nil)
+	s.mockSubmissionStorer.EXPECT().StoreSubmission(s.domainID, gomock.Any()).DoAndReturn(func(domainID uint8, submission *store.Submission) error {
+		s.Equal(submission.Status, store.RetriedSubmission)
+		s.Equal(submission.Receipt, &store.Receipt{Outcome: store.DroppedOutcome})
+		return nil
+	})
+	s.mockRequeuer.EXPECT().Requeue(gomock.Any()).Return(nil)
+
+	err := s.tracker.CheckReceipts()
+
+	s.Nil(err)
+}
+
+func (s *ReceiptTrackerTestSuite) Test_CheckReceipts_Success_Confirmed() {
+	s.mockReceiptFetcher.EXPECT().LatestBlock().Return(big.NewInt(100), nil)
+	s.mockSubmissionStorer.EXPECT().PendingSubmissions(s.domainID).Return([]*store.Submission{s.submission(0)}, nil)
+	s.mockReceiptFetcher.EXPECT().TransactionReceipt(gomock.Any(), common.HexToHash("0x01")).Return(&types.Receipt{
+		Status:            types.ReceiptStatusSuccessful,
+		BlockNumber:       big.NewInt(96),
+		GasUsed:           300000,
+		EffectiveGasPrice: big.NewInt(1000),
+	}, nil)
+	s.mockSubmissionStorer.EXPECT().StoreSubmission(s.domainID, gomock.Any()).DoAndReturn(func(domainID uint8, submission *store.Submission) error {
+		s.Equal(submission.Status, store.ConfirmedSubmission)
+		s.Equal(submission.Receipt, &store.Receipt{
+			Outcome:           store.SuccessOutcome,
+			BlockNumber:       96,
+			GasUsed:           300000,
+			EffectiveGasPrice: big.NewInt(1000),
+		})
+		return nil
+	})
+
+	err := s.tracker.CheckReceipts()
+
+	s.Nil(err)
+}
+
+func (s *ReceiptTrackerTestSuite) Test_CheckReceipts_Revert_Requeued() {
+	s.mockReceiptFetcher.EXPECT().LatestBlock().Return(big.NewInt(100), nil)
+	s.mockSubmissionStorer.EXPECT().PendingSubmissions(s.domainID).Return([]*store.Submission{s.submission(1)}, nil)
+	s.mockReceiptFetcher.EXPECT().TransactionReceipt(gomock.Any(), common.HexToHash("0x01")).Return(&types.Receipt{
+		Status:            types.ReceiptStatusFailed,
+		BlockNumber:       big.NewInt(90),
+		GasUsed:           50000,
+		EffectiveGasPrice: big.NewInt(1000),
+	}, nil)
+	s.mockSubmissionStorer.EXPECT().StoreSubmission(s.domainID, gomock.Any()).DoAndReturn(func(domainID uint8, submission *store.Submission) error {
+		s.Equal(submission.Status, store.RetriedSubmission)
+		s.Equal(submission.Receipt.Outcome, store.RevertOutcome)
+		s.Equal(submission.Receipt.GasUsed, uint64(50000))
+		s.Equal(submission.Retries, 2)
+		return nil
+	})
+	s.mockRequeuer.EXPECT().Requeue(&store.QueuedMessage{
+		Source:      1,
+		Destination: s.domainID,
+		Type:        "EVMRotateMessage",
+		Data:        json.RawMessage(`{"RotateProof":"AQ=="}`),
+	}).Return(nil)
+
+	err := s.tracker.CheckReceipts()
+
+	s.Nil(err)
+}
+
+func (s *ReceiptTrackerTestSuite) Test_CheckReceipts_StepRevert_DeliveriesRevoked() {
+	submission := s.submission(0)
+	submission.Type = store.StepProofType
+	submission.MessageType = "EVMStepMessage"
+	s.mockReceiptFetcher.EXPECT().LatestBlock().Return(big.NewInt(100), nil)
+	s.mockSubmissionStorer.EXPECT().PendingSubmissions(s.domainID).Return([]*store.Submission{submission}, nil)
+	s.mockReceiptFetcher.EXPECT().TransactionReceipt(gomock.Any(), common.HexToHash("0x01")).Return(&types.Receipt{
+		Status:      types.ReceiptStatusFailed,
+		BlockNumber: big.NewInt(90),
+	}, nil)
+	s.mockSubmissionStorer.EXPECT().StoreSubmission(s.domainID, gomock.Any()).Return(nil)
+	s.mockDeliveryRevoker.EXPECT().RevokeDeliveries(uint8(1), s.domainID, uint64(100)).Return([]*store.Message{{ID: "5"}}, nil)
+	s.mockRequeuer.EXPECT().Requeue(gomock.Any()).Return(nil)
+
+	err := s.tracker.CheckReceipts()
+
+	s.Nil(err)
+}
+
+func (s *ReceiptTrackerTestSuite) Test_CheckReceipts_StepSuccess_DeliveriesKept() {
+	submission := s.submission(0)
+	submission.Type = store.StepProofType
+	s.mockReceiptFetcher.EXPECT().LatestBlock().Return(big.NewInt(100), nil)
+	s.mockSubmissionStorer.EXPECT().PendingSubmissions(s.domainID).Return([]*store.Submission{submission}, nil)
+	s.mockReceiptFetcher.EXPECT().TransactionReceipt(gomock.Any(), common.HexToHash("0x01")).Return(&types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		BlockNumber: big.NewInt(90),
+	}, nil)
+	s.mockSubmissionStorer.EXPECT().StoreSubmission(s.domainID, gomock.Any()).Return(nil)
+
+	err := s.tracker.CheckReceipts()
+
+	s.Nil(err)
+}
+
+func (s *ReceiptTrackerTestSuite) Test_CheckReceipts_DroppedAfterTimeout_Requeued() {
+	submission := s.submission(0)
+	submission.SubmittedAt = time.Now().Add(-time.Minute * 20)
+	s.mockReceiptFetcher.EXPECT().LatestBlock().Return(big.NewInt(100), nil)
+	s.mockSubmissionStorer.EXPECT().PendingSubmissions(s.domainID).Return([]*store.Submission{submission}, nil)
+	s.mockReceiptFetcher.EXPECT().TransactionReceipt(gomock.Any(), common.HexToHash("0x01")).Return(nil, ethereum.NotFound)
+	s.mockReceiptFetcher.EXPECT().TransactionByHash(gomock.Any(), common.HexToHash("0x01")).Return(nil, false, ethereum.NotFound)
+	s.mockSubmissionStorer.EXPECT().StoreSubmission(s.domainID, gomock.Any()).DoAndReturn(func(domainID uint8, submission *store.Submission) error {
+		s.Equal(submission.Status, store.RetriedSubmission)
+		s.Equal(submission.Receipt, &store.Receipt{Outcome: store.DroppedOutcome})
+		s.Equal(submission.Retries, 1)
+		return nil
+	})
+	s.mockRequeuer.EXPECT().Requeue(gomock.Any()).Return(nil)
+
+	err := s.tracker.CheckReceipts()
+
+	s.Nil(err)
+}
+
+func (s *ReceiptTrackerTestSuite) Test_CheckReceipts_MaxRetries_Failed() {
+	s.mockReceiptFetcher.EXPECT().LatestBlock().Return(big.NewInt(100), nil)
+	s.mockSubmissionStorer.EXPECT().PendingSubmissions(s.domainID).Return([]*store.Submission{s.submission(2)}, nil)
+	s.mockReceiptFetcher.EXPECT().TransactionReceipt(gomock.Any(), common.HexToHash("0x01")).Return(&types.Receipt{
+		Status:      types.ReceiptStatusFailed,
+		BlockNumber: big.NewInt(90),
+	}, nil)
+	s.mockSubmissionStorer.EXPECT().StoreSubmission(s.domainID, gomock.Any()).DoAndReturn(func(domainID uint8, submission *store.Submission) error {
+		s.Equal(submission.Status, store.FailedSubmission)
+		s.Equal(submission.Failure, string(store.RevertOutcome))
+		s.Equal(submission.Retries, 2)
+		return nil
+	})
+
+	err := s.tracker.CheckReceipts()
+
+	s.Nil(err)
+}
@@ -0,0 +1,205 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package monitor
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"time"
+
+	ethereumABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/abi"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/listener/events"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/listener/events/handlers"
+	"github.com/sygmaprotocol/spectre-node/store"
+)
+
+type LogFetcher interface {
+	handlers.EventFetcher
+	LatestBlock() (*big.Int, error)
+}
+
+type SpectreEventStorer interface {
+	StoreStateRoot(destinationDomainID uint8, sourceDomainID uint8, slot uint64, stateRoot [32]byte) error
+	StateRoot(destinationDomainID uint8, sourceDomainID uint8, slot uint64) ([32]byte, bool, error)
+	LatestStateRootSlot(destinationDomainID uint8, sourceDomainID uint8) (uint64, error)
+	StoreRotation(destinationDomainID uint8, sourceDomainID uint8, slot uint64) error
+	Rotated(destinationDomainID uint8, sourceDomainID uint8, slot uint64) (bool, error)
+	StoreBlock(destinationDomainID uint8, block *big.Int) error
+	Block(destinationDomainID uint8) (*big.Int, error)
+}
+
+type PendingSubmissionStorer interface {
+	StoreSubmission(destinationDomainID uint8, submission *store.Submission) error
+	PendingSubmissions(destinationDomainID uint8) ([]*store.Submission, error)
+}
+
+// SpectreListener indexes Spectre contract events on the destination domain
+// and reconciles them with submitted proofs
+type SpectreListener struct {
+	domainID       uint8
+	spectreAddress common.Address
+	spectreABI     ethereumABI.ABI
+
+	eventFetcher     LogFetcher
+	spectreStorer    SpectreEventStorer
+	submissionStorer PendingSubmissionStorer
+
+	confirmationTimeout time.Duration
+	interval            time.Duration
+	blockRange          int64
+
+	log zerolog.Logger
+}
+
+func NewSpectreListener(
+	domainID uint8,
+	spectreAddress common.Address,
+	eventFetcher LogFetcher,
+	spectreStorer SpectreEventStorer,
+	submissionStorer PendingSubmissionStorer,
+	confirmationTimeout time.Duration,
+	interval time.Duration,
+	blockRange int64,
+) *SpectreListener {
+	a, _ := ethereumABI.JSON(strings.NewReader(abi.SpectreABI))
+	return &SpectreListener{
+		domainID:            domainID,
+		spectreAddress:      spectreAddress,
+		spectreABI:          a,
+		eventFetcher:        eventFetcher,
+		spectreStorer:       spectreStorer,
+		submissionStorer:    submissionStorer,
+		confirmationTimeout: confirmationTimeout,
+		interval:            interval,
+		blockRange:          blockRange,
+		log:                 log.With().Uint8("domainID", domainID).Logger(),
+	}
+}
+
+// Listen periodically indexes new Spectre events on the destination domain
+// and reconciles them with submitted proofs
+func (l *SpectreListener) Listen(ctx context.Context) {
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := l.IndexEvents()
+			if err != nil {
+				l.log.Warn().Err(err).Msgf("Unable to index spectre events")
+				continue
+			}
+
+			err = l.Reconcile()
+			if err != nil {
+				l.log.Warn().Err(err).Msgf("Unable to reconcile submissions")
+			}
+		}
+	}
+}
+
+// IndexEvents stores StateRootSubmitted and CommitteeRotated events emitted
+// since the last indexed block
+func (l *SpectreListener) IndexEvents() error {
+	latestBlock, err := l.eventFetcher.LatestBlock()
+	if err != nil {
+		return err
+	}
+	startBlock, err := l.spectreStorer.Block(l.domainID)
+	if err != nil {
+		return err
+	}
+	if startBlock.Cmp(big.NewInt(0)) == 0 {
+		return l.spectreStorer.StoreBlock(l.domainID, latestBlock)
+	}
+	startBlock = new(big.Int).Add(startBlock, big.NewInt(1))
+	if startBlock.Cmp(latestBlock) > 0 {
+		return nil
+	}
+
+	stateRootLogs, err := handlers.FetchLogs(l.eventFetcher, startBlock, latestBlock, l.spectreAddress, string(events.StateRootSubmittedSig), l.blockRange)
+	if err != nil {
+		return err
+	}
+	for _, stateRootLog := range stateRootLogs {
+		var e events.StateRootSubmitted
+		err := l.spectreABI.UnpackIntoInterface(&e, "StateRootSubmitted", stateRootLog.Data)
+		if err != nil {
+			return err
+		}
+
+		l.log.Debug().Uint64("slot", e.Slot.Uint64()).Msgf("State root from domain %d submitted in tx %s", e.SourceDomainID, stateRootLog.TxHash)
+		err = l.spectreStorer.StoreStateRoot(l.domainID, e.SourceDomainID, e.Slot.Uint64(), e.StateRoot)
+		if err != nil {
+			return err
+		}
+	}
+
+	rotationLogs, err := handlers.FetchLogs(l.eventFetcher, startBlock, latestBlock, l.spectreAddress, string(events.CommitteeRotatedSig), l.blockRange)
+	if err != nil {
+		return err
+	}
+	for _, rotationLog := range rotationLogs {
+		var e events.CommitteeRotated
+		err := l.spectreABI.UnpackIntoInterface(&e, "CommitteeRotated", rotationLog.Data)
+		if err != nil {
+			return err
+		}
+
+		l.log.Debug().Uint64("slot", e.Slot.Uint64()).Msgf("Committee from domain %d rotated in tx %s", e.SourceDomainID, rotationLog.TxHash)
+		err = l.spectreStorer.StoreRotation(l.domainID, e.SourceDomainID, e.Slot.Uint64())
+		if err != nil {
+			return err
+		}
+	}
+
+	return l.spectreStorer.StoreBlock(l.domainID, latestBlock)
+}
+
+// Reconcile marks pending submissions as confirmed if the Spectre contract emitted the
+// matching event or as missing if it didn't emit it before the confirmation timeout
+func (l *SpectreListener) Reconcile() error {
+	submissions, err := l.submissionStorer.PendingSubmissions(l.domainID)
+	if err != nil {
+		return err
+	}
+
+	for _, submission := range submissions {
+		var confirmed bool
+		switch submission.Type {
+		case store.StepProofType:
+			_, confirmed, err = l.spectreStorer.StateRoot(l.domainID, submission.SourceDomain, submission.Slot)
+		case store.RotateProofType:
+			confirmed, err = l.spectreStorer.Rotated(l.domainID, submission.SourceDomain, submission.Slot)
+		}
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case confirmed:
+			l.log.Info().Uint64("slot", submission.Slot).Msgf("Confirmed %s from domain %d with hash %s", submission.Type, submission.SourceDomain, submission.TxHash)
+			submission.Status = store.ConfirmedSubmission
+		case time.Since(submission.SubmittedAt) > l.confirmationTimeout:
+			l.log.Error().Uint64("slot", submission.Slot).Msgf("Missing %s from domain %d with hash %s", submission.Type, submission.SourceDomain, submission.TxHash)
+			submission.Status = store.MissingSubmission
+		default:
+			continue
+		}
+
+		err = l.submissionStorer.StoreSubmission(l.domainID, submission)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
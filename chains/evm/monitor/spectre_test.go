@@ -0,0 +1,140 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package monitor_test
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	ethereumABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/abi"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/listener/events"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/monitor"
+	"github.com/sygmaprotocol/spectre-node/mock"
+	"github.com/sygmaprotocol/spectre-node/store"
+	"go.uber.org/mock/gomock"
+)
+
+type SpectreListenerTestSuite struct {
+	suite.Suite
+
+	listener *monitor.SpectreListener
+
+	mockLogFetcher         *mock.MockLogFetcher
+	mockSpectreStorer      *mock.MockSpectreEventStorer
+	mockSubmissionStorer   *mock.MockPendingSubmissionStorer
+	spectreAddress         common.Address
+	spectreABI             ethereumABI.ABI
+	destinationDomainID    uint8
+	sourceDomainID         uint8
+	stateRootSubmittedData []byte
+}
+
+func TestRunSpectreListenerTestSuite(t *testing.T) {
+	suite.Run(t, new(SpectreListenerTestSuite))
+}
+
+func (s *SpectreListenerTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockLogFetcher = mock.NewMockLogFetcher(ctrl)
+	s.mockSpectreStorer = mock.NewMockSpectreEventStorer(ctrl)
+	s.mockSubmissionStorer = mock.NewMockPendingSubmissionStorer(ctrl)
+	s.spectreAddress = common.HexToAddress("0xa83114A443dA1CecEFC50368531cACE9F37fCCcb")
+	s.spectreABI, _ = ethereumABI.JSON(strings.NewReader(abi.SpectreABI))
+	s.destinationDomainID = 2
+	s.sourceDomainID = 1
+	s.stateRootSubmittedData, _ = s.spectreABI.Events["StateRootSubmitted"].Inputs.Pack(uint8(1), big.NewInt(1000), [32]byte{1})
+	s.listener = monitor.NewSpectreListener(
+		s.destinationDomainID,
+		s.spectreAddress,
+		s.mockLogFetcher,
+		s.mockSpectreStorer,
+		s.mockSubmissionStorer,
+		time.Minute,
+		time.Second,
+		1000,
+	)
+}
+
+func (s *SpectreListenerTestSuite) Test_IndexEvents_FirstRun_StoresLatestBlock() {
+	s.mockLogFetcher.EXPECT().LatestBlock().Return(big.NewInt(100), nil)
+	s.mockSpectreStorer.EXPECT().Block(s.destinationDomainID).Return(big.NewInt(0), nil)
+	s.mockSpectreStorer.EXPECT().StoreBlock(s.destinationDomainID, big.NewInt(100)).Return(nil)
+
+	err := s.listener.IndexEvents()
+
+	s.Nil(err)
+}
+
+func (s *SpectreListenerTestSuite) Test_IndexEvents_FetchingLogsFails() {
+	s.mockLogFetcher.EXPECT().LatestBlock().Return(big.NewInt(100), nil)
+	s.mockSpectreStorer.EXPECT().Block(s.destinationDomainID).Return(big.NewInt(90), nil)
+	s.mockLogFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.spectreAddress, string(events.StateRootSubmittedSig), big.NewInt(91), big.NewInt(100)).Return(nil, fmt.Errorf("error"))
+
+	err := s.listener.IndexEvents()
+
+	s.NotNil(err)
+}
+
+func (s *SpectreListenerTestSuite) Test_IndexEvents_ValidEvents() {
+	rotatedData, _ := s.spectreABI.Events["CommitteeRotated"].Inputs.Pack(uint8(1), big.NewInt(2000))
+	s.mockLogFetcher.EXPECT().LatestBlock().Return(big.NewInt(100), nil)
+	s.mockSpectreStorer.EXPECT().Block(s.destinationDomainID).Return(big.NewInt(90), nil)
+	s.mockLogFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.spectreAddress, string(events.StateRootSubmittedSig), big.NewInt(91), big.NewInt(100)).Return([]types.Log{
+		{Data: s.stateRootSubmittedData},
+	}, nil)
+	s.mockLogFetcher.EXPECT().FetchEventLogs(gomock.Any(), s.spectreAddress, string(events.CommitteeRotatedSig), big.NewInt(91), big.NewInt(100)).Return([]types.Log{
+		{Data: rotatedData},
+	}, nil)
+	s.mockSpectreStorer.EXPECT().StoreStateRoot(s.destinationDomainID, s.sourceDomainID, uint64(1000), [32]byte{1}).Return(nil)
+	s.mockSpectreStorer.EXPECT().StoreRotation(s.destinationDomainID, s.sourceDomainID, uint64(2000)).Return(nil)
+	s.mockSpectreStorer.EXPECT().StoreBlock(s.destinationDomainID, big.NewInt(100)).Return(nil)
+
+	err := s.listener.IndexEvents()
+
+	s.Nil(err)
+}
+
+func (s *SpectreListenerTestSuite) Test_Reconcile_ConfirmedAndMissing() {
+	confirmed := &store.Submission{
+		Type:         store.StepProofType,
+		SourceDomain: s.sourceDomainID,
+		Slot:         1000,
+		SubmittedAt:  time.Now(),
+		Status:       store.PendingSubmission,
+	}
+	missing := &store.Submission{
+		Type:         store.RotateProofType,
+		SourceDomain: s.sourceDomainID,
+		Slot:         2000,
+		SubmittedAt:  time.Now().Add(-time.Hour),
+		Status:       store.PendingSubmission,
+	}
+	pending := &store.Submission{
+		Type:         store.StepProofType,
+		SourceDomain: s.sourceDomainID,
+		Slot:         3000,
+		SubmittedAt:  time.Now(),
+		Status:       store.PendingSubmission,
+	}
+	s.mockSubmissionStorer.EXPECT().PendingSubmissions(s.destinationDomainID).Return([]*store.Submission{confirmed, missing, pending}, nil)
+	s.mockSpectreStorer.EXPECT().StateRoot(s.destinationDomainID, s.sourceDomainID, uint64(1000)).Return([32]byte{1}, true, nil)
+	s.mockSpectreStorer.EXPECT().Rotated(s.destinationDomainID, s.sourceDomainID, uint64(2000)).Return(false, nil)
+	s.mockSpectreStorer.EXPECT().StateRoot(s.destinationDomainID, s.sourceDomainID, uint64(3000)).Return([32]byte{}, false, nil)
+	s.mockSubmissionStorer.EXPECT().StoreSubmission(s.destinationDomainID, confirmed).Return(nil)
+	s.mockSubmissionStorer.EXPECT().StoreSubmission(s.destinationDomainID, missing).Return(nil)
+
+	err := s.listener.Reconcile()
+
+	s.Nil(err)
+	s.Equal(confirmed.Status, store.ConfirmedSubmission)
+	s.Equal(missing.Status, store.MissingSubmission)
+	s.Equal(pending.Status, store.PendingSubmission)
+}
@@ -0,0 +1,130 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/prover"
+)
+
+const DEFAULT_ADMIN_ROLE = "DEFAULT_ADMIN_ROLE"
+
+type ChainClient interface {
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+	ChainID(ctx context.Context) (*big.Int, error)
+}
+
+type SpectreReader interface {
+	SpectreContract(domainID uint8) (common.Address, error)
+	HasRole(role [32]byte, account common.Address) (bool, error)
+}
+
+type BeaconSpecProvider interface {
+	Genesis(ctx context.Context, opts *api.GenesisOpts) (*api.Response[*apiv1.Genesis], error)
+	Fork(ctx context.Context, opts *api.ForkOpts) (*api.Response[*phase0.Fork], error)
+}
+
+// CheckChainID verifies the endpoint serves the chain with the expected chain ID
+func CheckChainID(ctx context.Context, client ChainClient, expected uint64) error {
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed fetching chain ID: %w", err)
+	}
+	if chainID.Cmp(new(big.Int).SetUint64(expected)) != 0 {
+		return fmt.Errorf("endpoint chain ID %s doesn't match the expected chain ID %d", chainID, expected)
+	}
+	return nil
+}
+
+// CheckContract verifies a contract is deployed at the address
+func CheckContract(ctx context.Context, client ChainClient, name string, address common.Address) error {
+	code, err := client.CodeAt(ctx, address, nil)
+	if err != nil {
+		return fmt.Errorf("failed fetching %s code: %w", name, err)
+	}
+	if len(code) == 0 {
+		return fmt.Errorf("%s address %s has no code", name, address)
+	}
+	return nil
+}
+
+// CheckSpectreContracts verifies the proxy has a Spectre contract set for every source domain
+func CheckSpectreContracts(spectre SpectreReader, sources []uint8) error {
+	for _, source := range sources {
+		address, err := spectre.SpectreContract(source)
+		if err != nil {
+			return fmt.Errorf("failed fetching spectre contract of domain %d: %w", source, err)
+		}
+		if address == (common.Address{}) {
+			return fmt.Errorf("spectre contract of source domain %d is not set", source)
+		}
+	}
+	return nil
+}
+
+// CheckRoles verifies the account was granted every role
+func CheckRoles(spectre SpectreReader, account common.Address, roles []string) error {
+	for _, name := range roles {
+		hasRole, err := spectre.HasRole(RoleID(name), account)
+		if err != nil {
+			return fmt.Errorf("failed fetching role %s: %w", name, err)
+		}
+		if !hasRole {
+			return fmt.Errorf("relayer %s doesn't have role %s", account, name)
+		}
+	}
+	return nil
+}
+
+// CheckBeaconSpec verifies the beacon node serves the network of the spec
+// and is at the deneb fork the prover supports
+func CheckBeaconSpec(ctx context.Context, beacon BeaconSpecProvider, chainSpec *prover.ChainSpec) error {
+	network := chainSpec.Network
+	spec := chainSpec.Name
+
+	genesis, err := beacon.Genesis(ctx, &api.GenesisOpts{})
+	if err != nil {
+		return fmt.Errorf("failed fetching beacon genesis: %w", err)
+	}
+	if genesis.Data.GenesisForkVersion != network.GenesisForkVersion {
+		return fmt.Errorf("beacon genesis fork version %#x doesn't match the %s spec", genesis.Data.GenesisForkVersion, spec)
+	}
+	if network.GenesisValidatorsRoot != (phase0.Root{}) && genesis.Data.GenesisValidatorsRoot != network.GenesisValidatorsRoot {
+		return fmt.Errorf("beacon genesis validators root %#x doesn't match the %s spec", genesis.Data.GenesisValidatorsRoot, spec)
+	}
+
+	fork, err := beacon.Fork(ctx, &api.ForkOpts{State: "head"})
+	if err != nil {
+		return fmt.Errorf("failed fetching beacon fork: %w", err)
+	}
+	if fork.Data.CurrentVersion != network.DenebForkVersion {
+		return fmt.Errorf("beacon fork version %#x isn't the %s spec deneb fork version %#x", fork.Data.CurrentVersion, spec, network.DenebForkVersion)
+	}
+	if network.DenebForkEpoch != 0 && fork.Data.Epoch != network.DenebForkEpoch {
+		return fmt.Errorf("beacon deneb fork epoch %d doesn't match the %s spec deneb fork epoch %d", fork.Data.Epoch, spec, network.DenebForkEpoch)
+	}
+	return nil
+}
+
+// RoleID returns the identifier of a role given by its hash or by
+// its name hashed with keccak256 as done by OpenZeppelin AccessControl
+func RoleID(name string) [32]byte {
+	switch {
+	case name == DEFAULT_ADMIN_ROLE:
+		return [32]byte{}
+	case strings.HasPrefix(name, "0x") && len(name) == 66:
+		return common.HexToHash(name)
+	default:
+		return crypto.Keccak256Hash([]byte(name))
+	}
+}
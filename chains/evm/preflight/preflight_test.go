@@ -0,0 +1,192 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package preflight_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/preflight"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/prover"
+	"github.com/sygmaprotocol/spectre-node/mock"
+	"go.uber.org/mock/gomock"
+)
+
+type PreflightTestSuite struct {
+	suite.Suite
+
+	mockChainClient   *mock.MockChainClient
+	mockSpectreReader *mock.MockSpectreReader
+	mockBeacon        *mock.MockBeaconSpecProvider
+
+	address common.Address
+}
+
+func TestRunPreflightTestSuite(t *testing.T) {
+	suite.Run(t, new(PreflightTestSuite))
+}
+
+func (s *PreflightTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockChainClient = mock.NewMockChainClient(ctrl)
+	s.mockSpectreReader = mock.NewMockSpectreReader(ctrl)
+	s.mockBeacon = mock.NewMockBeaconSpecProvider(ctrl)
+	s.address = common.HexToAddress("0x5798a6d2a3a4d6e5a2b1e7d4e2d6b6c2d8c6a5b3")
+}
+
+func (s *PreflightTestSuite) Test_CheckChainID_Mismatch() {
+	s.mockChainClient.EXPECT().ChainID(gomock.Any()).Return(big.NewInt(5), nil)
+
+	err := preflight.CheckChainID(context.Background(), s.mockChainClient, 1)
+
+	s.NotNil(err)
+}
+
+func (s *PreflightTestSuite) Test_CheckChainID_Match() {
+	s.mockChainClient.EXPECT().ChainID(gomock.Any()).Return(big.NewInt(1), nil)
+
+	err := preflight.CheckChainID(context.Background(), s.mockChainClient, 1)
+
+	s.Nil(err)
+}
+
+func (s *PreflightTestSuite) Test_CheckContract_NoCode() {
+	s.mockChainClient.EXPECT().CodeAt(gomock.Any(), s.address, nil).Return([]byte{}, nil)
+
+	err := preflight.CheckContract(context.Background(), s.mockChainClient, "spectre", s.address)
+
+	s.NotNil(err)
+}
+
+func (s *PreflightTestSuite) Test_CheckContract_Deployed() {
+	s.mockChainClient.EXPECT().CodeAt(gomock.Any(), s.address, nil).Return([]byte{0x60, 0x80}, nil)
+
+	err := preflight.CheckContract(context.Background(), s.mockChainClient, "spectre", s.address)
+
+	s.Nil(err)
+}
+
+func (s *PreflightTestSuite) Test_CheckSpectreContracts_SourceNotSet() {
+	s.mockSpectreReader.EXPECT().SpectreContract(uint8(1)).Return(s.address, nil)
+	s.mockSpectreReader.EXPECT().SpectreContract(uint8(3)).Return(common.Address{}, nil)
+
+	err := preflight.CheckSpectreContracts(s.mockSpectreReader, []uint8{1, 3})
+
+	s.NotNil(err)
+}
+
+func (s *PreflightTestSuite) Test_CheckSpectreContracts_FetchingFails() {
+	s.mockSpectreReader.EXPECT().SpectreContract(uint8(1)).Return(common.Address{}, fmt.Errorf("error"))
+
+	err := preflight.CheckSpectreContracts(s.mockSpectreReader, []uint8{1})
+
+	s.NotNil(err)
+}
+
+func (s *PreflightTestSuite) Test_CheckRoles_MissingRole() {
+	s.mockSpectreReader.EXPECT().HasRole([32]byte{}, s.address).Return(true, nil)
+	s.mockSpectreReader.EXPECT().HasRole([32]byte(crypto.Keccak256Hash([]byte("RELAYER_ROLE"))), s.address).Return(false, nil)
+
+	err := preflight.CheckRoles(s.mockSpectreReader, s.address, []string{preflight.DEFAULT_ADMIN_ROLE, "RELAYER_ROLE"})
+
+	s.NotNil(err)
+}
+
+func (s *PreflightTestSuite) Test_CheckRoles_ValidRoles() {
+	role := common.HexToHash("0x01")
+	s.mockSpectreReader.EXPECT().HasRole([32]byte(role), s.address).Return(true, nil)
+
+	err := preflight.CheckRoles(s.mockSpectreReader, s.address, []string{role.Hex()})
+
+	s.Nil(err)
+}
+
+func (s *PreflightTestSuite) builtinSpec(name prover.Spec) *prover.ChainSpec {
+	spec, err := prover.BuiltinSpec(name)
+	s.Nil(err)
+	return spec
+}
+
+func (s *PreflightTestSuite) Test_CheckBeaconSpec_GenesisMismatch() {
+	s.mockBeacon.EXPECT().Genesis(gomock.Any(), gomock.Any()).Return(&api.Response[*apiv1.Genesis]{
+		Data: &apiv1.Genesis{
+			GenesisForkVersion: phase0.Version{0x90, 0x00, 0x00, 0x69},
+		},
+	}, nil)
+
+	err := preflight.CheckBeaconSpec(context.Background(), s.mockBeacon, s.builtinSpec(prover.MAINNET_SPEC))
+
+	s.NotNil(err)
+}
+
+func (s *PreflightTestSuite) Test_CheckBeaconSpec_UnsupportedFork() {
+	s.mockBeacon.EXPECT().Genesis(gomock.Any(), gomock.Any()).Return(&api.Response[*apiv1.Genesis]{
+		Data: &apiv1.Genesis{
+			GenesisForkVersion: prover.NETWORKS[prover.MINIMAL_SPEC].GenesisForkVersion,
+		},
+	}, nil)
+	s.mockBeacon.EXPECT().Fork(gomock.Any(), &api.ForkOpts{State: "head"}).Return(&api.Response[*phase0.Fork]{
+		Data: &phase0.Fork{
+			CurrentVersion: phase0.Version{0x03, 0x00, 0x00, 0x01},
+		},
+	}, nil)
+
+	err := preflight.CheckBeaconSpec(context.Background(), s.mockBeacon, s.builtinSpec(prover.MINIMAL_SPEC))
+
+	s.NotNil(err)
+}
+
+func (s *PreflightTestSuite) Test_CheckBeaconSpec_Valid() {
+	network := prover.NETWORKS[prover.MAINNET_SPEC]
+	s.mockBeacon.EXPECT().Genesis(gomock.Any(), gomock.Any()).Return(&api.Response[*apiv1.Genesis]{
+		Data: &apiv1.Genesis{
+			GenesisForkVersion:    network.GenesisForkVersion,
+			GenesisValidatorsRoot: network.GenesisValidatorsRoot,
+		},
+	}, nil)
+	s.mockBeacon.EXPECT().Fork(gomock.Any(), &api.ForkOpts{State: "head"}).Return(&api.Response[*phase0.Fork]{
+		Data: &phase0.Fork{
+			CurrentVersion: network.DenebForkVersion,
+			Epoch:          network.DenebForkEpoch,
+		},
+	}, nil)
+
+	err := preflight.CheckBeaconSpec(context.Background(), s.mockBeacon, s.builtinSpec(prover.MAINNET_SPEC))
+
+	s.Nil(err)
+}
+
+func (s *PreflightTestSuite) Test_CheckBeaconSpec_CustomSpecForkEpochMismatch() {
+	spec := &prover.ChainSpec{
+		Name: "gnosis",
+		Network: prover.Network{
+			GenesisForkVersion: phase0.Version{0x00, 0x00, 0x00, 0x64},
+			DenebForkVersion:   phase0.Version{0x04, 0x00, 0x00, 0x64},
+			DenebForkEpoch:     889856,
+		},
+	}
+	s.mockBeacon.EXPECT().Genesis(gomock.Any(), gomock.Any()).Return(&api.Response[*apiv1.Genesis]{
+		Data: &apiv1.Genesis{
+			GenesisForkVersion: spec.Network.GenesisForkVersion,
+		},
+	}, nil)
+	s.mockBeacon.EXPECT().Fork(gomock.Any(), &api.ForkOpts{State: "head"}).Return(&api.Response[*phase0.Fork]{
+		Data: &phase0.Fork{
+			CurrentVersion: spec.Network.DenebForkVersion,
+			Epoch:          889000,
+		},
+	}, nil)
+
+	err := preflight.CheckBeaconSpec(context.Background(), s.mockBeacon, spec)
+
+	s.NotNil(err)
+}
@@ -0,0 +1,101 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package prover
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/message"
+)
+
+type ProofCacher interface {
+	Proof(key string, proof interface{}) (bool, error)
+	StoreProof(key string, proof interface{}) error
+	Lock(key string) func()
+}
+
+// CachedProver reuses proofs for the same light client update so the rotate
+// step and domains sharing the same beacon chain don't prove the same data twice
+type CachedProver struct {
+	*Prover
+
+	cache ProofCacher
+}
+
+func NewCachedProver(prover *Prover, cache ProofCacher) *CachedProver {
+	return &CachedProver{
+		Prover: prover,
+		cache:  cache,
+	}
+}
+
+// StepProof returns the cached step proof for the update or generates it
+func (p *CachedProver) StepProof(args *StepArgs) (*EvmProof[message.SyncStepInput], error) {
+	updateSSZ, err := args.UpdateSSZ()
+	if err != nil {
+		return nil, err
+	}
+	key := proofCacheKey("step", args.Spec.Name, updateSSZ)
+
+	unlock := p.cache.Lock(key)
+	defer unlock()
+
+	proof := &EvmProof[message.SyncStepInput]{}
+	cached, err := p.cache.Proof(key, proof)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Unable to read cached step proof")
+	}
+	if cached && err == nil {
+		log.Debug().Msgf("Using cached step proof %s", key)
+		return proof, nil
+	}
+
+	proof, err = p.Prover.StepProof(args)
+	if err != nil {
+		return nil, err
+	}
+	err = p.cache.StoreProof(key, proof)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Unable to cache step proof")
+	}
+	return proof, nil
+}
+
+// RotateProof returns the cached rotate proof for the update or generates it
+func (p *CachedProver) RotateProof(args *RotateArgs) (*EvmProof[struct{}], error) {
+	updateSSZ, err := args.UpdateSSZ()
+	if err != nil {
+		return nil, err
+	}
+	key := proofCacheKey("rotate", args.Spec.Name, updateSSZ)
+
+	unlock := p.cache.Lock(key)
+	defer unlock()
+
+	proof := &EvmProof[struct{}]{}
+	cached, err := p.cache.Proof(key, proof)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Unable to read cached rotate proof")
+	}
+	if cached && err == nil {
+		log.Debug().Msgf("Using cached rotate proof %s", key)
+		return proof, nil
+	}
+
+	proof, err = p.Prover.RotateProof(args)
+	if err != nil {
+		return nil, err
+	}
+	err = p.cache.StoreProof(key, proof)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Unable to cache rotate proof")
+	}
+	return proof, nil
+}
+
+func proofCacheKey(proofType string, spec Spec, updateSSZ []byte) string {
+	return fmt.Sprintf("%s:%s:%s", proofType, spec, crypto.Keccak256Hash(updateSSZ).Hex())
+}
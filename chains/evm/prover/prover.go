@@ -6,6 +6,7 @@ package prover
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -15,19 +16,29 @@ import (
 )
 
 type StepArgs struct {
-	Spec    Spec
-	Pubkeys [512][48]byte
+	Spec    *ChainSpec
+	Pubkeys [][48]byte
 	Domain  phase0.Domain
 	Update  *consensus.LightClientFinalityUpdateDeneb
 }
 
 type RotateArgs struct {
-	Spec    Spec
+	Spec    *ChainSpec
 	Update  *consensus.LightClientUpdateDeneb
-	Pubkeys [512][48]byte
+	Pubkeys [][48]byte
 	Domain  phase0.Domain
 }
 
+// UpdateSSZ returns the finality update encoded for the sync committee size of the spec
+func (a *StepArgs) UpdateSSZ() ([]byte, error) {
+	return FinalityUpdateSSZ(a.Update, a.Spec.Preset.SyncCommitteeSize)
+}
+
+// UpdateSSZ returns the light client update encoded for the sync committee size of the spec
+func (a *RotateArgs) UpdateSSZ() ([]byte, error) {
+	return UpdateSSZ(a.Update, a.Spec.Preset.SyncCommitteeSize)
+}
+
 type ProverResponse struct {
 	Proof      []uint16 `json:"proof"`
 	Commitment string   `json:"committee_poseidon"`
@@ -58,18 +69,23 @@ type Prover struct {
 	beaconClient BeaconClient
 	proverClient ProverClient
 
-	spec              Spec
+	spec              *ChainSpec
 	slotsPerEpoch     uint64
+	slotsPerPeriod    uint64
 	finalityThreshold uint64
+
+	bestUpdates map[uint64]*consensus.LightClientUpdateDeneb
+	lock        sync.Mutex
 }
 
 func NewProver(
 	proverClient ProverClient,
 	beaconClient BeaconClient,
 	lightClient LightClient,
-	spec Spec,
+	spec *ChainSpec,
 	finalityTreshold uint64,
 	slotsPerEpoch uint64,
+	epochsPerPeriod uint64,
 ) *Prover {
 	return &Prover{
 		proverClient:      proverClient,
@@ -78,16 +94,18 @@ func NewProver(
 		lightClient:       lightClient,
 		finalityThreshold: finalityTreshold,
 		slotsPerEpoch:     slotsPerEpoch,
+		slotsPerPeriod:    slotsPerEpoch * epochsPerPeriod,
+		bestUpdates:       make(map[uint64]*consensus.LightClientUpdateDeneb),
 	}
 }
 
 // StepProof generates the proof for the sync step
 func (p *Prover) StepProof(args *StepArgs) (*EvmProof[message.SyncStepInput], error) {
-	participation := uint64(CountSetBits(args.Update.SyncAggregate.SyncCommiteeBits))
+	participation := Participation(args.Update.SyncAggregate, args.Spec.Preset.SyncCommitteeSize)
 	if participation < p.finalityThreshold {
 		return nil, fmt.Errorf("participation %d lower than finality treshold %d", participation, p.finalityThreshold)
 	}
-	updateSzz, err := args.Update.MarshalSSZ()
+	updateSzz, err := args.UpdateSSZ()
 	if err != nil {
 		return nil, err
 	}
@@ -99,8 +117,8 @@ func (p *Prover) StepProof(args *StepArgs) (*EvmProof[message.SyncStepInput], er
 	}
 	var resp ProverResponse
 	err = p.proverClient.CallFor(context.Background(), &resp, "genEvmProof_SyncStepCompressed", stepArgs{
-		Spec:    args.Spec,
-		Pubkeys: ByteArrayToU16Array(p.pubkeysSSZ(args.Pubkeys)),
+		Spec:    args.Spec.Name,
+		Pubkeys: ByteArrayToU16Array(PubkeysSSZ(args.Pubkeys)),
 		Update:  ByteArrayToU16Array(updateSzz),
 		Domain:  ByteArrayToU16Array(args.Domain[:]),
 	})
@@ -134,7 +152,7 @@ func (p *Prover) StepProof(args *StepArgs) (*EvmProof[message.SyncStepInput], er
 // RotateProof generates the proof for the sync committee rotation for the period
 func (p *Prover) RotateProof(args *RotateArgs) (*EvmProof[struct{}], error) {
 	args.Update.AttestedHeader = args.Update.FinalizedHeader
-	updateSzz, err := args.Update.MarshalSSZ()
+	updateSzz, err := args.UpdateSSZ()
 	if err != nil {
 		return nil, err
 	}
@@ -145,7 +163,7 @@ func (p *Prover) RotateProof(args *RotateArgs) (*EvmProof[struct{}], error) {
 	}
 	var resp ProverResponse
 
-	err = p.proverClient.CallFor(context.Background(), &resp, "genEvmProof_CommitteeUpdateCompressed", rotateArgs{Update: ByteArrayToU16Array(updateSzz), Spec: args.Spec})
+	err = p.proverClient.CallFor(context.Background(), &resp, "genEvmProof_CommitteeUpdateCompressed", rotateArgs{Update: ByteArrayToU16Array(updateSzz), Spec: args.Spec.Name})
 	if err != nil {
 		return nil, err
 	}
@@ -175,9 +193,9 @@ func (p *Prover) StepArgs() (*StepArgs, error) {
 	if err != nil {
 		return nil, err
 	}
-	pubkeys := bootstrap.CurrentSyncCommittee.PubKeys
+	pubkeys := bootstrap.CurrentSyncCommittee.PubKeys[:p.spec.Preset.SyncCommitteeSize]
 
-	domain, err := p.beaconClient.Domain(context.Background(), SYNC_COMMITTEE_DOMAIN, phase0.Epoch(update.FinalizedHeader.Header.Slot/p.slotsPerEpoch))
+	domain, err := p.beaconClient.Domain(context.Background(), p.spec.SyncCommitteeDomain, phase0.Epoch(update.FinalizedHeader.Header.Slot/p.slotsPerEpoch))
 	if err != nil {
 		return nil, err
 	}
@@ -191,14 +209,10 @@ func (p *Prover) StepArgs() (*StepArgs, error) {
 }
 
 func (p *Prover) RotateArgs(period uint64) (*RotateArgs, error) {
-	updates, err := p.lightClient.Updates(period)
+	update, err := p.bestUpdate(period)
 	if err != nil {
 		return nil, err
 	}
-	if len(updates) == 0 {
-		return nil, fmt.Errorf("missing light client updates")
-	}
-	update := updates[0]
 
 	finalizedNextSyncCommitteeBranch := make([][32]byte, len(update.NextSyncCommitteeBranch))
 	blockRoot, err := p.beaconClient.BeaconBlockRoot(context.Background(), &api.BeaconBlockRootOpts{
@@ -216,7 +230,7 @@ func (p *Prover) RotateArgs(period uint64) (*RotateArgs, error) {
 	finalizedNextSyncCommitteeBranch[0] = update.NextSyncCommitteeBranch[0]
 	update.NextSyncCommitteeBranch = finalizedNextSyncCommitteeBranch
 
-	domain, err := p.beaconClient.Domain(context.Background(), SYNC_COMMITTEE_DOMAIN, phase0.Epoch(update.FinalizedHeader.Header.Slot/p.slotsPerEpoch))
+	domain, err := p.beaconClient.Domain(context.Background(), p.spec.SyncCommitteeDomain, phase0.Epoch(update.FinalizedHeader.Header.Slot/p.slotsPerEpoch))
 	if err != nil {
 		return nil, err
 	}
@@ -224,15 +238,48 @@ func (p *Prover) RotateArgs(period uint64) (*RotateArgs, error) {
 	return &RotateArgs{
 		Update:  update,
 		Spec:    p.spec,
-		Pubkeys: bootstrap.CurrentSyncCommittee.PubKeys,
+		Pubkeys: bootstrap.CurrentSyncCommittee.PubKeys[:p.spec.Preset.SyncCommitteeSize],
 		Domain:  domain,
 	}, nil
 }
 
-func (p *Prover) pubkeysSSZ(pubkeys [512][48]byte) []byte {
-	var pubkeysSSZ []byte
-	for _, pubkeys := range pubkeys {
-		pubkeysSSZ = append(pubkeysSSZ, pubkeys[:]...)
+// bestUpdate returns a copy of the best light client update of the period seen so far
+// and refuses updates that can't prove the next sync committee with enough participation
+func (p *Prover) bestUpdate(period uint64) (*consensus.LightClientUpdateDeneb, error) {
+	updates, err := p.lightClient.Updates(period)
+	if err != nil {
+		return nil, err
 	}
-	return pubkeysSSZ
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	best := p.bestUpdates[period]
+	for _, update := range updates {
+		if update.AttestedHeader.Header.Slot/p.slotsPerPeriod != period {
+			continue
+		}
+		if best == nil || IsBetterUpdate(update, best, p.slotsPerPeriod, p.spec.Preset.SyncCommitteeSize) {
+			best = update
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("missing light client updates for period %d", period)
+	}
+	p.bestUpdates[period] = best
+	for cachedPeriod := range p.bestUpdates {
+		if cachedPeriod < period {
+			delete(p.bestUpdates, cachedPeriod)
+		}
+	}
+
+	if !isSyncCommitteeUpdate(best) || !isFinalityUpdate(best) || !hasSyncCommitteeFinality(best, p.slotsPerPeriod) {
+		return nil, fmt.Errorf("light client update for period %d doesn't finalize the next sync committee", period)
+	}
+	participation := Participation(best.SyncAggregate, p.spec.Preset.SyncCommitteeSize)
+	if participation < p.finalityThreshold {
+		return nil, fmt.Errorf("light client update for period %d participation %d lower than finality treshold %d", period, participation, p.finalityThreshold)
+	}
+
+	update := *best
+	return &update, nil
 }
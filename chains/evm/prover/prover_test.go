@@ -0,0 +1,141 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package prover_test
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/prover"
+	"github.com/sygmaprotocol/spectre-node/mock"
+	consensus "github.com/umbracle/go-eth-consensus"
+	"go.uber.org/mock/gomock"
+)
+
+type RotateArgsTestSuite struct {
+	suite.Suite
+
+	prover           *prover.Prover
+	mockLightClient  *mock.MockLightClient
+	mockBeaconClient *mock.MockBeaconClient
+}
+
+func TestRunRotateArgsTestSuite(t *testing.T) {
+	suite.Run(t, new(RotateArgsTestSuite))
+}
+
+func (s *RotateArgsTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockLightClient = mock.NewMockLightClient(ctrl)
+	s.mockBeaconClient = mock.NewMockBeaconClient(ctrl)
+	spec, err := prover.BuiltinSpec(prover.MAINNET_SPEC)
+	s.Nil(err)
+	s.prover = prover.NewProver(
+		mock.NewMockProverClient(ctrl),
+		s.mockBeaconClient,
+		s.mockLightClient,
+		spec,
+		342,
+		32,
+		256,
+	)
+}
+
+func (s *RotateArgsTestSuite) expectBootstrap() {
+	root := phase0.Root{1}
+	s.mockBeaconClient.EXPECT().BeaconBlockRoot(gomock.Any(), gomock.Any()).Return(&api.Response[*phase0.Root]{Data: &root}, nil)
+	s.mockLightClient.EXPECT().Bootstrap(root.String()).Return(&consensus.LightClientBootstrapDeneb{
+		CurrentSyncCommittee:       &consensus.SyncCommittee{},
+		CurrentSyncCommitteeBranch: make([][32]byte, 5),
+	}, nil)
+	s.mockBeaconClient.EXPECT().Domain(gomock.Any(), prover.DEFAULT_SYNC_COMMITTEE_DOMAIN, gomock.Any()).Return(phase0.Domain{}, nil)
+}
+
+func (s *RotateArgsTestSuite) Test_RotateArgs_MissingUpdates() {
+	s.mockLightClient.EXPECT().Updates(uint64(4)).Return([]*consensus.LightClientUpdateDeneb{}, nil)
+
+	_, err := s.prover.RotateArgs(4)
+
+	s.NotNil(err)
+}
+
+func (s *RotateArgsTestSuite) Test_RotateArgs_ParticipationBelowThreshold() {
+	s.mockLightClient.EXPECT().Updates(uint64(4)).Return([]*consensus.LightClientUpdateDeneb{
+		newUpdate(updateOpts{participants: 341, attestedSlot: 32800, finalizedSlot: 32770, signatureSlot: 32801}),
+	}, nil)
+
+	_, err := s.prover.RotateArgs(4)
+
+	s.NotNil(err)
+}
+
+func (s *RotateArgsTestSuite) Test_RotateArgs_FinalizedInPreviousPeriod() {
+	s.mockLightClient.EXPECT().Updates(uint64(4)).Return([]*consensus.LightClientUpdateDeneb{
+		newUpdate(updateOpts{participants: 512, attestedSlot: 32800, finalizedSlot: 32700, signatureSlot: 32801}),
+	}, nil)
+
+	_, err := s.prover.RotateArgs(4)
+
+	s.NotNil(err)
+}
+
+func (s *RotateArgsTestSuite) Test_RotateArgs_KeepsBestUpdate() {
+	best := newUpdate(updateOpts{participants: 500, attestedSlot: 32800, finalizedSlot: 32770, signatureSlot: 32801})
+	s.mockLightClient.EXPECT().Updates(uint64(4)).Return([]*consensus.LightClientUpdateDeneb{
+		newUpdate(updateOpts{participants: 400, attestedSlot: 32900, finalizedSlot: 32870, signatureSlot: 32901}),
+		best,
+		// updates from other periods are ignored
+		newUpdate(updateOpts{participants: 512, attestedSlot: 41000, finalizedSlot: 40970, signatureSlot: 41001}),
+	}, nil)
+	s.expectBootstrap()
+
+	args, err := s.prover.RotateArgs(4)
+	s.Nil(err)
+	s.Equal(args.Update.AttestedHeader.Header.Slot, uint64(32800))
+
+	// the beacon node now returns a worse update for the period
+	s.mockLightClient.EXPECT().Updates(uint64(4)).Return([]*consensus.LightClientUpdateDeneb{
+		newUpdate(updateOpts{participants: 350, attestedSlot: 33000, finalizedSlot: 32970, signatureSlot: 33001}),
+	}, nil)
+	s.expectBootstrap()
+
+	args, err = s.prover.RotateArgs(4)
+	s.Nil(err)
+	s.Equal(args.Update.AttestedHeader.Header.Slot, uint64(32800))
+	s.Equal(best.NextSyncCommitteeBranch[0], [32]byte{1})
+}
+
+func (s *RotateArgsTestSuite) Test_RotateArgs_MinimalSpec() {
+	spec, err := prover.BuiltinSpec(prover.MINIMAL_SPEC)
+	s.Nil(err)
+	s.prover = prover.NewProver(
+		mock.NewMockProverClient(gomock.NewController(s.T())),
+		s.mockBeaconClient,
+		s.mockLightClient,
+		spec,
+		22,
+		8,
+		8,
+	)
+	s.mockLightClient.EXPECT().Updates(uint64(4)).Return([]*consensus.LightClientUpdateDeneb{
+		newUpdate(updateOpts{participants: 32, attestedSlot: 260, finalizedSlot: 258, signatureSlot: 261}),
+	}, nil)
+	s.expectBootstrap()
+
+	args, err := s.prover.RotateArgs(4)
+
+	s.Nil(err)
+	s.Equal(len(args.Pubkeys), 32)
+	update, err := args.UpdateSSZ()
+	s.Nil(err)
+	s.Equal(len(update), 4+33*48+5*32+4+6*32+4+96+8+2*len(s.headerSSZ(args.Update.FinalizedHeader)))
+}
+
+func (s *RotateArgsTestSuite) headerSSZ(header *consensus.LightClientHeaderDeneb) []byte {
+	headerSSZ, err := header.MarshalSSZ()
+	s.Nil(err)
+	return headerSSZ
+}
@@ -3,7 +3,14 @@
 
 package prover
 
-import "github.com/attestantio/go-eth2-client/spec/phase0"
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
 
 type Spec string
 
@@ -13,6 +20,160 @@ const (
 	MAINNET_SPEC Spec = "mainnet"
 )
 
-var (
-	SYNC_COMMITTEE_DOMAIN phase0.DomainType = [4]byte{7, 0, 0, 0}
-)
+// DEFAULT_SYNC_COMMITTEE_DOMAIN is the sync committee signature domain type of the consensus specs
+var DEFAULT_SYNC_COMMITTEE_DOMAIN = phase0.DomainType{7, 0, 0, 0}
+
+// Network identifies the beacon chain the spec is used for
+type Network struct {
+	GenesisForkVersion phase0.Version
+	// GenesisValidatorsRoot is empty if the spec is used by multiple networks
+	GenesisValidatorsRoot phase0.Root
+	DenebForkVersion      phase0.Version
+	// DenebForkEpoch isn't checked if zero
+	DenebForkEpoch phase0.Epoch
+}
+
+// Preset are the beacon chain preset parameters the spec circuits are built for
+type Preset struct {
+	SlotsPerEpoch                uint64
+	EpochsPerSyncCommitteePeriod uint64
+	SyncCommitteeSize            uint64
+}
+
+var PRESETS = map[Spec]Preset{
+	MAINNET_SPEC: {SlotsPerEpoch: 32, EpochsPerSyncCommitteePeriod: 256, SyncCommitteeSize: 512},
+	TESTNET_SPEC: {SlotsPerEpoch: 32, EpochsPerSyncCommitteePeriod: 256, SyncCommitteeSize: 512},
+	MINIMAL_SPEC: {SlotsPerEpoch: 8, EpochsPerSyncCommitteePeriod: 8, SyncCommitteeSize: 32},
+}
+
+// FinalityThreshold returns the supermajority of the sync committee
+// required to finalize light client updates
+func FinalityThreshold(syncCommitteeSize uint64) uint64 {
+	return (syncCommitteeSize*2 + 2) / 3
+}
+
+var NETWORKS = map[Spec]Network{
+	MAINNET_SPEC: {
+		GenesisForkVersion:    phase0.Version{0x00, 0x00, 0x00, 0x00},
+		GenesisValidatorsRoot: phase0.Root{0x4b, 0x36, 0x3d, 0xb9, 0x4e, 0x28, 0x61, 0x20, 0xd7, 0x6e, 0xb9, 0x05, 0x34, 0x0f, 0xdd, 0x4e, 0x54, 0xbf, 0xe9, 0xf0, 0x6b, 0xf3, 0x3f, 0xf6, 0xcf, 0x5a, 0xd2, 0x7f, 0x51, 0x1b, 0xfe, 0x95},
+		DenebForkVersion:      phase0.Version{0x04, 0x00, 0x00, 0x00},
+		DenebForkEpoch:        269568,
+	},
+	TESTNET_SPEC: {
+		GenesisForkVersion:    phase0.Version{0x90, 0x00, 0x00, 0x69},
+		GenesisValidatorsRoot: phase0.Root{0xd8, 0xea, 0x17, 0x1f, 0x3c, 0x94, 0xae, 0xa2, 0x1e, 0xbc, 0x42, 0xa1, 0xed, 0x61, 0x05, 0x2a, 0xcf, 0x3f, 0x92, 0x09, 0xc0, 0x0e, 0x4e, 0xfb, 0xaa, 0xdd, 0xac, 0x09, 0xed, 0x9b, 0x80, 0x78},
+		DenebForkVersion:      phase0.Version{0x90, 0x00, 0x00, 0x73},
+		DenebForkEpoch:        132608,
+	},
+	MINIMAL_SPEC: {
+		GenesisForkVersion: phase0.Version{0x00, 0x00, 0x00, 0x01},
+		DenebForkVersion:   phase0.Version{0x04, 0x00, 0x00, 0x01},
+	},
+}
+
+// ChainSpec is the spec a source domain is proven with
+type ChainSpec struct {
+	// Name is the spec passed to the prover
+	Name                Spec
+	Preset              Preset
+	Network             Network
+	SyncCommitteeDomain phase0.DomainType
+}
+
+// BuiltinSpec returns the chain spec of a spec the prover is built with
+func BuiltinSpec(name Spec) (*ChainSpec, error) {
+	preset, ok := PRESETS[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown spec %s", name)
+	}
+	return &ChainSpec{
+		Name:                name,
+		Preset:              preset,
+		Network:             NETWORKS[name],
+		SyncCommitteeDomain: DEFAULT_SYNC_COMMITTEE_DOMAIN,
+	}, nil
+}
+
+type chainSpecConfig struct {
+	Name                         string `json:"name"`
+	SlotsPerEpoch                uint64 `json:"slotsPerEpoch"`
+	EpochsPerSyncCommitteePeriod uint64 `json:"epochsPerSyncCommitteePeriod"`
+	SyncCommitteeSize            uint64 `json:"syncCommitteeSize"`
+	GenesisForkVersion           string `json:"genesisForkVersion"`
+	GenesisValidatorsRoot        string `json:"genesisValidatorsRoot"`
+	DenebForkVersion             string `json:"denebForkVersion"`
+	DenebForkEpoch               uint64 `json:"denebForkEpoch"`
+	SyncCommitteeDomain          string `json:"syncCommitteeDomain"`
+}
+
+// Decode decodes a custom spec from JSON, e.g.
+// {"name":"gnosis","slotsPerEpoch":16,"epochsPerSyncCommitteePeriod":512,"syncCommitteeSize":512,
+// "genesisForkVersion":"0x00000064","denebForkVersion":"0x04000064","denebForkEpoch":889856}
+func (s *ChainSpec) Decode(value string) error {
+	var c chainSpecConfig
+	err := json.Unmarshal([]byte(value), &c)
+	if err != nil {
+		return fmt.Errorf("invalid custom spec: %w", err)
+	}
+	if c.Name == "" {
+		return fmt.Errorf("custom spec name is required")
+	}
+	if c.SlotsPerEpoch == 0 || c.EpochsPerSyncCommitteePeriod == 0 {
+		return fmt.Errorf("custom spec %s slots per epoch and epochs per sync committee period are required", c.Name)
+	}
+	err = validateCommitteeSize(c.SyncCommitteeSize)
+	if err != nil {
+		return fmt.Errorf("custom spec %s: %w", c.Name, err)
+	}
+
+	spec := ChainSpec{
+		Name: Spec(c.Name),
+		Preset: Preset{
+			SlotsPerEpoch:                c.SlotsPerEpoch,
+			EpochsPerSyncCommitteePeriod: c.EpochsPerSyncCommitteePeriod,
+			SyncCommitteeSize:            c.SyncCommitteeSize,
+		},
+		Network: Network{
+			DenebForkEpoch: phase0.Epoch(c.DenebForkEpoch),
+		},
+		SyncCommitteeDomain: DEFAULT_SYNC_COMMITTEE_DOMAIN,
+	}
+	fields := []struct {
+		name     string
+		value    string
+		dst      []byte
+		required bool
+	}{
+		{"genesis fork version", c.GenesisForkVersion, spec.Network.GenesisForkVersion[:], true},
+		{"genesis validators root", c.GenesisValidatorsRoot, spec.Network.GenesisValidatorsRoot[:], false},
+		{"deneb fork version", c.DenebForkVersion, spec.Network.DenebForkVersion[:], true},
+		{"sync committee domain", c.SyncCommitteeDomain, spec.SyncCommitteeDomain[:], false},
+	}
+	for _, field := range fields {
+		if field.value == "" {
+			if field.required {
+				return fmt.Errorf("custom spec %s %s is required", c.Name, field.name)
+			}
+			continue
+		}
+		err = decodeHex(field.value, field.dst)
+		if err != nil {
+			return fmt.Errorf("custom spec %s has invalid %s: %w", c.Name, field.name, err)
+		}
+	}
+
+	*s = spec
+	return nil
+}
+
+func decodeHex(value string, dst []byte) error {
+	decoded, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+	if err != nil {
+		return err
+	}
+	if len(decoded) != len(dst) {
+		return fmt.Errorf("expected %d bytes but got %d", len(dst), len(decoded))
+	}
+	copy(dst, decoded)
+	return nil
+}
@@ -0,0 +1,102 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package prover_test
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/prover"
+)
+
+type ChainSpecTestSuite struct {
+	suite.Suite
+}
+
+func TestRunChainSpecTestSuite(t *testing.T) {
+	suite.Run(t, new(ChainSpecTestSuite))
+}
+
+func (s *ChainSpecTestSuite) Test_BuiltinSpec_UnknownSpec() {
+	_, err := prover.BuiltinSpec("gnosis")
+
+	s.NotNil(err)
+}
+
+func (s *ChainSpecTestSuite) Test_BuiltinSpec_Minimal() {
+	spec, err := prover.BuiltinSpec(prover.MINIMAL_SPEC)
+
+	s.Nil(err)
+	s.Equal(spec.Name, prover.MINIMAL_SPEC)
+	s.Equal(spec.Preset.SyncCommitteeSize, uint64(32))
+	s.Equal(spec.SyncCommitteeDomain, prover.DEFAULT_SYNC_COMMITTEE_DOMAIN)
+}
+
+func (s *ChainSpecTestSuite) Test_Decode_InvalidJSON() {
+	spec := &prover.ChainSpec{}
+
+	err := spec.Decode("{")
+
+	s.NotNil(err)
+}
+
+func (s *ChainSpecTestSuite) Test_Decode_MissingForkVersion() {
+	spec := &prover.ChainSpec{}
+
+	err := spec.Decode(`{"name":"gnosis","slotsPerEpoch":16,"epochsPerSyncCommitteePeriod":512,"syncCommitteeSize":512,"genesisForkVersion":"0x00000064"}`)
+
+	s.NotNil(err)
+}
+
+func (s *ChainSpecTestSuite) Test_Decode_InvalidCommitteeSize() {
+	spec := &prover.ChainSpec{}
+
+	err := spec.Decode(`{"name":"devnet","slotsPerEpoch":8,"epochsPerSyncCommitteePeriod":8,"syncCommitteeSize":30,"genesisForkVersion":"0x10000038","denebForkVersion":"0x40000038"}`)
+
+	s.NotNil(err)
+}
+
+func (s *ChainSpecTestSuite) Test_Decode_InvalidHex() {
+	spec := &prover.ChainSpec{}
+
+	err := spec.Decode(`{"name":"gnosis","slotsPerEpoch":16,"epochsPerSyncCommitteePeriod":512,"syncCommitteeSize":512,"genesisForkVersion":"0x000064","denebForkVersion":"0x04000064"}`)
+
+	s.NotNil(err)
+}
+
+func (s *ChainSpecTestSuite) Test_Decode_ValidSpec() {
+	spec := &prover.ChainSpec{}
+
+	err := spec.Decode(`{
+		"name": "gnosis",
+		"slotsPerEpoch": 16,
+		"epochsPerSyncCommitteePeriod": 512,
+		"syncCommitteeSize": 512,
+		"genesisForkVersion": "0x00000064",
+		"genesisValidatorsRoot": "0xf5dcb5564e829aab27264b9becd5dfaa017085611224cb3036f573368dbb9d47",
+		"denebForkVersion": "0x04000064",
+		"denebForkEpoch": 889856
+	}`)
+
+	s.Nil(err)
+	s.Equal(spec, &prover.ChainSpec{
+		Name: "gnosis",
+		Preset: prover.Preset{
+			SlotsPerEpoch:                16,
+			EpochsPerSyncCommitteePeriod: 512,
+			SyncCommitteeSize:            512,
+		},
+		Network: prover.Network{
+			GenesisForkVersion: phase0.Version{0x00, 0x00, 0x00, 0x64},
+			GenesisValidatorsRoot: phase0.Root{
+				0xf5, 0xdc, 0xb5, 0x56, 0x4e, 0x82, 0x9a, 0xab, 0x27, 0x26, 0x4b, 0x9b, 0xec, 0xd5, 0xdf, 0xaa,
+				0x01, 0x70, 0x85, 0x61, 0x12, 0x24, 0xcb, 0x30, 0x36, 0xf5, 0x73, 0x36, 0x8d, 0xbb, 0x9d, 0x47,
+			},
+			DenebForkVersion: phase0.Version{0x04, 0x00, 0x00, 0x64},
+			DenebForkEpoch:   889856,
+		},
+		SyncCommitteeDomain: prover.DEFAULT_SYNC_COMMITTEE_DOMAIN,
+	})
+}
@@ -0,0 +1,121 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package prover
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	consensus "github.com/umbracle/go-eth-consensus"
+)
+
+const (
+	FINALITY_BRANCH_DEPTH            = 6
+	NEXT_SYNC_COMMITTEE_BRANCH_DEPTH = 5
+	OFFSET_SIZE                      = 4
+	SIGNATURE_SIZE                   = 96
+	PUBKEY_SIZE                      = 48
+)
+
+// The consensus types are sized for the mainnet preset, so updates are re-encoded
+// with the sync committee size of the spec before they are passed to the prover.
+// For the mainnet preset the encoding matches MarshalSSZ.
+
+// FinalityUpdateSSZ returns the SSZ encoding of the finality update for the sync committee size
+func FinalityUpdateSSZ(update *consensus.LightClientFinalityUpdateDeneb, committeeSize uint64) ([]byte, error) {
+	err := validateCommitteeSize(committeeSize)
+	if err != nil {
+		return nil, err
+	}
+	if len(update.FinalityBranch) != FINALITY_BRANCH_DEPTH {
+		return nil, fmt.Errorf("invalid finality branch length %d", len(update.FinalityBranch))
+	}
+	attestedHeader, err := update.AttestedHeader.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	finalizedHeader, err := update.FinalizedHeader.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+
+	fixedSize := 2*OFFSET_SIZE + FINALITY_BRANCH_DEPTH*32 + syncAggregateSize(committeeSize) + 8
+	dst := make([]byte, 0, fixedSize+len(attestedHeader)+len(finalizedHeader))
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(fixedSize))
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(fixedSize+len(attestedHeader)))
+	dst = appendBranch(dst, update.FinalityBranch)
+	dst = appendSyncAggregate(dst, update.SyncAggregate, committeeSize)
+	dst = binary.LittleEndian.AppendUint64(dst, update.SignatureSlot)
+	dst = append(dst, attestedHeader...)
+	return append(dst, finalizedHeader...), nil
+}
+
+// UpdateSSZ returns the SSZ encoding of the light client update for the sync committee size
+func UpdateSSZ(update *consensus.LightClientUpdateDeneb, committeeSize uint64) ([]byte, error) {
+	err := validateCommitteeSize(committeeSize)
+	if err != nil {
+		return nil, err
+	}
+	if len(update.NextSyncCommitteeBranch) != NEXT_SYNC_COMMITTEE_BRANCH_DEPTH {
+		return nil, fmt.Errorf("invalid next sync committee branch length %d", len(update.NextSyncCommitteeBranch))
+	}
+	if len(update.FinalityBranch) != FINALITY_BRANCH_DEPTH {
+		return nil, fmt.Errorf("invalid finality branch length %d", len(update.FinalityBranch))
+	}
+	attestedHeader, err := update.AttestedHeader.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	finalizedHeader, err := update.FinalizedHeader.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+
+	fixedSize := OFFSET_SIZE +
+		int(committeeSize+1)*PUBKEY_SIZE +
+		NEXT_SYNC_COMMITTEE_BRANCH_DEPTH*32 +
+		OFFSET_SIZE +
+		FINALITY_BRANCH_DEPTH*32 +
+		syncAggregateSize(committeeSize) +
+		8
+	dst := make([]byte, 0, fixedSize+len(attestedHeader)+len(finalizedHeader))
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(fixedSize))
+	dst = append(dst, PubkeysSSZ(update.NextSyncCommittee.PubKeys[:committeeSize])...)
+	dst = append(dst, update.NextSyncCommittee.AggregatePubKey[:]...)
+	dst = appendBranch(dst, update.NextSyncCommitteeBranch)
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(fixedSize+len(attestedHeader)))
+	dst = appendBranch(dst, update.FinalityBranch)
+	dst = appendSyncAggregate(dst, update.SyncAggregate, committeeSize)
+	dst = binary.LittleEndian.AppendUint64(dst, update.SignatureSlot)
+	dst = append(dst, attestedHeader...)
+	return append(dst, finalizedHeader...), nil
+}
+
+// Participation returns the number of sync committee members that signed the sync aggregate
+func Participation(aggregate *consensus.SyncAggregate, committeeSize uint64) uint64 {
+	return uint64(CountSetBits(aggregate.SyncCommiteeBits[:committeeSize/8]))
+}
+
+func validateCommitteeSize(committeeSize uint64) error {
+	if committeeSize == 0 || committeeSize%8 != 0 || committeeSize > 512 {
+		return fmt.Errorf("unsupported sync committee size %d", committeeSize)
+	}
+	return nil
+}
+
+func syncAggregateSize(committeeSize uint64) int {
+	return int(committeeSize/8) + SIGNATURE_SIZE
+}
+
+func appendSyncAggregate(dst []byte, aggregate *consensus.SyncAggregate, committeeSize uint64) []byte {
+	dst = append(dst, aggregate.SyncCommiteeBits[:committeeSize/8]...)
+	return append(dst, aggregate.SyncCommiteeSignature[:]...)
+}
+
+func appendBranch(dst []byte, branch [][32]byte) []byte {
+	for _, node := range branch {
+		dst = append(dst, node[:]...)
+	}
+	return dst
+}
@@ -0,0 +1,84 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package prover_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/prover"
+	consensus "github.com/umbracle/go-eth-consensus"
+)
+
+type SSZTestSuite struct {
+	suite.Suite
+}
+
+func TestRunSSZTestSuite(t *testing.T) {
+	suite.Run(t, new(SSZTestSuite))
+}
+
+func finalityUpdate(update *consensus.LightClientUpdateDeneb) *consensus.LightClientFinalityUpdateDeneb {
+	return &consensus.LightClientFinalityUpdateDeneb{
+		AttestedHeader:  update.AttestedHeader,
+		FinalizedHeader: update.FinalizedHeader,
+		FinalityBranch:  update.FinalityBranch,
+		SyncAggregate:   update.SyncAggregate,
+		SignatureSlot:   update.SignatureSlot,
+	}
+}
+
+func (s *SSZTestSuite) Test_UpdateSSZ_MainnetMatchesMarshalSSZ() {
+	update := newUpdate(updateOpts{participants: 400, attestedSlot: 32800, finalizedSlot: 32770, signatureSlot: 32801})
+	update.NextSyncCommittee.PubKeys[511] = [48]byte{1}
+	update.NextSyncCommittee.AggregatePubKey = [48]byte{2}
+	update.SyncAggregate.SyncCommiteeSignature = [96]byte{3}
+	expected, err := update.MarshalSSZ()
+	s.Nil(err)
+
+	updateSSZ, err := prover.UpdateSSZ(update, 512)
+
+	s.Nil(err)
+	s.Equal(updateSSZ, expected)
+}
+
+func (s *SSZTestSuite) Test_FinalityUpdateSSZ_MainnetMatchesMarshalSSZ() {
+	update := finalityUpdate(newUpdate(updateOpts{participants: 400, attestedSlot: 32800, finalizedSlot: 32770, signatureSlot: 32801}))
+	update.SyncAggregate.SyncCommiteeSignature = [96]byte{3}
+	expected, err := update.MarshalSSZ()
+	s.Nil(err)
+
+	updateSSZ, err := prover.FinalityUpdateSSZ(update, 512)
+
+	s.Nil(err)
+	s.Equal(updateSSZ, expected)
+}
+
+func (s *SSZTestSuite) Test_FinalityUpdateSSZ_Minimal() {
+	update := finalityUpdate(newUpdate(updateOpts{participants: 32, attestedSlot: 260, finalizedSlot: 258, signatureSlot: 261}))
+	mainnetSSZ, err := update.MarshalSSZ()
+	s.Nil(err)
+
+	updateSSZ, err := prover.FinalityUpdateSSZ(update, 32)
+
+	s.Nil(err)
+	// the sync committee bits shrink from 64 to 4 bytes
+	s.Equal(len(updateSSZ), len(mainnetSSZ)-60)
+	s.Equal(updateSSZ[8+6*32:8+6*32+4], []byte{0xff, 0xff, 0xff, 0xff})
+}
+
+func (s *SSZTestSuite) Test_FinalityUpdateSSZ_InvalidCommitteeSize() {
+	update := finalityUpdate(newUpdate(updateOpts{participants: 32}))
+
+	_, err := prover.FinalityUpdateSSZ(update, 33)
+
+	s.NotNil(err)
+}
+
+func (s *SSZTestSuite) Test_Participation_CountsCommitteeBits() {
+	update := newUpdate(updateOpts{participants: 40})
+
+	s.Equal(prover.Participation(update.SyncAggregate, 32), uint64(32))
+	s.Equal(prover.Participation(update.SyncAggregate, 512), uint64(40))
+}
@@ -0,0 +1,82 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package prover
+
+import (
+	consensus "github.com/umbracle/go-eth-consensus"
+)
+
+// IsBetterUpdate ranks light client updates as defined by is_better_update
+// in the altair light client sync protocol for the preset sync committee size
+func IsBetterUpdate(newUpdate *consensus.LightClientUpdateDeneb, oldUpdate *consensus.LightClientUpdateDeneb, slotsPerPeriod uint64, committeeSize uint64) bool {
+	maxParticipants := committeeSize
+	newParticipants := Participation(newUpdate.SyncAggregate, committeeSize)
+	oldParticipants := Participation(oldUpdate.SyncAggregate, committeeSize)
+	newHasSupermajority := newParticipants*3 >= maxParticipants*2
+	oldHasSupermajority := oldParticipants*3 >= maxParticipants*2
+	if newHasSupermajority != oldHasSupermajority {
+		return newHasSupermajority
+	}
+	if !newHasSupermajority && newParticipants != oldParticipants {
+		return newParticipants > oldParticipants
+	}
+
+	newHasRelevantSyncCommittee := hasRelevantSyncCommittee(newUpdate, slotsPerPeriod)
+	oldHasRelevantSyncCommittee := hasRelevantSyncCommittee(oldUpdate, slotsPerPeriod)
+	if newHasRelevantSyncCommittee != oldHasRelevantSyncCommittee {
+		return newHasRelevantSyncCommittee
+	}
+
+	newHasFinality := isFinalityUpdate(newUpdate)
+	oldHasFinality := isFinalityUpdate(oldUpdate)
+	if newHasFinality != oldHasFinality {
+		return newHasFinality
+	}
+	if newHasFinality {
+		newHasSyncCommitteeFinality := hasSyncCommitteeFinality(newUpdate, slotsPerPeriod)
+		oldHasSyncCommitteeFinality := hasSyncCommitteeFinality(oldUpdate, slotsPerPeriod)
+		if newHasSyncCommitteeFinality != oldHasSyncCommitteeFinality {
+			return newHasSyncCommitteeFinality
+		}
+	}
+
+	// tiebreakers prefer higher participation and then older data
+	if newParticipants != oldParticipants {
+		return newParticipants > oldParticipants
+	}
+	if newUpdate.AttestedHeader.Header.Slot != oldUpdate.AttestedHeader.Header.Slot {
+		return newUpdate.AttestedHeader.Header.Slot < oldUpdate.AttestedHeader.Header.Slot
+	}
+	return newUpdate.SignatureSlot < oldUpdate.SignatureSlot
+}
+
+// hasRelevantSyncCommittee returns true if the update proves the next sync committee
+// of the period it was signed in
+func hasRelevantSyncCommittee(update *consensus.LightClientUpdateDeneb, slotsPerPeriod uint64) bool {
+	return isSyncCommitteeUpdate(update) &&
+		update.AttestedHeader.Header.Slot/slotsPerPeriod == update.SignatureSlot/slotsPerPeriod
+}
+
+// hasSyncCommitteeFinality returns true if the finalized header is in the same period as the
+// attested header, so the next sync committee is proven by a finalized state
+func hasSyncCommitteeFinality(update *consensus.LightClientUpdateDeneb, slotsPerPeriod uint64) bool {
+	return update.FinalizedHeader.Header.Slot/slotsPerPeriod == update.AttestedHeader.Header.Slot/slotsPerPeriod
+}
+
+func isSyncCommitteeUpdate(update *consensus.LightClientUpdateDeneb) bool {
+	return !isEmptyBranch(update.NextSyncCommitteeBranch)
+}
+
+func isFinalityUpdate(update *consensus.LightClientUpdateDeneb) bool {
+	return !isEmptyBranch(update.FinalityBranch)
+}
+
+func isEmptyBranch(branch [][32]byte) bool {
+	for _, node := range branch {
+		if node != [32]byte{} {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,152 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package prover_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/prover"
+	consensus "github.com/umbracle/go-eth-consensus"
+)
+
+const (
+	SLOTS_PER_PERIOD    = 8192
+	SYNC_COMMITTEE_SIZE = 512
+)
+
+type updateOpts struct {
+	participants  int
+	attestedSlot  uint64
+	finalizedSlot uint64
+	signatureSlot uint64
+	noFinality    bool
+	noCommittee   bool
+}
+
+func newUpdate(opts updateOpts) *consensus.LightClientUpdateDeneb {
+	bits := [64]byte{}
+	for i := 0; i < opts.participants; i++ {
+		bits[i/8] |= 1 << (i % 8)
+	}
+	nextSyncCommitteeBranch := make([][32]byte, 5)
+	if !opts.noCommittee {
+		nextSyncCommitteeBranch[0] = [32]byte{1}
+	}
+	finalityBranch := make([][32]byte, 6)
+	if !opts.noFinality {
+		finalityBranch[0] = [32]byte{1}
+	}
+
+	return &consensus.LightClientUpdateDeneb{
+		AttestedHeader: &consensus.LightClientHeaderDeneb{
+			Header:    &consensus.BeaconBlockHeader{Slot: opts.attestedSlot},
+			Execution: &consensus.ExecutionPayloadHeaderDeneb{},
+		},
+		FinalizedHeader: &consensus.LightClientHeaderDeneb{
+			Header:    &consensus.BeaconBlockHeader{Slot: opts.finalizedSlot},
+			Execution: &consensus.ExecutionPayloadHeaderDeneb{},
+		},
+		NextSyncCommittee:       &consensus.SyncCommittee{},
+		NextSyncCommitteeBranch: nextSyncCommitteeBranch,
+		FinalityBranch:          finalityBranch,
+		SyncAggregate:           &consensus.SyncAggregate{SyncCommiteeBits: bits},
+		SignatureSlot:           opts.signatureSlot,
+	}
+}
+
+type IsBetterUpdateTestSuite struct {
+	suite.Suite
+}
+
+func TestRunIsBetterUpdateTestSuite(t *testing.T) {
+	suite.Run(t, new(IsBetterUpdateTestSuite))
+}
+
+func (s *IsBetterUpdateTestSuite) Test_IsBetterUpdate() {
+	base := updateOpts{participants: 400, attestedSlot: 32800, finalizedSlot: 32770, signatureSlot: 32801}
+	with := func(change func(opts *updateOpts)) *consensus.LightClientUpdateDeneb {
+		opts := base
+		change(&opts)
+		return newUpdate(opts)
+	}
+
+	tests := []struct {
+		name      string
+		newUpdate *consensus.LightClientUpdateDeneb
+		oldUpdate *consensus.LightClientUpdateDeneb
+		better    bool
+	}{
+		{
+			name:      "supermajority beats higher participation without it",
+			newUpdate: with(func(opts *updateOpts) { opts.participants = 342; opts.noFinality = true }),
+			oldUpdate: with(func(opts *updateOpts) { opts.participants = 341 }),
+			better:    true,
+		},
+		{
+			name:      "higher participation without supermajority",
+			newUpdate: with(func(opts *updateOpts) { opts.participants = 300; opts.noFinality = true }),
+			oldUpdate: with(func(opts *updateOpts) { opts.participants = 200 }),
+			better:    true,
+		},
+		{
+			name:      "relevant sync committee",
+			newUpdate: with(func(opts *updateOpts) { opts.participants = 342 }),
+			oldUpdate: with(func(opts *updateOpts) { opts.signatureSlot = 40960 }),
+			better:    true,
+		},
+		{
+			name:      "finality",
+			newUpdate: with(func(opts *updateOpts) { opts.participants = 342 }),
+			oldUpdate: with(func(opts *updateOpts) { opts.noFinality = true }),
+			better:    true,
+		},
+		{
+			name:      "sync committee finality",
+			newUpdate: with(func(opts *updateOpts) { opts.participants = 342 }),
+			oldUpdate: with(func(opts *updateOpts) { opts.finalizedSlot = 32700 }),
+			better:    true,
+		},
+		{
+			name:      "higher participation beyond supermajority",
+			newUpdate: with(func(opts *updateOpts) {}),
+			oldUpdate: with(func(opts *updateOpts) { opts.participants = 342 }),
+			better:    true,
+		},
+		{
+			name:      "older attested header",
+			newUpdate: with(func(opts *updateOpts) {}),
+			oldUpdate: with(func(opts *updateOpts) { opts.attestedSlot = 32900 }),
+			better:    true,
+		},
+		{
+			name:      "older signature slot",
+			newUpdate: with(func(opts *updateOpts) {}),
+			oldUpdate: with(func(opts *updateOpts) { opts.signatureSlot = 32802 }),
+			better:    true,
+		},
+		{
+			name:      "equal updates",
+			newUpdate: with(func(opts *updateOpts) {}),
+			oldUpdate: with(func(opts *updateOpts) {}),
+			better:    false,
+		},
+	}
+
+	for _, t := range tests {
+		s.Equal(t.better, prover.IsBetterUpdate(t.newUpdate, t.oldUpdate, SLOTS_PER_PERIOD, SYNC_COMMITTEE_SIZE), t.name)
+		if t.better {
+			s.False(prover.IsBetterUpdate(t.oldUpdate, t.newUpdate, SLOTS_PER_PERIOD, SYNC_COMMITTEE_SIZE), t.name)
+		}
+	}
+}
+
+func (s *IsBetterUpdateTestSuite) Test_IsBetterUpdate_MinimalCommittee() {
+	// 22 of 32 members are a supermajority, so finality outranks the higher participation
+	finalityUpdate := newUpdate(updateOpts{participants: 22, attestedSlot: 72, finalizedSlot: 66, signatureSlot: 73})
+	attestedUpdate := newUpdate(updateOpts{participants: 23, attestedSlot: 72, finalizedSlot: 66, signatureSlot: 73, noFinality: true})
+
+	s.True(prover.IsBetterUpdate(finalityUpdate, attestedUpdate, 64, 32))
+	s.False(prover.IsBetterUpdate(attestedUpdate, finalityUpdate, 64, 32))
+}
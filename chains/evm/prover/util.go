@@ -27,7 +27,7 @@ func U16ArrayToByteArray(src []uint16) []byte {
 	return dst
 }
 
-func CountSetBits(arr [64]byte) int {
+func CountSetBits(arr []byte) int {
 	count := 0
 	for _, b := range arr {
 		for i := 0; i < 8; i++ {
@@ -45,3 +45,12 @@ func SliceTo32Bytes(in []byte) [32]byte {
 	copy(res[:], in)
 	return res
 }
+
+// PubkeysSSZ concatenates sync committee public keys into their SSZ encoding
+func PubkeysSSZ(pubkeys [][48]byte) []byte {
+	pubkeysSSZ := make([]byte, 0, len(pubkeys)*PUBKEY_SIZE)
+	for _, pubkey := range pubkeys {
+		pubkeysSSZ = append(pubkeysSSZ, pubkey[:]...)
+	}
+	return pubkeysSSZ
+}
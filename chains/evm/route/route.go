@@ -0,0 +1,141 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package route
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Route configures what is relayed from the source domain to a destination domain
+type Route struct {
+	Destination uint8
+	Step        bool
+	Rotate      bool
+	// SecurityModels are the deposit security models the destination accepts,
+	// deposits with any security model are accepted if empty
+	SecurityModels []uint8
+	// MinStepInterval is the minimum time between two steps to the destination
+	MinStepInterval time.Duration
+}
+
+type routeConfig struct {
+	Destination     *uint8  `json:"destination"`
+	Step            *bool   `json:"step"`
+	Rotate          *bool   `json:"rotate"`
+	SecurityModels  []uint8 `json:"securityModels"`
+	MinStepInterval uint64  `json:"minStepInterval"`
+}
+
+// UnmarshalJSON decodes the route with step and rotate enabled unless disabled
+// and the minimum step interval in seconds
+func (r *Route) UnmarshalJSON(data []byte) error {
+	var c routeConfig
+	err := json.Unmarshal(data, &c)
+	if err != nil {
+		return err
+	}
+	if c.Destination == nil {
+		return fmt.Errorf("route destination is required")
+	}
+
+	*r = *NewRoute(*c.Destination)
+	if c.Step != nil {
+		r.Step = *c.Step
+	}
+	if c.Rotate != nil {
+		r.Rotate = *c.Rotate
+	}
+	r.SecurityModels = c.SecurityModels
+	r.MinStepInterval = time.Duration(c.MinStepInterval) * time.Second
+	return nil
+}
+
+// NewRoute returns the default route that steps and rotates to the destination
+func NewRoute(destination uint8) *Route {
+	return &Route{
+		Destination: destination,
+		Step:        true,
+		Rotate:      true,
+	}
+}
+
+// AcceptsSecurityModel returns true if deposits with the security model are relayed to the destination
+func (r *Route) AcceptsSecurityModel(securityModel uint8) bool {
+	if len(r.SecurityModels) == 0 {
+		return true
+	}
+	for _, model := range r.SecurityModels {
+		if model == securityModel {
+			return true
+		}
+	}
+	return false
+}
+
+// Routes are the routes of a source domain keyed by the destination domain
+type Routes map[uint8]*Route
+
+// NewRoutes returns default routes to the destinations
+func NewRoutes(destinations []uint8) Routes {
+	routes := make(Routes)
+	for _, destination := range destinations {
+		routes[destination] = NewRoute(destination)
+	}
+	return routes
+}
+
+// Decode decodes routes from a JSON list of routes, e.g.
+// [{"destination":2,"step":false},{"destination":4,"securityModels":[2],"minStepInterval":600}]
+func (r *Routes) Decode(value string) error {
+	var routes []*Route
+	err := json.Unmarshal([]byte(value), &routes)
+	if err != nil {
+		return fmt.Errorf("invalid routes: %w", err)
+	}
+
+	*r = make(Routes)
+	for _, route := range routes {
+		if _, ok := (*r)[route.Destination]; ok {
+			return fmt.Errorf("duplicate route to domain %d", route.Destination)
+		}
+		(*r)[route.Destination] = route
+	}
+	return nil
+}
+
+// Merge returns the routes overridden by routes of the other routes
+func (r Routes) Merge(other Routes) Routes {
+	routes := make(Routes)
+	for destination, route := range r {
+		routes[destination] = route
+	}
+	for destination, route := range other {
+		routes[destination] = route
+	}
+	return routes
+}
+
+// StepDomains returns the sorted destinations steps are submitted to
+func (r Routes) StepDomains() []uint8 {
+	return r.domains(func(route *Route) bool { return route.Step })
+}
+
+// RotateDomains returns the sorted destinations committee rotations are submitted to
+func (r Routes) RotateDomains() []uint8 {
+	return r.domains(func(route *Route) bool { return route.Rotate })
+}
+
+func (r Routes) domains(enabled func(route *Route) bool) []uint8 {
+	domains := make([]uint8, 0, len(r))
+	for destination, route := range r {
+		if enabled(route) {
+			domains = append(domains, destination)
+		}
+	}
+	sort.Slice(domains, func(i, j int) bool { return domains[i] < domains[j] })
+	return domains
+}
@@ -0,0 +1,68 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package route_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/route"
+)
+
+type RouteTestSuite struct {
+	suite.Suite
+}
+
+func TestRunRouteTestSuite(t *testing.T) {
+	suite.Run(t, new(RouteTestSuite))
+}
+
+func (s *RouteTestSuite) Test_Decode_InvalidJSON() {
+	var routes route.Routes
+	err := routes.Decode("2,3")
+
+	s.NotNil(err)
+}
+
+func (s *RouteTestSuite) Test_Decode_DuplicateRoute() {
+	var routes route.Routes
+	err := routes.Decode(`[{"destination":2},{"destination":2,"step":false}]`)
+
+	s.NotNil(err)
+}
+
+func (s *RouteTestSuite) Test_Decode_DefaultsEnabled() {
+	var routes route.Routes
+	err := routes.Decode(`[{"destination":2},{"destination":3,"rotate":false,"securityModels":[1,2],"minStepInterval":60}]`)
+
+	s.Nil(err)
+	s.Equal(routes[2], route.NewRoute(2))
+	s.Equal(routes[3], &route.Route{
+		Destination:     3,
+		Step:            true,
+		Rotate:          false,
+		SecurityModels:  []uint8{1, 2},
+		MinStepInterval: time.Minute,
+	})
+}
+
+func (s *RouteTestSuite) Test_Merge_OverridesRoutes() {
+	routes := route.NewRoutes([]uint8{2, 3}).Merge(route.Routes{
+		3: &route.Route{Destination: 3, Rotate: true},
+		4: &route.Route{Destination: 4, Step: true},
+	})
+
+	s.Equal(routes.StepDomains(), []uint8{2, 4})
+	s.Equal(routes.RotateDomains(), []uint8{2, 3})
+}
+
+func (s *RouteTestSuite) Test_AcceptsSecurityModel() {
+	r := route.NewRoute(2)
+	s.True(r.AcceptsSecurityModel(5))
+
+	r.SecurityModels = []uint8{1, 2}
+	s.True(r.AcceptsSecurityModel(2))
+	s.False(r.AcceptsSecurityModel(3))
+}
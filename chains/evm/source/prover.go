@@ -0,0 +1,85 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package source
+
+import (
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/listener/handlers"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/prover"
+)
+
+// SharedProver reuses prover arguments fetched for the finalized checkpoint, so every
+// domain of a source proves the same light client update and gets the cached proof.
+// Arguments are fetched on every call until a checkpoint is set.
+type SharedProver struct {
+	handlers.Prover
+
+	lock       sync.Mutex
+	checkpoint phase0.Root
+	stepArgs   *prover.StepArgs
+	rotateArgs map[uint64]*prover.RotateArgs
+}
+
+func NewSharedProver(sourceProver handlers.Prover) *SharedProver {
+	return &SharedProver{
+		Prover:     sourceProver,
+		rotateArgs: make(map[uint64]*prover.RotateArgs),
+	}
+}
+
+// SetCheckpoint drops arguments fetched for a previous finalized checkpoint
+func (p *SharedProver) SetCheckpoint(root phase0.Root) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if root == p.checkpoint {
+		return
+	}
+	p.checkpoint = root
+	p.stepArgs = nil
+	p.rotateArgs = make(map[uint64]*prover.RotateArgs)
+}
+
+// StepArgs returns a copy of the step arguments fetched for the checkpoint
+func (p *SharedProver) StepArgs() (*prover.StepArgs, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.stepArgs == nil || p.checkpoint == (phase0.Root{}) {
+		args, err := p.Prover.StepArgs()
+		if err != nil {
+			return nil, err
+		}
+		p.stepArgs = args
+	}
+
+	args := *p.stepArgs
+	update := *args.Update
+	args.Update = &update
+	return &args, nil
+}
+
+// RotateArgs returns a copy of the rotate arguments of the period fetched for the checkpoint
+func (p *SharedProver) RotateArgs(period uint64) (*prover.RotateArgs, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	cached, ok := p.rotateArgs[period]
+	if !ok || p.checkpoint == (phase0.Root{}) {
+		args, err := p.Prover.RotateArgs(period)
+		if err != nil {
+			return nil, err
+		}
+		cached = args
+		p.rotateArgs[period] = args
+	}
+
+	// the rotate proof overrides the attested header of the update
+	args := *cached
+	update := *args.Update
+	args.Update = &update
+	return &args, nil
+}
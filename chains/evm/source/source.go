@@ -0,0 +1,128 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package source
+
+import (
+	"fmt"
+	"sort"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/http"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/listener"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/prover"
+)
+
+// Params are the network parameters every domain sharing a source has to agree on
+type Params struct {
+	Spec                  prover.Spec
+	FinalityThreshold     uint64
+	SlotsPerEpoch         uint64
+	CommitteePeriodLength uint64
+}
+
+// Source is a beacon chain shared by the source domains using it. Finality is
+// followed by a single listener that fans out checkpoints to the event handlers
+// of every domain and proofs are generated once by the shared prover.
+type Source struct {
+	GenesisValidatorsRoot phase0.Root
+	Params                Params
+	BeaconProvider        *http.Service
+	EventStream           listener.EventStream
+	Prover                *SharedProver
+
+	domains  []uint8
+	handlers map[uint8][]listener.EventHandler
+}
+
+func NewSource(
+	genesisValidatorsRoot phase0.Root,
+	params Params,
+	beaconProvider *http.Service,
+	eventStream listener.EventStream,
+	prover *SharedProver,
+) *Source {
+	return &Source{
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+		Params:                params,
+		BeaconProvider:        beaconProvider,
+		EventStream:           eventStream,
+		Prover:                prover,
+		domains:               make([]uint8, 0),
+		handlers:              make(map[uint8][]listener.EventHandler),
+	}
+}
+
+// AddDomain registers the event handlers of a source domain using the beacon chain
+func (s *Source) AddDomain(domainID uint8, params Params, eventHandlers []listener.EventHandler) error {
+	if params != s.Params {
+		return fmt.Errorf("domain %d network parameters %+v don't match the parameters %+v of domains %v on the same beacon chain", domainID, params, s.Params, s.domains)
+	}
+	if _, ok := s.handlers[domainID]; ok {
+		return fmt.Errorf("domain %d already uses the source", domainID)
+	}
+
+	s.domains = append(s.domains, domainID)
+	sort.Slice(s.domains, func(i, j int) bool { return s.domains[i] < s.domains[j] })
+	s.handlers[domainID] = eventHandlers
+	return nil
+}
+
+// Domains returns the sorted source domains using the beacon chain
+func (s *Source) Domains() []uint8 {
+	return s.domains
+}
+
+// ListenerDomain returns the domain the shared listener is run and checkpoints are stored for
+func (s *Source) ListenerDomain() uint8 {
+	return s.domains[0]
+}
+
+// Handlers returns the event handlers of every domain. Handlers of sources shared by
+// multiple domains are named by their domain in logs and metrics.
+func (s *Source) Handlers() []listener.EventHandler {
+	shared := len(s.domains) > 1
+	eventHandlers := make([]listener.EventHandler, 0)
+	for _, domainID := range s.domains {
+		for _, handler := range s.handlers[domainID] {
+			name := listener.HandlerName(handler)
+			if shared {
+				name = fmt.Sprintf("%s:%d", name, domainID)
+			}
+			eventHandlers = append(eventHandlers, &domainHandler{
+				handler: handler,
+				prover:  s.Prover,
+				name:    name,
+			})
+		}
+	}
+	return eventHandlers
+}
+
+// domainHandler sets the checkpoint of the shared prover before handling events
+type domainHandler struct {
+	handler listener.EventHandler
+	prover  *SharedProver
+	name    string
+}
+
+func (h *domainHandler) HandleEvents(checkpoint *apiv1.Finality) error {
+	h.prover.SetCheckpoint(checkpoint.Finalized.Root)
+	return h.handler.HandleEvents(checkpoint)
+}
+
+func (h *domainHandler) HandleGap(from phase0.Epoch, to phase0.Epoch) error {
+	gapHandler, ok := h.handler.(listener.GapHandler)
+	if !ok {
+		return nil
+	}
+	return gapHandler.HandleGap(from, to)
+}
+
+func (h *domainHandler) Name() string {
+	return h.name
+}
+
+// Sources are the beacon chains of source domains keyed by their genesis validators root
+type Sources map[phase0.Root]*Source
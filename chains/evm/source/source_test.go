@@ -0,0 +1,240 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package source_test
+
+import (
+	"fmt"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/listener"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/listener/handlers"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/prover"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/source"
+	"github.com/sygmaprotocol/spectre-node/mock"
+	consensus "github.com/umbracle/go-eth-consensus"
+	"go.uber.org/mock/gomock"
+)
+
+var params = source.Params{
+	Spec:                  prover.MAINNET_SPEC,
+	FinalityThreshold:     342,
+	SlotsPerEpoch:         32,
+	CommitteePeriodLength: 256,
+}
+
+func checkpoint(root byte) *apiv1.Finality {
+	return &apiv1.Finality{
+		Finalized: &phase0.Checkpoint{Root: phase0.Root{root}},
+	}
+}
+
+type SharedProverTestSuite struct {
+	suite.Suite
+
+	mockProver   *mock.MockProver
+	sharedProver *source.SharedProver
+}
+
+func TestRunSharedProverTestSuite(t *testing.T) {
+	suite.Run(t, new(SharedProverTestSuite))
+}
+
+func (s *SharedProverTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockProver = mock.NewMockProver(ctrl)
+	s.sharedProver = source.NewSharedProver(s.mockProver)
+}
+
+func (s *SharedProverTestSuite) stepArgs(slot uint64) *prover.StepArgs {
+	return &prover.StepArgs{
+		Update: &consensus.LightClientFinalityUpdateDeneb{
+			FinalizedHeader: &consensus.LightClientHeaderDeneb{
+				Header: &consensus.BeaconBlockHeader{Slot: slot},
+			},
+		},
+	}
+}
+
+func (s *SharedProverTestSuite) Test_StepArgs_NoCheckpoint_FetchedOnEveryCall() {
+	s.mockProver.EXPECT().StepArgs().Return(s.stepArgs(100), nil).Times(2)
+
+	_, err := s.sharedProver.StepArgs()
+	s.Nil(err)
+	_, err = s.sharedProver.StepArgs()
+	s.Nil(err)
+}
+
+func (s *SharedProverTestSuite) Test_StepArgs_SameCheckpoint_Reused() {
+	s.mockProver.EXPECT().StepArgs().Return(s.stepArgs(100), nil)
+	s.sharedProver.SetCheckpoint(phase0.Root{1})
+
+	first, err := s.sharedProver.StepArgs()
+	s.Nil(err)
+	second, err := s.sharedProver.StepArgs()
+	s.Nil(err)
+
+	s.Equal(first, second)
+	// every domain gets its own copy of the update
+	s.NotSame(first.Update, second.Update)
+}
+
+func (s *SharedProverTestSuite) Test_StepArgs_NewCheckpoint_Refetched() {
+	s.mockProver.EXPECT().StepArgs().Return(s.stepArgs(100), nil)
+	s.mockProver.EXPECT().StepArgs().Return(s.stepArgs(132), nil)
+	s.sharedProver.SetCheckpoint(phase0.Root{1})
+
+	first, err := s.sharedProver.StepArgs()
+	s.Nil(err)
+	s.sharedProver.SetCheckpoint(phase0.Root{2})
+	second, err := s.sharedProver.StepArgs()
+	s.Nil(err)
+
+	s.Equal(first.Update.FinalizedHeader.Header.Slot, uint64(100))
+	s.Equal(second.Update.FinalizedHeader.Header.Slot, uint64(132))
+}
+
+func (s *SharedProverTestSuite) Test_StepArgs_FetchingFails_NotReused() {
+	s.mockProver.EXPECT().StepArgs().Return(nil, fmt.Errorf("error"))
+	s.mockProver.EXPECT().StepArgs().Return(s.stepArgs(100), nil)
+	s.sharedProver.SetCheckpoint(phase0.Root{1})
+
+	_, err := s.sharedProver.StepArgs()
+	s.NotNil(err)
+	_, err = s.sharedProver.StepArgs()
+	s.Nil(err)
+}
+
+func (s *SharedProverTestSuite) Test_RotateArgs_ReusedPerPeriod() {
+	finalizedHeader := &consensus.LightClientHeaderDeneb{}
+	s.mockProver.EXPECT().RotateArgs(uint64(4)).Return(&prover.RotateArgs{
+		Update: &consensus.LightClientUpdateDeneb{
+			AttestedHeader:  &consensus.LightClientHeaderDeneb{},
+			FinalizedHeader: finalizedHeader,
+		},
+	}, nil)
+	s.mockProver.EXPECT().RotateArgs(uint64(5)).Return(&prover.RotateArgs{
+		Update: &consensus.LightClientUpdateDeneb{},
+	}, nil)
+	s.sharedProver.SetCheckpoint(phase0.Root{1})
+
+	first, err := s.sharedProver.RotateArgs(4)
+	s.Nil(err)
+	// the rotate proof of the first domain overrides the attested header
+	first.Update.AttestedHeader = first.Update.FinalizedHeader
+	second, err := s.sharedProver.RotateArgs(4)
+	s.Nil(err)
+	_, err = s.sharedProver.RotateArgs(5)
+	s.Nil(err)
+
+	s.NotSame(second.Update.AttestedHeader, finalizedHeader)
+}
+
+type SourceTestSuite struct {
+	suite.Suite
+
+	mockProver *mock.MockProver
+	source     *source.Source
+}
+
+func TestRunSourceTestSuite(t *testing.T) {
+	suite.Run(t, new(SourceTestSuite))
+}
+
+func (s *SourceTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockProver = mock.NewMockProver(ctrl)
+	s.source = source.NewSource(phase0.Root{1}, params, nil, nil, source.NewSharedProver(s.mockProver))
+}
+
+// stepHandler fetches step args like the step handler of a domain
+type stepHandler struct {
+	prover handlers.Prover
+	slots  []uint64
+}
+
+func (h *stepHandler) HandleEvents(checkpoint *apiv1.Finality) error {
+	args, err := h.prover.StepArgs()
+	if err != nil {
+		return err
+	}
+	h.slots = append(h.slots, args.Update.FinalizedHeader.Header.Slot)
+	return nil
+}
+
+// gapHandler records handled gaps like the rotate handler of a domain
+type gapHandler struct {
+	stepHandler
+	gaps []phase0.Epoch
+}
+
+func (h *gapHandler) HandleGap(from phase0.Epoch, to phase0.Epoch) error {
+	h.gaps = append(h.gaps, from, to)
+	return nil
+}
+
+func (s *SourceTestSuite) Test_AddDomain_ParamsMismatch() {
+	minimalParams := params
+	minimalParams.Spec = prover.MINIMAL_SPEC
+
+	err := s.source.AddDomain(2, minimalParams, []listener.EventHandler{})
+
+	s.NotNil(err)
+}
+
+func (s *SourceTestSuite) Test_AddDomain_DuplicateDomain() {
+	err := s.source.AddDomain(2, params, []listener.EventHandler{})
+	s.Nil(err)
+
+	err = s.source.AddDomain(2, params, []listener.EventHandler{})
+
+	s.NotNil(err)
+}
+
+func (s *SourceTestSuite) Test_ListenerDomain_LowestDomain() {
+	s.Nil(s.source.AddDomain(3, params, []listener.EventHandler{}))
+	s.Nil(s.source.AddDomain(1, params, []listener.EventHandler{}))
+
+	s.Equal(s.source.ListenerDomain(), uint8(1))
+	s.Equal(s.source.Domains(), []uint8{1, 3})
+}
+
+func (s *SourceTestSuite) Test_Handlers_SingleDomain_KeepNames() {
+	handler := &stepHandler{prover: s.source.Prover}
+	s.Nil(s.source.AddDomain(1, params, []listener.EventHandler{handler}))
+
+	eventHandlers := s.source.Handlers()
+
+	s.Equal(len(eventHandlers), 1)
+	s.Equal(listener.HandlerName(eventHandlers[0]), "source_test.stepHandler")
+}
+
+func (s *SourceTestSuite) Test_Handlers_SharedSource_FanOutSameArgs() {
+	s.mockProver.EXPECT().StepArgs().Return(&prover.StepArgs{
+		Update: &consensus.LightClientFinalityUpdateDeneb{
+			FinalizedHeader: &consensus.LightClientHeaderDeneb{
+				Header: &consensus.BeaconBlockHeader{Slot: 100},
+			},
+		},
+	}, nil)
+	first := &stepHandler{prover: s.source.Prover}
+	second := &gapHandler{stepHandler: stepHandler{prover: s.source.Prover}}
+	s.Nil(s.source.AddDomain(2, params, []listener.EventHandler{second}))
+	s.Nil(s.source.AddDomain(1, params, []listener.EventHandler{first}))
+
+	eventHandlers := s.source.Handlers()
+	s.Equal(len(eventHandlers), 2)
+	s.Equal(listener.HandlerName(eventHandlers[0]), "source_test.stepHandler:1")
+	s.Equal(listener.HandlerName(eventHandlers[1]), "source_test.gapHandler:2")
+	for _, handler := range eventHandlers {
+		s.Nil(handler.(listener.GapHandler).HandleGap(5, 7))
+		s.Nil(handler.HandleEvents(checkpoint(1)))
+	}
+
+	s.Equal(first.slots, []uint64{100})
+	s.Equal(second.slots, []uint64{100})
+	s.Equal(second.gaps, []phase0.Epoch{5, 7})
+}
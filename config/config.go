@@ -3,7 +3,11 @@
 
 package config
 
-import "github.com/kelseyhightower/envconfig"
+import (
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
 
 const PREFIX = "SPECTRE"
 
@@ -11,6 +15,8 @@ type Config struct {
 	Observability *Observability   `env_config:"observability"`
 	Prover        *Prover          `env_config:"prover"`
 	Store         *Store           `env_config:"store"`
+	Alerts        *Alerts          `env_config:"alerts"`
+	Queue         *Queue           `env_config:"queue"`
 	Domains       map[uint8]string `required:"true"`
 }
 
@@ -21,7 +27,21 @@ type Observability struct {
 }
 
 type Prover struct {
-	URL string `required:"true"`
+	URL      string        `required:"true"`
+	CacheTTL time.Duration `default:"1h" split_words:"true"`
+}
+
+type Alerts struct {
+	JSONWebhook         string `split_words:"true"`
+	SlackWebhook        string `split_words:"true"`
+	PagerdutyWebhook    string `default:"https://events.pagerduty.com/v2/enqueue" split_words:"true"`
+	PagerdutyRoutingKey string `split_words:"true"`
+}
+
+// Queue configures delivery of queued messages to destination domains
+type Queue struct {
+	MaxAttempts   int           `default:"10" split_words:"true"`
+	RetryInterval time.Duration `default:"12s" split_words:"true"`
 }
 
 type Store struct {
@@ -6,6 +6,7 @@ package config_test
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/sygmaprotocol/spectre-node/config"
@@ -46,11 +47,19 @@ func (s *ConfigTestSuite) Test_LoadConfig_DefaultValues() {
 			HealthPort: 9001,
 		},
 		Prover: &config.Prover{
-			URL: "http://prover.com",
+			URL:      "http://prover.com",
+			CacheTTL: time.Hour,
 		},
 		Store: &config.Store{
 			Path: "./lvldbdata",
 		},
+		Alerts: &config.Alerts{
+			PagerdutyWebhook: "https://events.pagerduty.com/v2/enqueue",
+		},
+		Queue: &config.Queue{
+			MaxAttempts:   10,
+			RetryInterval: 12 * time.Second,
+		},
 		Domains: domains,
 	})
 }
@@ -61,6 +70,11 @@ func (s *ConfigTestSuite) Test_LoadEVMConfig_SuccessfulLoad() {
 	os.Setenv("SPECTRE_OBSERVABILITY_HEALTH_PORT", "9003")
 	os.Setenv("SPECTRE_STORE_PATH", "./custom_path")
 	os.Setenv("SPECTRE_PROVER_URL", "http://prover.com")
+	os.Setenv("SPECTRE_PROVER_CACHE_TTL", "30m")
+	os.Setenv("SPECTRE_ALERTS_SLACK_WEBHOOK", "http://slack.com")
+	os.Setenv("SPECTRE_ALERTS_PAGERDUTY_ROUTING_KEY", "key")
+	os.Setenv("SPECTRE_QUEUE_MAX_ATTEMPTS", "5")
+	os.Setenv("SPECTRE_QUEUE_RETRY_INTERVAL", "1m")
 	os.Setenv("SPECTRE_DOMAINS", "1:evm,2:evm")
 
 	c, err := config.LoadConfig()
@@ -76,11 +90,21 @@ func (s *ConfigTestSuite) Test_LoadEVMConfig_SuccessfulLoad() {
 			HealthPort: 9003,
 		},
 		Prover: &config.Prover{
-			URL: "http://prover.com",
+			URL:      "http://prover.com",
+			CacheTTL: time.Minute * 30,
 		},
 		Store: &config.Store{
 			Path: "./custom_path",
 		},
+		Alerts: &config.Alerts{
+			SlackWebhook:        "http://slack.com",
+			PagerdutyWebhook:    "https://events.pagerduty.com/v2/enqueue",
+			PagerdutyRoutingKey: "key",
+		},
+		Queue: &config.Queue{
+			MaxAttempts:   5,
+			RetryInterval: time.Minute,
+		},
 		Domains: domains,
 	})
 }
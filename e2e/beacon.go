@@ -0,0 +1,368 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package e2e
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
+	encoding "github.com/umbracle/go-eth-consensus/http"
+)
+
+var (
+	GENESIS_VALIDATORS_ROOT = phase0.Root{1}
+	DENEB_FORK_VERSION      = phase0.Version{4, 0, 0, 1}
+)
+
+// BeaconNode is a beacon API server serving the fixtures up to the finalized epoch
+type BeaconNode struct {
+	Fixtures
+
+	server *httptest.Server
+
+	finalizedEpoch uint64
+	blockRoots     map[[32]byte]uint64
+	subscribers    map[chan uint64]struct{}
+	lock           sync.RWMutex
+}
+
+func NewBeaconNode() *BeaconNode {
+	b := &BeaconNode{
+		blockRoots:  make(map[[32]byte]uint64),
+		subscribers: make(map[chan uint64]struct{}),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/beacon/genesis", b.genesis)
+	mux.HandleFunc("/eth/v1/config/spec", b.spec)
+	mux.HandleFunc("/eth/v1/config/deposit_contract", b.depositContract)
+	mux.HandleFunc("/eth/v1/config/fork_schedule", b.forkSchedule)
+	mux.HandleFunc("/eth/v1/beacon/states/head/fork", b.fork)
+	mux.HandleFunc("/eth/v1/node/version", b.nodeVersion)
+	mux.HandleFunc("/eth/v1/beacon/states/finalized/finality_checkpoints", b.finality)
+	mux.HandleFunc("/eth/v1/beacon/blocks/", b.blockRoot)
+	mux.HandleFunc("/eth/v2/beacon/blocks/", b.signedBeaconBlock)
+	mux.HandleFunc("/eth/v1/beacon/light_client/finality_update", b.finalityUpdate)
+	mux.HandleFunc("/eth/v1/beacon/light_client/updates", b.updates)
+	mux.HandleFunc("/eth/v1/beacon/light_client/bootstrap/", b.bootstrap)
+	mux.HandleFunc("/eth/v1/events", b.events)
+	b.server = httptest.NewServer(mux)
+	return b
+}
+
+func (b *BeaconNode) URL() string {
+	return b.server.URL
+}
+
+func (b *BeaconNode) Close() {
+	b.server.Close()
+}
+
+// Finalize moves the finalized checkpoint to the epoch and notifies event stream subscribers
+func (b *BeaconNode) Finalize(epoch uint64) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.finalizedEpoch = epoch
+	for subscriber := range b.subscribers {
+		select {
+		case subscriber <- epoch:
+		default:
+		}
+	}
+}
+
+// Subscribers returns the number of open event streams
+func (b *BeaconNode) Subscribers() int {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return len(b.subscribers)
+}
+
+// FinalizedSlot returns the first slot of the finalized epoch
+func (b *BeaconNode) FinalizedSlot() uint64 {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.finalizedEpoch * SLOTS_PER_EPOCH
+}
+
+func (b *BeaconNode) genesis(w http.ResponseWriter, r *http.Request) {
+	writeData(w, &apiv1.Genesis{
+		GenesisTime:           time.Unix(1700000000, 0),
+		GenesisValidatorsRoot: GENESIS_VALIDATORS_ROOT,
+		GenesisForkVersion:    phase0.Version{0, 0, 0, 1},
+	})
+}
+
+func (b *BeaconNode) spec(w http.ResponseWriter, r *http.Request) {
+	writeData(w, map[string]string{
+		"PRESET_BASE":                      "minimal",
+		"SLOTS_PER_EPOCH":                  fmt.Sprint(SLOTS_PER_EPOCH),
+		"SECONDS_PER_SLOT":                 fmt.Sprint(SECONDS_PER_SLOT),
+		"EPOCHS_PER_SYNC_COMMITTEE_PERIOD": fmt.Sprint(EPOCHS_PER_SYNC_COMMITTEE_PERIOD),
+		"SYNC_COMMITTEE_SIZE":              "32",
+		"DENEB_FORK_VERSION":               fmt.Sprintf("%#x", DENEB_FORK_VERSION),
+		"DENEB_FORK_EPOCH":                 "0",
+		"DOMAIN_SYNC_COMMITTEE":            "0x07000000",
+	})
+}
+
+func (b *BeaconNode) depositContract(w http.ResponseWriter, r *http.Request) {
+	writeData(w, &apiv1.DepositContract{
+		ChainID: 1337,
+		Address: make([]byte, 20),
+	})
+}
+
+func (b *BeaconNode) forkSchedule(w http.ResponseWriter, r *http.Request) {
+	writeData(w, []*phase0.Fork{
+		{
+			PreviousVersion: DENEB_FORK_VERSION,
+			CurrentVersion:  DENEB_FORK_VERSION,
+			Epoch:           0,
+		},
+	})
+}
+
+func (b *BeaconNode) fork(w http.ResponseWriter, r *http.Request) {
+	writeData(w, &phase0.Fork{
+		PreviousVersion: DENEB_FORK_VERSION,
+		CurrentVersion:  DENEB_FORK_VERSION,
+		Epoch:           0,
+	})
+}
+
+func (b *BeaconNode) nodeVersion(w http.ResponseWriter, r *http.Request) {
+	writeData(w, map[string]string{
+		"version": "e2e/minimal",
+	})
+}
+
+func (b *BeaconNode) finality(w http.ResponseWriter, r *http.Request) {
+	slot := b.FinalizedSlot()
+	root, err := b.root(slot)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	epoch := phase0.Epoch(slot / SLOTS_PER_EPOCH)
+	writeData(w, &apiv1.Finality{
+		Finalized:         &phase0.Checkpoint{Epoch: epoch, Root: root},
+		Justified:         &phase0.Checkpoint{Epoch: epoch + 1, Root: phase0.Root{}},
+		PreviousJustified: &phase0.Checkpoint{Epoch: epoch, Root: root},
+	})
+}
+
+func (b *BeaconNode) blockRoot(w http.ResponseWriter, r *http.Request) {
+	block := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/eth/v1/beacon/blocks/"), "/root")
+	slot, ok := b.slot(w, block)
+	if !ok {
+		return
+	}
+
+	root, err := b.root(slot)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeData(w, map[string]string{
+		"root": fmt.Sprintf("%#x", root),
+	})
+}
+
+func (b *BeaconNode) signedBeaconBlock(w http.ResponseWriter, r *http.Request) {
+	slot, ok := b.slot(w, strings.TrimPrefix(r.URL.Path, "/eth/v2/beacon/blocks/"))
+	if !ok {
+		return
+	}
+
+	header := b.Header(slot)
+	block := &deneb.SignedBeaconBlock{
+		Message: &deneb.BeaconBlock{
+			Slot:          phase0.Slot(slot),
+			ProposerIndex: phase0.ValidatorIndex(header.Header.ProposerIndex),
+			ParentRoot:    phase0.Root(header.Header.ParentRoot),
+			StateRoot:     phase0.Root(header.Header.StateRoot),
+			Body: &deneb.BeaconBlockBody{
+				ETH1Data: &phase0.ETH1Data{
+					BlockHash: make([]byte, 32),
+				},
+				SyncAggregate: &altair.SyncAggregate{
+					SyncCommitteeBits: make([]byte, 64),
+				},
+				ExecutionPayload: &deneb.ExecutionPayload{
+					ParentHash:    header.Execution.ParentHash,
+					StateRoot:     header.Execution.StateRoot,
+					ReceiptsRoot:  header.Execution.ReceiptsRoot,
+					PrevRandao:    header.Execution.PrevRandao,
+					BlockNumber:   header.Execution.BlockNumber,
+					GasLimit:      header.Execution.GasLimit,
+					Timestamp:     header.Execution.Timestamp,
+					BaseFeePerGas: uint256.NewInt(0),
+					BlockHash:     header.Execution.BlockHash,
+				},
+			},
+		},
+	}
+	data, err := block.MarshalSSZ()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Eth-Consensus-Version", "deneb")
+	_, _ = w.Write(data)
+}
+
+func (b *BeaconNode) finalityUpdate(w http.ResponseWriter, r *http.Request) {
+	writeLightClientData(w, b.FinalityUpdate(b.FinalizedSlot()))
+}
+
+func (b *BeaconNode) updates(w http.ResponseWriter, r *http.Request) {
+	period, err := strconv.ParseUint(r.URL.Query().Get("start_period"), 10, 64)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if period > b.FinalizedSlot()/SLOTS_PER_PERIOD {
+		writeJSON(w, []interface{}{})
+		return
+	}
+
+	update, err := encoding.Marshal(b.Update(period))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, []map[string]json.RawMessage{
+		{
+			"version": json.RawMessage(`"deneb"`),
+			"data":    update,
+		},
+	})
+}
+
+func (b *BeaconNode) bootstrap(w http.ResponseWriter, r *http.Request) {
+	rootHex := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/eth/v1/beacon/light_client/bootstrap/"), "0x")
+	rootBytes, err := hex.DecodeString(rootHex)
+	if err != nil || len(rootBytes) != 32 {
+		http.Error(w, "invalid block root", http.StatusBadRequest)
+		return
+	}
+
+	var root [32]byte
+	copy(root[:], rootBytes)
+	b.lock.RLock()
+	slot, ok := b.blockRoots[root]
+	b.lock.RUnlock()
+	if !ok {
+		http.Error(w, "unknown block root", http.StatusNotFound)
+		return
+	}
+	writeLightClientData(w, b.Bootstrap(slot))
+}
+
+func (b *BeaconNode) events(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	subscriber := make(chan uint64, 1)
+	b.lock.Lock()
+	b.subscribers[subscriber] = struct{}{}
+	b.lock.Unlock()
+	defer func() {
+		b.lock.Lock()
+		delete(b.subscribers, subscriber)
+		b.lock.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case epoch := <-subscriber:
+			root, err := b.root(epoch * SLOTS_PER_EPOCH)
+			if err != nil {
+				return
+			}
+			_, _ = fmt.Fprintf(w, "event: finalized_checkpoint\ndata: {\"block\":\"%#x\",\"epoch\":\"%d\"}\n\n", root, epoch)
+			flusher.Flush()
+		}
+	}
+}
+
+// root returns the block root of the slot and remembers it for bootstrap requests
+func (b *BeaconNode) root(slot uint64) (phase0.Root, error) {
+	root, err := b.BlockRoot(slot)
+	if err != nil {
+		return phase0.Root{}, err
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.blockRoots[root] = slot
+	return root, nil
+}
+
+// slot parses the block ID and rejects blocks after the finalized slot
+func (b *BeaconNode) slot(w http.ResponseWriter, block string) (uint64, bool) {
+	slot, err := strconv.ParseUint(block, 10, 64)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("unsupported block ID %s", block), http.StatusBadRequest)
+		return 0, false
+	}
+	if slot > b.FinalizedSlot()+2*SLOTS_PER_EPOCH {
+		http.Error(w, fmt.Sprintf("block %d not found", slot), http.StatusNotFound)
+		return 0, false
+	}
+	return slot, true
+}
+
+func writeLightClientData(w http.ResponseWriter, data interface{}) {
+	encoded, err := encoding.Marshal(data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, map[string]json.RawMessage{
+		"version": json.RawMessage(`"deneb"`),
+		"data":    encoded,
+	})
+}
+
+func writeData(w http.ResponseWriter, data interface{}) {
+	writeJSON(w, map[string]interface{}{
+		"data": data,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,259 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package e2e
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	ethereumABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// SPECTRE_STUB_BYTECODE deploys a SpectreProxy stub that accepts any call,
+// emits its calldata as an anonymous log and returns the word 1 so view
+// calls resolve to a non-zero address
+const SPECTRE_STUB_BYTECODE = "0x6014600c60003960146000f3366000600037366000a0600160005260206000f3"
+
+// SimulatedChain is an in-memory EVM chain served over JSON-RPC that mines
+// a block for every transaction it receives
+type SimulatedChain struct {
+	backend *backends.SimulatedBackend
+	key     *ecdsa.PrivateKey
+	server  *httptest.Server
+
+	// the simulated backend reads the pending block without locking
+	lock sync.Mutex
+}
+
+func NewSimulatedChain() (*SimulatedChain, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &SimulatedChain{
+		backend: backends.NewSimulatedBackend(core.GenesisAlloc{
+			crypto.PubkeyToAddress(key.PublicKey): {Balance: new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))},
+		}, 30000000),
+		key: key,
+	}
+	server := rpc.NewServer()
+	err = server.RegisterName("eth", &ethAPI{chain: c})
+	if err != nil {
+		return nil, err
+	}
+	c.server = httptest.NewServer(server)
+	return c, nil
+}
+
+// Endpoint returns the JSON-RPC endpoint of the chain
+func (c *SimulatedChain) Endpoint() string {
+	return c.server.URL
+}
+
+// Key returns the hex encoded key of the funded account
+func (c *SimulatedChain) Key() string {
+	return hex.EncodeToString(crypto.FromECDSA(c.key))
+}
+
+func (c *SimulatedChain) Close() error {
+	c.server.Close()
+	return c.backend.Close()
+}
+
+// DeploySpectreStub deploys the SpectreProxy stub and returns its address
+func (c *SimulatedChain) DeploySpectreStub() (common.Address, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	opts, err := bind.NewKeyedTransactorWithChainID(c.key, c.backend.Blockchain().Config().ChainID)
+	if err != nil {
+		return common.Address{}, err
+	}
+	address, _, _, err := bind.DeployContract(opts, ethereumABI.ABI{}, hexutil.MustDecode(SPECTRE_STUB_BYTECODE), c.backend)
+	if err != nil {
+		return common.Address{}, err
+	}
+	c.backend.Commit()
+	return address, nil
+}
+
+// Calls returns the calldata of all calls made to the stub in the order they were executed
+func (c *SimulatedChain) Calls(stub common.Address) ([][]byte, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	logs, err := c.backend.FilterLogs(context.Background(), ethereum.FilterQuery{
+		FromBlock: big.NewInt(0),
+		Addresses: []common.Address{stub},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	calls := make([][]byte, len(logs))
+	for i, log := range logs {
+		calls[i] = log.Data
+	}
+	return calls, nil
+}
+
+// ethAPI serves the eth namespace methods used by the relayer from the simulated backend
+type ethAPI struct {
+	chain *SimulatedChain
+}
+
+type callArgs struct {
+	From  common.Address  `json:"from"`
+	To    *common.Address `json:"to"`
+	Data  hexutil.Bytes   `json:"data"`
+	Input hexutil.Bytes   `json:"input"`
+}
+
+type filterArgs struct {
+	FromBlock *hexutil.Big     `json:"fromBlock"`
+	ToBlock   *hexutil.Big     `json:"toBlock"`
+	Address   []common.Address `json:"address"`
+	Topics    [][]common.Hash  `json:"topics"`
+}
+
+func (api *ethAPI) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(api.chain.backend.Blockchain().Config().ChainID)
+}
+
+func (api *ethAPI) BlockNumber() hexutil.Uint64 {
+	api.chain.lock.Lock()
+	defer api.chain.lock.Unlock()
+	return hexutil.Uint64(api.chain.backend.Blockchain().CurrentBlock().Number.Uint64())
+}
+
+// GetBlockByNumber returns the header of the block as transactions are never requested
+func (api *ethAPI) GetBlockByNumber(ctx context.Context, number rpc.BlockNumber, fullTx bool) (*types.Header, error) {
+	api.chain.lock.Lock()
+	defer api.chain.lock.Unlock()
+	return api.chain.backend.HeaderByNumber(ctx, blockNumber(number))
+}
+
+func (api *ethAPI) GasPrice(ctx context.Context) (*hexutil.Big, error) {
+	api.chain.lock.Lock()
+	defer api.chain.lock.Unlock()
+	price, err := api.chain.backend.SuggestGasPrice(ctx)
+	return (*hexutil.Big)(price), err
+}
+
+func (api *ethAPI) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	api.chain.lock.Lock()
+	defer api.chain.lock.Unlock()
+	tip, err := api.chain.backend.SuggestGasTipCap(ctx)
+	return (*hexutil.Big)(tip), err
+}
+
+func (api *ethAPI) GetTransactionCount(ctx context.Context, address common.Address, number rpc.BlockNumber) (hexutil.Uint64, error) {
+	api.chain.lock.Lock()
+	defer api.chain.lock.Unlock()
+	var nonce uint64
+	var err error
+	if number == rpc.PendingBlockNumber {
+		nonce, err = api.chain.backend.PendingNonceAt(ctx, address)
+	} else {
+		nonce, err = api.chain.backend.NonceAt(ctx, address, blockNumber(number))
+	}
+	return hexutil.Uint64(nonce), err
+}
+
+func (api *ethAPI) GetCode(ctx context.Context, address common.Address, number rpc.BlockNumber) (hexutil.Bytes, error) {
+	api.chain.lock.Lock()
+	defer api.chain.lock.Unlock()
+	return api.chain.backend.CodeAt(ctx, address, blockNumber(number))
+}
+
+func (api *ethAPI) Call(ctx context.Context, args callArgs, number rpc.BlockNumber) (hexutil.Bytes, error) {
+	data := args.Input
+	if data == nil {
+		data = args.Data
+	}
+
+	api.chain.lock.Lock()
+	defer api.chain.lock.Unlock()
+	return api.chain.backend.CallContract(ctx, ethereum.CallMsg{
+		From: args.From,
+		To:   args.To,
+		Data: data,
+	}, blockNumber(number))
+}
+
+// SendRawTransaction sends the transaction and mines it in a new block
+func (api *ethAPI) SendRawTransaction(ctx context.Context, input hexutil.Bytes) (common.Hash, error) {
+	tx := &types.Transaction{}
+	err := tx.UnmarshalBinary(input)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	api.chain.lock.Lock()
+	defer api.chain.lock.Unlock()
+	err = api.chain.backend.SendTransaction(ctx, tx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	api.chain.backend.Commit()
+	return tx.Hash(), nil
+}
+
+func (api *ethAPI) GetTransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, error) {
+	api.chain.lock.Lock()
+	defer api.chain.lock.Unlock()
+	tx, _, err := api.chain.backend.TransactionByHash(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, nil
+	}
+	return tx, err
+}
+
+func (api *ethAPI) GetTransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	api.chain.lock.Lock()
+	defer api.chain.lock.Unlock()
+	receipt, err := api.chain.backend.TransactionReceipt(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, nil
+	}
+	return receipt, err
+}
+
+func (api *ethAPI) GetLogs(ctx context.Context, args filterArgs) ([]types.Log, error) {
+	api.chain.lock.Lock()
+	defer api.chain.lock.Unlock()
+	logs, err := api.chain.backend.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: (*big.Int)(args.FromBlock),
+		ToBlock:   (*big.Int)(args.ToBlock),
+		Addresses: args.Address,
+		Topics:    args.Topics,
+	})
+	if logs == nil {
+		logs = []types.Log{}
+	}
+	return logs, err
+}
+
+// blockNumber converts the block number argument to the backend argument which is nil for the latest block
+func blockNumber(number rpc.BlockNumber) *big.Int {
+	if number < 0 {
+		return nil
+	}
+	return big.NewInt(number.Int64())
+}
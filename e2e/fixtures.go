@@ -0,0 +1,143 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package e2e
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	consensus "github.com/umbracle/go-eth-consensus"
+)
+
+// Minimal preset parameters used by the fixtures
+const (
+	SLOTS_PER_EPOCH                  = 8
+	EPOCHS_PER_SYNC_COMMITTEE_PERIOD = 8
+	SECONDS_PER_SLOT                 = 6
+	SYNC_COMMITTEE_SIZE              = 32
+	SLOTS_PER_PERIOD                 = SLOTS_PER_EPOCH * EPOCHS_PER_SYNC_COMMITTEE_PERIOD
+)
+
+// Fixtures generates deterministic Deneb light client data for the minimal preset
+// so expected calldata can be recomputed by the tests
+type Fixtures struct{}
+
+// Header returns the light client header of the block at the slot
+func (f *Fixtures) Header(slot uint64) *consensus.LightClientHeaderDeneb {
+	return &consensus.LightClientHeaderDeneb{
+		Header: &consensus.BeaconBlockHeader{
+			Slot:          slot,
+			ProposerIndex: slot % 64,
+			ParentRoot:    fixtureRoot("parent_root", slot),
+			StateRoot:     fixtureRoot("state_root", slot),
+			BodyRoot:      fixtureRoot("body_root", slot),
+		},
+		Execution: &consensus.ExecutionPayloadHeaderDeneb{
+			ParentHash:       fixtureRoot("parent_hash", slot),
+			StateRoot:        fixtureRoot("execution_state_root", slot),
+			ReceiptsRoot:     fixtureRoot("receipts_root", slot),
+			PrevRandao:       fixtureRoot("prev_randao", slot),
+			BlockNumber:      slot,
+			GasLimit:         30000000,
+			Timestamp:        slot * SECONDS_PER_SLOT,
+			BlockHash:        fixtureRoot("block_hash", slot),
+			TransactionsRoot: fixtureRoot("transactions_root", slot),
+			WithdrawalRoot:   fixtureRoot("withdrawals_root", slot),
+		},
+		ExecutionBranch: [4][32]byte{
+			fixtureRoot("execution_branch_0", slot),
+			fixtureRoot("execution_branch_1", slot),
+			fixtureRoot("execution_branch_2", slot),
+			fixtureRoot("execution_branch_3", slot),
+		},
+	}
+}
+
+// BlockRoot returns the hash tree root of the beacon block header at the slot
+func (f *Fixtures) BlockRoot(slot uint64) ([32]byte, error) {
+	return f.Header(slot).Header.HashTreeRoot()
+}
+
+// FinalityUpdate returns the light client finality update for the finalized slot
+func (f *Fixtures) FinalityUpdate(finalizedSlot uint64) *consensus.LightClientFinalityUpdateDeneb {
+	attestedSlot := finalizedSlot + 2*SLOTS_PER_EPOCH
+	return &consensus.LightClientFinalityUpdateDeneb{
+		AttestedHeader:  f.Header(attestedSlot),
+		FinalizedHeader: f.Header(finalizedSlot),
+		FinalityBranch:  fixtureBranch("finality_branch", finalizedSlot, 6),
+		SyncAggregate:   f.syncAggregate(attestedSlot),
+		SignatureSlot:   attestedSlot + 1,
+	}
+}
+
+// Update returns the light client update for the sync committee period
+func (f *Fixtures) Update(period uint64) *consensus.LightClientUpdateDeneb {
+	finalizedSlot := period*SLOTS_PER_PERIOD + SLOTS_PER_EPOCH
+	attestedSlot := finalizedSlot + 2*SLOTS_PER_EPOCH
+	return &consensus.LightClientUpdateDeneb{
+		AttestedHeader:          f.Header(attestedSlot),
+		NextSyncCommittee:       f.SyncCommittee(period + 1),
+		NextSyncCommitteeBranch: fixtureBranch("next_sync_committee_branch", finalizedSlot, 5),
+		FinalizedHeader:         f.Header(finalizedSlot),
+		FinalityBranch:          fixtureBranch("finality_branch", finalizedSlot, 6),
+		SyncAggregate:           f.syncAggregate(attestedSlot),
+		SignatureSlot:           attestedSlot + 1,
+	}
+}
+
+// Bootstrap returns the light client bootstrap for the block at the slot
+func (f *Fixtures) Bootstrap(slot uint64) *consensus.LightClientBootstrapDeneb {
+	return &consensus.LightClientBootstrapDeneb{
+		Header:                     f.Header(slot),
+		CurrentSyncCommittee:       f.SyncCommittee(slot / SLOTS_PER_PERIOD),
+		CurrentSyncCommitteeBranch: fixtureBranch("current_sync_committee_branch", slot, 5),
+	}
+}
+
+// SyncCommittee returns the sync committee of the period padded
+// to the mainnet sized consensus type
+func (f *Fixtures) SyncCommittee(period uint64) *consensus.SyncCommittee {
+	committee := &consensus.SyncCommittee{}
+	for i := 0; i < SYNC_COMMITTEE_SIZE; i++ {
+		copy(committee.PubKeys[i][:], fixtureBytes("pubkey", period, uint64(i), 48))
+	}
+	copy(committee.AggregatePubKey[:], fixtureBytes("aggregate_pubkey", period, 0, 48))
+	return committee
+}
+
+func (f *Fixtures) syncAggregate(slot uint64) *consensus.SyncAggregate {
+	aggregate := &consensus.SyncAggregate{}
+	for i := 0; i < SYNC_COMMITTEE_SIZE/8; i++ {
+		aggregate.SyncCommiteeBits[i] = 0xff
+	}
+	copy(aggregate.SyncCommiteeSignature[:], fixtureBytes("sync_committee_signature", slot, 0, 96))
+	return aggregate
+}
+
+func fixtureBranch(label string, slot uint64, depth int) [][32]byte {
+	branch := make([][32]byte, depth)
+	for i := range branch {
+		copy(branch[i][:], fixtureBytes(label, slot, uint64(i), 32))
+	}
+	return branch
+}
+
+func fixtureRoot(label string, slot uint64) [32]byte {
+	var root [32]byte
+	copy(root[:], fixtureBytes(label, slot, 0, 32))
+	return root
+}
+
+func fixtureBytes(label string, slot uint64, index uint64, length int) []byte {
+	out := make([]byte, 0, length)
+	for counter := uint64(0); len(out) < length; counter++ {
+		seed := make([]byte, 24)
+		binary.BigEndian.PutUint64(seed[0:8], slot)
+		binary.BigEndian.PutUint64(seed[8:16], index)
+		binary.BigEndian.PutUint64(seed[16:24], counter)
+		digest := sha256.Sum256(append([]byte(label), seed...))
+		out = append(out, digest[:]...)
+	}
+	return out[:length]
+}
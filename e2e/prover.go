@@ -0,0 +1,91 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package e2e
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/sygmaprotocol/spectre-node/chains/evm/prover"
+)
+
+// ProverRequest is a proof request received by the prover and the proof
+// it responded with
+type ProverRequest struct {
+	Method string
+	Params json.RawMessage
+	Proof  []byte
+}
+
+// ProverNode is a JSON-RPC server responding to proof requests with
+// proofs derived from the request params
+type ProverNode struct {
+	server *httptest.Server
+
+	requests []*ProverRequest
+	lock     sync.Mutex
+}
+
+func NewProverNode() *ProverNode {
+	p := &ProverNode{
+		requests: make([]*ProverRequest, 0),
+	}
+	p.server = httptest.NewServer(http.HandlerFunc(p.handle))
+	return p
+}
+
+func (p *ProverNode) URL() string {
+	return p.server.URL
+}
+
+func (p *ProverNode) Close() {
+	p.server.Close()
+}
+
+// Requests returns the requests received for the method in the order they were received
+func (p *ProverNode) Requests(method string) []*ProverRequest {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	requests := make([]*ProverRequest, 0)
+	for _, request := range p.requests {
+		if request.Method == method {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
+
+func (p *ProverNode) handle(w http.ResponseWriter, r *http.Request) {
+	var rpcRequest struct {
+		ID     interface{}     `json:"id"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&rpcRequest); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	proof := sha256.Sum256(append([]byte(rpcRequest.Method), rpcRequest.Params...))
+	p.lock.Lock()
+	p.requests = append(p.requests, &ProverRequest{
+		Method: rpcRequest.Method,
+		Params: rpcRequest.Params,
+		Proof:  proof[:],
+	})
+	p.lock.Unlock()
+
+	writeJSON(w, map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      rpcRequest.ID,
+		"result": prover.ProverResponse{
+			Proof:      prover.ByteArrayToU16Array(proof[:]),
+			Commitment: "0x0",
+		},
+	})
+}
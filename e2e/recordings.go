@@ -0,0 +1,124 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package e2e
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/sygmaprotocol/spectre-node/chains/evm/beacon"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/lightclient"
+	consensus "github.com/umbracle/go-eth-consensus"
+)
+
+const (
+	FINALITY_UPDATE_PATH = "/eth/v1/beacon/light_client/finality_update"
+	UPDATES_PATH         = "/eth/v1/beacon/light_client/updates"
+)
+
+// Recordings are the light client updates of beacon API responses recorded from a
+// minimal preset devnet, decoded by the light client the same way the node decodes them
+type Recordings struct {
+	FinalityUpdates []*consensus.LightClientFinalityUpdateDeneb
+	// Updates are the updates served for each sync committee period in the order they were recorded
+	Updates map[uint64][]*consensus.LightClientUpdateDeneb
+}
+
+// RecordingFiles returns the recording files in the directory
+func RecordingFiles(path string) ([]string, error) {
+	return filepath.Glob(filepath.Join(path, "*.json"))
+}
+
+// LoadRecordings decodes the light client updates of the recordings in the directory
+// by replaying every recorded light client response through the light client
+func LoadRecordings(path string) (*Recordings, error) {
+	files, err := RecordingFiles(path)
+	if err != nil {
+		return nil, err
+	}
+
+	finalityUpdates := 0
+	periods := make([]uint64, 0)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		recording := &beacon.Recording{}
+		err = json.Unmarshal(data, recording)
+		if err != nil {
+			return nil, err
+		}
+		if recording.StatusCode != http.StatusOK {
+			continue
+		}
+
+		requestURL, err := url.Parse(recording.Path)
+		if err != nil {
+			return nil, err
+		}
+		switch requestURL.Path {
+		case FINALITY_UPDATE_PATH:
+			finalityUpdates++
+		case UPDATES_PATH:
+			period, err := strconv.ParseUint(requestURL.Query().Get("start_period"), 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			periods = append(periods, period)
+		}
+	}
+
+	replayer, err := beacon.NewReplayer(path)
+	if err != nil {
+		return nil, err
+	}
+	server := httptest.NewServer(replayer)
+	defer server.Close()
+	lightClient := lightclient.NewLightClient(server.URL)
+
+	recordings := &Recordings{
+		FinalityUpdates: make([]*consensus.LightClientFinalityUpdateDeneb, finalityUpdates),
+		Updates:         make(map[uint64][]*consensus.LightClientUpdateDeneb),
+	}
+	for i := range recordings.FinalityUpdates {
+		recordings.FinalityUpdates[i], err = lightClient.FinalityUpdate()
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, period := range periods {
+		updates, err := lightClient.Updates(period)
+		if err != nil {
+			return nil, err
+		}
+		recordings.Updates[period] = append(recordings.Updates[period], updates...)
+	}
+	return recordings, nil
+}
+
+// FinalityUpdate returns the recorded finality update of the finalized slot
+func (r *Recordings) FinalityUpdate(finalizedSlot uint64) *consensus.LightClientFinalityUpdateDeneb {
+	for _, update := range r.FinalityUpdates {
+		if update.FinalizedHeader.Header.Slot == finalizedSlot {
+			return update
+		}
+	}
+	return nil
+}
+
+// Update returns the recorded update of the period with the finalized slot
+func (r *Recordings) Update(period uint64, finalizedSlot uint64) *consensus.LightClientUpdateDeneb {
+	for _, update := range r.Updates[period] {
+		if update.FinalizedHeader.Header.Slot == finalizedSlot {
+			return update
+		}
+	}
+	return nil
+}
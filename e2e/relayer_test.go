@@ -0,0 +1,278 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package e2e_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	ethereumABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/alert"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/abi"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/listener/handlers"
+	evmMessage "github.com/sygmaprotocol/spectre-node/chains/evm/message"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/prover"
+	"github.com/sygmaprotocol/spectre-node/config"
+	"github.com/sygmaprotocol/spectre-node/e2e"
+	"github.com/sygmaprotocol/spectre-node/metrics"
+	"github.com/sygmaprotocol/spectre-node/node"
+	"github.com/sygmaprotocol/sygma-core/store/lvldb"
+	consensus "github.com/umbracle/go-eth-consensus"
+)
+
+const (
+	SOURCE_DOMAIN_ID      = 1
+	DESTINATION_DOMAIN_ID = 2
+	STEP_METHOD           = "genEvmProof_SyncStepCompressed"
+	ROTATE_METHOD         = "genEvmProof_CommitteeUpdateCompressed"
+	// RECORDINGS_PATH holds beacon API responses of a minimal preset devnet recorded
+	// with SPECTRE_DOMAINS_<ID>_BEACON_RECORD_PATH while the node stepped and rotated
+	// across a sync committee period boundary
+	RECORDINGS_PATH = "testdata/minimal"
+)
+
+type RelayerTestSuite struct {
+	suite.Suite
+
+	beacon      *e2e.BeaconNode
+	proverNode  *e2e.ProverNode
+	destination *e2e.SimulatedChain
+	spectre     common.Address
+	spectreABI  ethereumABI.ABI
+
+	cancel context.CancelFunc
+}
+
+func TestRunRelayerTestSuite(t *testing.T) {
+	suite.Run(t, new(RelayerTestSuite))
+}
+
+func (s *RelayerTestSuite) SetupTest() {
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
+
+	var err error
+	s.spectreABI, err = ethereumABI.JSON(strings.NewReader(abi.SpectreABI))
+	s.Nil(err)
+	s.beacon = e2e.NewBeaconNode()
+	s.proverNode = e2e.NewProverNode()
+	s.destination, err = e2e.NewSimulatedChain()
+	s.Nil(err)
+	s.spectre, err = s.destination.DeploySpectreStub()
+	s.Nil(err)
+}
+
+func (s *RelayerTestSuite) TearDownTest() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.beacon.Close()
+	s.proverNode.Close()
+	_ = s.destination.Close()
+}
+
+// startRelayer starts the node with the source domain following the beacon node
+// and the destination domain submitting to the spectre stub. The environment
+// overrides the default configuration of the test.
+func (s *RelayerTestSuite) startRelayer(overrides map[string]string) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	env := map[string]string{
+		"SPECTRE_DOMAINS":                   fmt.Sprintf("%d:evm,%d:evm", SOURCE_DOMAIN_ID, DESTINATION_DOMAIN_ID),
+		"SPECTRE_PROVER_URL":                s.proverNode.URL(),
+		"SPECTRE_OBSERVABILITY_LOG_LEVEL":   "warn",
+		"SPECTRE_QUEUE_RETRY_INTERVAL":      "100ms",
+		"SPECTRE_DOMAINS_1_ENDPOINT":        s.destination.Endpoint(),
+		"SPECTRE_DOMAINS_1_KEY":             s.destination.Key(),
+		"SPECTRE_DOMAINS_1_BEACON_ENDPOINT": s.beacon.URL(),
+		"SPECTRE_DOMAINS_1_TARGET_DOMAINS":  fmt.Sprint(DESTINATION_DOMAIN_ID),
+		"SPECTRE_DOMAINS_1_STARTING_PERIOD": "0",
+		"SPECTRE_DOMAINS_1_RETRY_INTERVAL":  "1",
+		// finality is only polled at startup, later checkpoints are handled from the event stream
+		"SPECTRE_DOMAINS_1_EVENT_POLL_INTERVAL": "3600",
+		"SPECTRE_DOMAINS_2_ENDPOINT":            s.destination.Endpoint(),
+		"SPECTRE_DOMAINS_2_KEY":                 s.destination.Key(),
+		"SPECTRE_DOMAINS_2_SPECTRE":             s.spectre.Hex(),
+		"SPECTRE_DOMAINS_2_STARTING_PERIOD":     "0",
+		"SPECTRE_DOMAINS_2_RETRY_INTERVAL":      "1",
+		"SPECTRE_DOMAINS_2_MONITOR_INTERVAL":    "3600",
+	}
+	for key, value := range overrides {
+		env[key] = value
+	}
+	for key, value := range env {
+		s.T().Setenv(key, value)
+	}
+	cfg, err := config.LoadConfig()
+	s.Nil(err)
+
+	db, err := lvldb.NewLvlDB(s.T().TempDir())
+	s.Nil(err)
+	err = node.Start(ctx, cfg, db, metrics.NewMetrics(), alert.NewAlerter([]alert.Sender{}), http.NewServeMux().Handle)
+	s.Nil(err)
+}
+
+func (s *RelayerTestSuite) Test_StepAndRotate_AcrossPeriodBoundary() {
+	s.beacon.Finalize(e2e.EPOCHS_PER_SYNC_COMMITTEE_PERIOD - 2)
+	s.startRelayer(nil)
+
+	calls := s.waitForCalls(1)
+	stepSlot := s.beacon.FinalizedSlot()
+	stepArgs := s.unpackCall(calls[0], "step")
+	s.assertStepInput(stepArgs[1], s.beacon.FinalityUpdate(stepSlot).AttestedHeader.Header.Slot, s.beacon.Header(stepSlot), e2e.SYNC_COMMITTEE_SIZE)
+	s.Equal(stepArgs[0], uint8(SOURCE_DOMAIN_ID))
+	s.Equal(stepArgs[2], s.proverNode.Requests(STEP_METHOD)[0].Proof)
+	stateRoot := stepArgs[3].([32]byte)
+	s.Equal(stateRoot, s.beacon.Header(stepSlot).Execution.StateRoot)
+	executionRoot, err := s.beacon.Header(stepSlot).Execution.HashTreeRoot()
+	s.Nil(err)
+	s.True(verifyBranch(stateRoot, stepArgs[4].([][]byte), handlers.EXECUTION_STATE_ROOT_INDEX, executionRoot))
+
+	s.Eventually(func() bool {
+		return s.beacon.Subscribers() > 0
+	}, time.Second*5, time.Millisecond*10)
+	s.beacon.Finalize(e2e.EPOCHS_PER_SYNC_COMMITTEE_PERIOD + 1)
+
+	calls = s.waitForCalls(2)
+	rotateSlot := s.beacon.Update(1).FinalizedHeader.Header.Slot
+	rotateArgs := s.unpackCall(calls[1], "rotate")
+	s.Equal(rotateArgs[0], uint8(SOURCE_DOMAIN_ID))
+	s.Equal(rotateArgs[1], s.proverNode.Requests(ROTATE_METHOD)[0].Proof)
+	// rotate proves the finalized header as the attested header
+	s.assertStepInput(rotateArgs[2], s.beacon.Update(1).AttestedHeader.Header.Slot, s.beacon.Header(rotateSlot), e2e.SYNC_COMMITTEE_SIZE)
+	s.Equal(rotateArgs[3], s.proverNode.Requests(STEP_METHOD)[1].Proof)
+}
+
+// Test_Recorded_StepAndRotate replays recorded devnet responses and checks the
+// submitted calldata against the light client updates of the recordings
+func (s *RelayerTestSuite) Test_Recorded_StepAndRotate() {
+	path, err := filepath.Abs(RECORDINGS_PATH)
+	s.Nil(err)
+	files, err := e2e.RecordingFiles(path)
+	s.Nil(err)
+	if len(files) == 0 {
+		s.T().Skipf("no beacon recordings in %s", RECORDINGS_PATH)
+	}
+	recordings, err := e2e.LoadRecordings(path)
+	s.Nil(err)
+
+	s.startRelayer(map[string]string{
+		"SPECTRE_DOMAINS_1_BEACON_REPLAY_PATH":  path,
+		"SPECTRE_DOMAINS_1_EVENT_POLL_INTERVAL": "1",
+	})
+
+	var calls [][]byte
+	s.Eventually(func() bool {
+		calls, err = s.destination.Calls(s.spectre)
+		s.Nil(err)
+		for _, call := range calls {
+			if string(call[:4]) == string(s.spectreABI.Methods["rotate"].ID) {
+				return true
+			}
+		}
+		return false
+	}, time.Minute, time.Millisecond*100)
+
+	stepProofs := s.proofs(STEP_METHOD)
+	rotateProofs := s.proofs(ROTATE_METHOD)
+	for _, call := range calls {
+		method, err := s.spectreABI.MethodById(call[:4])
+		s.Nil(err)
+		switch method.Name {
+		case "step":
+			args := s.unpackCall(call, "step")
+			s.Equal(args[0], uint8(SOURCE_DOMAIN_ID))
+			s.Contains(stepProofs, string(args[2].([]byte)))
+			input := *ethereumABI.ConvertType(args[1], new(evmMessage.SyncStepInput)).(*evmMessage.SyncStepInput)
+			update := recordings.FinalityUpdate(input.FinalizedSlot)
+			s.NotNil(update, fmt.Sprintf("recorded finality update for slot %d", input.FinalizedSlot))
+			s.assertStepInput(args[1], update.AttestedHeader.Header.Slot, update.FinalizedHeader, prover.Participation(update.SyncAggregate, e2e.SYNC_COMMITTEE_SIZE))
+
+			stateRoot := args[3].([32]byte)
+			s.Equal(stateRoot, update.FinalizedHeader.Execution.StateRoot)
+			executionRoot, err := update.FinalizedHeader.Execution.HashTreeRoot()
+			s.Nil(err)
+			s.True(verifyBranch(stateRoot, args[4].([][]byte), handlers.EXECUTION_STATE_ROOT_INDEX, executionRoot))
+		case "rotate":
+			args := s.unpackCall(call, "rotate")
+			s.Equal(args[0], uint8(SOURCE_DOMAIN_ID))
+			s.Contains(rotateProofs, string(args[1].([]byte)))
+			s.Contains(stepProofs, string(args[3].([]byte)))
+			input := *ethereumABI.ConvertType(args[2], new(evmMessage.SyncStepInput)).(*evmMessage.SyncStepInput)
+			update := recordings.Update(input.FinalizedSlot/e2e.SLOTS_PER_PERIOD, input.FinalizedSlot)
+			s.NotNil(update, fmt.Sprintf("recorded update for slot %d", input.FinalizedSlot))
+			s.assertStepInput(args[2], update.AttestedHeader.Header.Slot, update.FinalizedHeader, prover.Participation(update.SyncAggregate, e2e.SYNC_COMMITTEE_SIZE))
+		default:
+			s.Fail(fmt.Sprintf("unexpected %s call", method.Name))
+		}
+	}
+}
+
+// proofs returns the proofs the prover responded with to requests of the method
+func (s *RelayerTestSuite) proofs(method string) []string {
+	proofs := make([]string, 0)
+	for _, request := range s.proverNode.Requests(method) {
+		proofs = append(proofs, string(request.Proof))
+	}
+	return proofs
+}
+
+func (s *RelayerTestSuite) waitForCalls(count int) [][]byte {
+	var calls [][]byte
+	s.Eventually(func() bool {
+		var err error
+		calls, err = s.destination.Calls(s.spectre)
+		s.Nil(err)
+		return len(calls) >= count
+	}, time.Second*20, time.Millisecond*100)
+	s.Len(calls, count)
+	return calls
+}
+
+func (s *RelayerTestSuite) unpackCall(calldata []byte, method string) []interface{} {
+	s.Equal(calldata[:4], s.spectreABI.Methods[method].ID)
+	args, err := s.spectreABI.Methods[method].Inputs.Unpack(calldata[4:])
+	s.Nil(err)
+	return args
+}
+
+func (s *RelayerTestSuite) assertStepInput(arg interface{}, attestedSlot uint64, header *consensus.LightClientHeaderDeneb, participation uint64) {
+	input := *ethereumABI.ConvertType(arg, new(evmMessage.SyncStepInput)).(*evmMessage.SyncStepInput)
+	finalizedSlot := header.Header.Slot
+	finalizedHeaderRoot, err := header.Header.HashTreeRoot()
+	s.Nil(err)
+	executionRoot, err := header.Execution.HashTreeRoot()
+	s.Nil(err)
+
+	s.Equal(input, evmMessage.SyncStepInput{
+		AttestedSlot:         attestedSlot,
+		FinalizedSlot:        finalizedSlot,
+		Participation:        participation,
+		FinalizedHeaderRoot:  finalizedHeaderRoot,
+		ExecutionPayloadRoot: executionRoot,
+	}, fmt.Sprintf("step input for finalized slot %d", finalizedSlot))
+}
+
+// verifyBranch checks the merkle branch of the leaf at the generalized index against the root
+func verifyBranch(leaf [32]byte, branch [][]byte, index uint64, root [32]byte) bool {
+	node := leaf
+	for _, sibling := range branch {
+		if index%2 == 0 {
+			node = sha256.Sum256(append(node[:], sibling...))
+		} else {
+			node = sha256.Sum256(append(sibling, node[:]...))
+		}
+		index /= 2
+	}
+	return index == 1 && node == root
+}
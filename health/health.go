@@ -19,3 +19,8 @@ func StartHealthEndpoint(port uint16) {
 	_ = http.ListenAndServe(fmt.Sprintf(":%d", port), nil)
 	log.Info().Msgf("started /health endpoint on port %d", port)
 }
+
+// RegisterHandler registers an additional endpoint served on the health port
+func RegisterHandler(pattern string, handler http.Handler) {
+	http.Handle(pattern, handler)
+}
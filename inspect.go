@@ -0,0 +1,157 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	evmConfig "github.com/sygmaprotocol/spectre-node/chains/evm/config"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/contracts"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/listener/handlers"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/preflight"
+	"github.com/sygmaprotocol/spectre-node/config"
+	"github.com/sygmaprotocol/spectre-node/store"
+	"github.com/sygmaprotocol/sygma-core/chains/evm/client"
+	"github.com/sygmaprotocol/sygma-core/crypto/secp256k1"
+	"github.com/sygmaprotocol/sygma-core/store/lvldb"
+)
+
+const INSPECT_COMMAND = "inspect"
+
+// inspect prints the on-chain state of the Spectre proxy of every EVM destination domain.
+// The latest state root of each source domain is read for the latest slot indexed in the store,
+// so the node must be stopped as the store is locked while running.
+func inspect(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet(INSPECT_COMMAND, flag.ExitOnError)
+	destination := flags.Uint("destination", 0, "destination domain to inspect, every EVM domain if not set")
+	roles := flags.String("roles", "", "comma separated role names or hashes the relayer must hold")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	db, err := lvldb.NewLvlDB(cfg.Store.Path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	spectreStore := store.NewSpectreStore(db)
+
+	domains := make([]uint8, 0, len(cfg.Domains))
+	for id, nType := range cfg.Domains {
+		if nType != "evm" {
+			continue
+		}
+		domains = append(domains, id)
+	}
+	sort.Slice(domains, func(i, j int) bool { return domains[i] < domains[j] })
+
+	for _, id := range domains {
+		if *destination != 0 && id != uint8(*destination) {
+			continue
+		}
+
+		err := inspectDomain(os.Stdout, id, domains, spectreStore, parseRoles(*roles))
+		if err != nil {
+			return fmt.Errorf("failed inspecting domain %d: %w", id, err)
+		}
+	}
+	return nil
+}
+
+func inspectDomain(w io.Writer, destination uint8, domains []uint8, spectreStore *store.SpectreStore, roles []string) error {
+	config, err := evmConfig.LoadEVMConfig(destination)
+	if err != nil {
+		return err
+	}
+	if config.Spectre == "" {
+		fmt.Fprintf(w, "Domain %d: no spectre contract configured\n\n", destination)
+		return nil
+	}
+	kp, err := secp256k1.NewKeypairFromString(config.Key)
+	if err != nil {
+		return err
+	}
+	client, err := client.NewEVMClient(config.Endpoint, kp)
+	if err != nil {
+		return err
+	}
+	spectre := contracts.NewSpectreContract(common.HexToAddress(config.Spectre), client, nil)
+
+	fmt.Fprintf(w, "Domain %d: spectre proxy %s\n", destination, config.Spectre)
+	stateRootIndex, err := spectre.StateRootIndex()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "  state root index: %d", stateRootIndex)
+	if stateRootIndex != handlers.EXECUTION_STATE_ROOT_INDEX {
+		fmt.Fprintf(w, " (relayer proves index %d)", handlers.EXECUTION_STATE_ROOT_INDEX)
+	}
+	fmt.Fprintln(w)
+
+	for _, source := range domains {
+		if source == destination {
+			continue
+		}
+
+		address, err := spectre.SpectreContract(source)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "  source %d: spectre %s\n", source, address)
+
+		slot, err := spectreStore.LatestStateRootSlot(destination, source)
+		if err != nil {
+			return err
+		}
+		if slot == 0 {
+			fmt.Fprintf(w, "    latest state root: none indexed\n")
+			continue
+		}
+		stateRoot, err := spectre.StateRoots(source, slot)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "    latest state root: %s at slot %d", common.Hash(stateRoot), slot)
+		indexedRoot, ok, err := spectreStore.StateRoot(destination, source, slot)
+		if err != nil {
+			return err
+		}
+		if ok && indexedRoot != stateRoot {
+			fmt.Fprintf(w, " (indexed %s)", common.Hash(indexedRoot))
+		}
+		fmt.Fprintln(w)
+	}
+
+	relayer := kp.CommonAddress()
+	fmt.Fprintf(w, "  relayer %s\n", relayer)
+	for _, name := range roles {
+		hasRole, err := spectre.HasRole(preflight.RoleID(name), relayer)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "    %s: %t\n", name, hasRole)
+	}
+	fmt.Fprintln(w)
+	return nil
+}
+
+// parseRoles returns the role names to check, the admin role is always checked
+func parseRoles(roles string) []string {
+	names := []string{preflight.DEFAULT_ADMIN_ROLE}
+	for _, role := range strings.Split(roles, ",") {
+		role = strings.TrimSpace(role)
+		if role == "" || role == preflight.DEFAULT_ADMIN_ROLE {
+			continue
+		}
+		names = append(names, role)
+	}
+	return names
+}
@@ -0,0 +1,98 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type gauge struct {
+	name   string
+	labels map[string]string
+	value  float64
+}
+
+// Metrics holds node gauges and serves them in the Prometheus text format
+type Metrics struct {
+	gauges map[string]*gauge
+	lock   sync.RWMutex
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{
+		gauges: make(map[string]*gauge),
+	}
+}
+
+// SetGauge sets the value of the gauge with the given name and labels
+func (m *Metrics) SetGauge(name string, labels map[string]string, value float64) {
+	key := name + formatLabels(labels)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.gauges[key] = &gauge{
+		name:   name,
+		labels: labels,
+		value:  value,
+	}
+}
+
+// Gauge returns the value of the gauge with the given name and labels
+func (m *Metrics) Gauge(name string, labels map[string]string) (float64, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	g, ok := m.gauges[name+formatLabels(labels)]
+	if !ok {
+		return 0, false
+	}
+	return g.value, true
+}
+
+// ServeHTTP writes all gauges in the Prometheus text format
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.lock.RLock()
+	keys := make([]string, 0, len(m.gauges))
+	for key := range m.gauges {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	typed := make(map[string]bool)
+	for _, key := range keys {
+		g := m.gauges[key]
+		if !typed[g.name] {
+			sb.WriteString(fmt.Sprintf("# TYPE %s gauge\n", g.name))
+			typed[g.name] = true
+		}
+		sb.WriteString(fmt.Sprintf("%s %v\n", key, g.value))
+	}
+	m.lock.RUnlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	_, _ = w.Write([]byte(sb.String()))
+}
+
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = fmt.Sprintf("%s=%q", name, labels[name])
+	}
+	return fmt.Sprintf("{%s}", strings.Join(pairs, ","))
+}
@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./api/messages.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./api/messages.go -destination=./mock/api.go -package mock
+//
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	store "github.com/sygmaprotocol/spectre-node/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMessageFetcher is a mock of MessageFetcher interface.
+type MockMessageFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageFetcherMockRecorder
+}
+
+// MockMessageFetcherMockRecorder is the mock recorder for MockMessageFetcher.
+type MockMessageFetcherMockRecorder struct {
+	mock *MockMessageFetcher
+}
+
+// NewMockMessageFetcher creates a new mock instance.
+func NewMockMessageFetcher(ctrl *gomock.Controller) *MockMessageFetcher {
+	mock := &MockMessageFetcher{ctrl: ctrl}
+	mock.recorder = &MockMessageFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageFetcher) EXPECT() *MockMessageFetcherMockRecorder {
+	return m.recorder
+}
+
+// Message mocks base method.
+func (m *MockMessageFetcher) Message(sourceDomainID uint8, messageType store.MessageType, id string) (*store.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Message", sourceDomainID, messageType, id)
+	ret0, _ := ret[0].(*store.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Message indicates an expected call of Message.
+func (mr *MockMessageFetcherMockRecorder) Message(sourceDomainID, messageType, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Message", reflect.TypeOf((*MockMessageFetcher)(nil).Message), sourceDomainID, messageType, id)
+}
+
+// MessagesByTx mocks base method.
+func (m *MockMessageFetcher) MessagesByTx(sourceDomainID uint8, txHash string) ([]*store.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MessagesByTx", sourceDomainID, txHash)
+	ret0, _ := ret[0].([]*store.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MessagesByTx indicates an expected call of MessagesByTx.
+func (mr *MockMessageFetcherMockRecorder) MessagesByTx(sourceDomainID, txHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MessagesByTx", reflect.TypeOf((*MockMessageFetcher)(nil).MessagesByTx), sourceDomainID, txHash)
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/evm/listener/handlers/archive.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./chains/evm/listener/handlers/archive.go -destination=./mock/archive.go -package mock
+//
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	common "github.com/ethereum/go-ethereum/common"
+	store "github.com/sygmaprotocol/spectre-node/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProofStorer is a mock of ProofStorer interface.
+type MockProofStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockProofStorerMockRecorder
+}
+
+// MockProofStorerMockRecorder is the mock recorder for MockProofStorer.
+type MockProofStorerMockRecorder struct {
+	mock *MockProofStorer
+}
+
+// NewMockProofStorer creates a new mock instance.
+func NewMockProofStorer(ctrl *gomock.Controller) *MockProofStorer {
+	mock := &MockProofStorer{ctrl: ctrl}
+	mock.recorder = &MockProofStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProofStorer) EXPECT() *MockProofStorerMockRecorder {
+	return m.recorder
+}
+
+// StoreProof mocks base method.
+func (m *MockProofStorer) StoreProof(record *store.ProofRecord) (common.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreProof", record)
+	ret0, _ := ret[0].(common.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StoreProof indicates an expected call of StoreProof.
+func (mr *MockProofStorerMockRecorder) StoreProof(record any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreProof", reflect.TypeOf((*MockProofStorer)(nil).StoreProof), record)
+}
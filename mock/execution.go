@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/evm/execution/prover.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./chains/evm/execution/prover.go -destination=./mock/execution.go -package mock
+//
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRPCClient is a mock of RPCClient interface.
+type MockRPCClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockRPCClientMockRecorder
+}
+
+// MockRPCClientMockRecorder is the mock recorder for MockRPCClient.
+type MockRPCClientMockRecorder struct {
+	mock *MockRPCClient
+}
+
+// NewMockRPCClient creates a new mock instance.
+func NewMockRPCClient(ctrl *gomock.Controller) *MockRPCClient {
+	mock := &MockRPCClient{ctrl: ctrl}
+	mock.recorder = &MockRPCClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRPCClient) EXPECT() *MockRPCClientMockRecorder {
+	return m.recorder
+}
+
+// CallContext mocks base method.
+func (m *MockRPCClient) CallContext(ctx context.Context, target any, rpcMethod string, args ...any) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, target, rpcMethod}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CallContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CallContext indicates an expected call of CallContext.
+func (mr *MockRPCClientMockRecorder) CallContext(ctx, target, rpcMethod any, args ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, target, rpcMethod}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CallContext", reflect.TypeOf((*MockRPCClient)(nil).CallContext), varargs...)
+}
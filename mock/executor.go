@@ -13,6 +13,7 @@ import (
 
 	common "github.com/ethereum/go-ethereum/common"
 	message "github.com/sygmaprotocol/spectre-node/chains/evm/message"
+	store "github.com/sygmaprotocol/spectre-node/store"
 	transactor "github.com/sygmaprotocol/sygma-core/chains/evm/transactor"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -69,3 +70,169 @@ func (mr *MockProofSubmitterMockRecorder) Step(domainID, input, stepProof, state
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Step", reflect.TypeOf((*MockProofSubmitter)(nil).Step), domainID, input, stepProof, stateRoot, stateRootProof, opts)
 }
+
+// MockExecutionProofSubmitter is a mock of ExecutionProofSubmitter interface.
+type MockExecutionProofSubmitter struct {
+	ctrl     *gomock.Controller
+	recorder *MockExecutionProofSubmitterMockRecorder
+}
+
+// MockExecutionProofSubmitterMockRecorder is the mock recorder for MockExecutionProofSubmitter.
+type MockExecutionProofSubmitterMockRecorder struct {
+	mock *MockExecutionProofSubmitter
+}
+
+// NewMockExecutionProofSubmitter creates a new mock instance.
+func NewMockExecutionProofSubmitter(ctrl *gomock.Controller) *MockExecutionProofSubmitter {
+	mock := &MockExecutionProofSubmitter{ctrl: ctrl}
+	mock.recorder = &MockExecutionProofSubmitterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExecutionProofSubmitter) EXPECT() *MockExecutionProofSubmitterMockRecorder {
+	return m.recorder
+}
+
+// ReceiveExecutionProofs mocks base method.
+func (m *MockExecutionProofSubmitter) ReceiveExecutionProofs(domainID uint8, slot uint64, fields []message.ExecutionField, accountProofs []message.AccountProof, opts transactor.TransactOptions) (*common.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiveExecutionProofs", domainID, slot, fields, accountProofs, opts)
+	ret0, _ := ret[0].(*common.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceiveExecutionProofs indicates an expected call of ReceiveExecutionProofs.
+func (mr *MockExecutionProofSubmitterMockRecorder) ReceiveExecutionProofs(domainID, slot, fields, accountProofs, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveExecutionProofs", reflect.TypeOf((*MockExecutionProofSubmitter)(nil).ReceiveExecutionProofs), domainID, slot, fields, accountProofs, opts)
+}
+
+// MockBlockHeaderStorer is a mock of BlockHeaderStorer interface.
+type MockBlockHeaderStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockHeaderStorerMockRecorder
+}
+
+// MockBlockHeaderStorerMockRecorder is the mock recorder for MockBlockHeaderStorer.
+type MockBlockHeaderStorerMockRecorder struct {
+	mock *MockBlockHeaderStorer
+}
+
+// NewMockBlockHeaderStorer creates a new mock instance.
+func NewMockBlockHeaderStorer(ctrl *gomock.Controller) *MockBlockHeaderStorer {
+	mock := &MockBlockHeaderStorer{ctrl: ctrl}
+	mock.recorder = &MockBlockHeaderStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockHeaderStorer) EXPECT() *MockBlockHeaderStorerMockRecorder {
+	return m.recorder
+}
+
+// StoreBlockHeader mocks base method.
+func (m *MockBlockHeaderStorer) StoreBlockHeader(domainID uint8, slot uint64, header message.BlockHeader, opts transactor.TransactOptions) (*common.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreBlockHeader", domainID, slot, header, opts)
+	ret0, _ := ret[0].(*common.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StoreBlockHeader indicates an expected call of StoreBlockHeader.
+func (mr *MockBlockHeaderStorerMockRecorder) StoreBlockHeader(domainID, slot, header, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreBlockHeader", reflect.TypeOf((*MockBlockHeaderStorer)(nil).StoreBlockHeader), domainID, slot, header, opts)
+}
+
+// MockMessageDeliverer is a mock of MessageDeliverer interface.
+type MockMessageDeliverer struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageDelivererMockRecorder
+}
+
+// MockMessageDelivererMockRecorder is the mock recorder for MockMessageDeliverer.
+type MockMessageDelivererMockRecorder struct {
+	mock *MockMessageDeliverer
+}
+
+// NewMockMessageDeliverer creates a new mock instance.
+func NewMockMessageDeliverer(ctrl *gomock.Controller) *MockMessageDeliverer {
+	mock := &MockMessageDeliverer{ctrl: ctrl}
+	mock.recorder = &MockMessageDelivererMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageDeliverer) EXPECT() *MockMessageDelivererMockRecorder {
+	return m.recorder
+}
+
+// DeliverMessages mocks base method.
+func (m *MockMessageDeliverer) DeliverMessages(sourceDomainID, destinationDomainID uint8, delivery *store.Delivery) ([]*store.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverMessages", sourceDomainID, destinationDomainID, delivery)
+	ret0, _ := ret[0].([]*store.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeliverMessages indicates an expected call of DeliverMessages.
+func (mr *MockMessageDelivererMockRecorder) DeliverMessages(sourceDomainID, destinationDomainID, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverMessages", reflect.TypeOf((*MockMessageDeliverer)(nil).DeliverMessages), sourceDomainID, destinationDomainID, delivery)
+}
+
+// MockSubmissionStorer is a mock of SubmissionStorer interface.
+type MockSubmissionStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubmissionStorerMockRecorder
+}
+
+// MockSubmissionStorerMockRecorder is the mock recorder for MockSubmissionStorer.
+type MockSubmissionStorerMockRecorder struct {
+	mock *MockSubmissionStorer
+}
+
+// NewMockSubmissionStorer creates a new mock instance.
+func NewMockSubmissionStorer(ctrl *gomock.Controller) *MockSubmissionStorer {
+	mock := &MockSubmissionStorer{ctrl: ctrl}
+	mock.recorder = &MockSubmissionStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubmissionStorer) EXPECT() *MockSubmissionStorerMockRecorder {
+	return m.recorder
+}
+
+// StoreSubmission mocks base method.
+func (m *MockSubmissionStorer) StoreSubmission(destinationDomainID uint8, submission *store.Submission) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreSubmission", destinationDomainID, submission)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreSubmission indicates an expected call of StoreSubmission.
+func (mr *MockSubmissionStorerMockRecorder) StoreSubmission(destinationDomainID, submission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreSubmission", reflect.TypeOf((*MockSubmissionStorer)(nil).StoreSubmission), destinationDomainID, submission)
+}
+
+// Submission mocks base method.
+func (m *MockSubmissionStorer) Submission(destinationDomainID uint8, proofType store.ProofType, sourceDomainID uint8, slot uint64) (*store.Submission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Submission", destinationDomainID, proofType, sourceDomainID, slot)
+	ret0, _ := ret[0].(*store.Submission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Submission indicates an expected call of Submission.
+func (mr *MockSubmissionStorerMockRecorder) Submission(destinationDomainID, proofType, sourceDomainID, slot any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Submission", reflect.TypeOf((*MockSubmissionStorer)(nil).Submission), destinationDomainID, proofType, sourceDomainID, slot)
+}
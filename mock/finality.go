@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/evm/monitor/finality.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./chains/evm/monitor/finality.go -destination=./mock/finality.go -package mock
+//
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	api "github.com/attestantio/go-eth2-client/api"
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFinalityProvider is a mock of FinalityProvider interface.
+type MockFinalityProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockFinalityProviderMockRecorder
+}
+
+// MockFinalityProviderMockRecorder is the mock recorder for MockFinalityProvider.
+type MockFinalityProviderMockRecorder struct {
+	mock *MockFinalityProvider
+}
+
+// NewMockFinalityProvider creates a new mock instance.
+func NewMockFinalityProvider(ctrl *gomock.Controller) *MockFinalityProvider {
+	mock := &MockFinalityProvider{ctrl: ctrl}
+	mock.recorder = &MockFinalityProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFinalityProvider) EXPECT() *MockFinalityProviderMockRecorder {
+	return m.recorder
+}
+
+// Finality mocks base method.
+func (m *MockFinalityProvider) Finality(ctx context.Context, opts *api.FinalityOpts) (*api.Response[*v1.Finality], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finality", ctx, opts)
+	ret0, _ := ret[0].(*api.Response[*v1.Finality])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Finality indicates an expected call of Finality.
+func (mr *MockFinalityProviderMockRecorder) Finality(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finality", reflect.TypeOf((*MockFinalityProvider)(nil).Finality), ctx, opts)
+}
+
+// MockSourceFinality is a mock of SourceFinality interface.
+type MockSourceFinality struct {
+	ctrl     *gomock.Controller
+	recorder *MockSourceFinalityMockRecorder
+}
+
+// MockSourceFinalityMockRecorder is the mock recorder for MockSourceFinality.
+type MockSourceFinalityMockRecorder struct {
+	mock *MockSourceFinality
+}
+
+// NewMockSourceFinality creates a new mock instance.
+func NewMockSourceFinality(ctrl *gomock.Controller) *MockSourceFinality {
+	mock := &MockSourceFinality{ctrl: ctrl}
+	mock.recorder = &MockSourceFinalityMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSourceFinality) EXPECT() *MockSourceFinalityMockRecorder {
+	return m.recorder
+}
+
+// FinalizedSlot mocks base method.
+func (m *MockSourceFinality) FinalizedSlot(ctx context.Context) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinalizedSlot", ctx)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinalizedSlot indicates an expected call of FinalizedSlot.
+func (mr *MockSourceFinalityMockRecorder) FinalizedSlot(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinalizedSlot", reflect.TypeOf((*MockSourceFinality)(nil).FinalizedSlot), ctx)
+}
@@ -0,0 +1,92 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/evm/listener/events/handlers/hashi.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mock/hashi.go -package mock -source=./chains/evm/listener/events/handlers/hashi.go
+//
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	events "github.com/sygmaprotocol/spectre-node/chains/evm/listener/events"
+	store "github.com/sygmaprotocol/spectre-node/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMessageHasher is a mock of MessageHasher interface.
+type MockMessageHasher struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageHasherMockRecorder
+}
+
+// MockMessageHasherMockRecorder is the mock recorder for MockMessageHasher.
+type MockMessageHasherMockRecorder struct {
+	mock *MockMessageHasher
+}
+
+// NewMockMessageHasher creates a new mock instance.
+func NewMockMessageHasher(ctrl *gomock.Controller) *MockMessageHasher {
+	mock := &MockMessageHasher{ctrl: ctrl}
+	mock.recorder = &MockMessageHasherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageHasher) EXPECT() *MockMessageHasherMockRecorder {
+	return m.recorder
+}
+
+// CalculateMessageHash mocks base method.
+func (m *MockMessageHasher) CalculateMessageHash(message events.HashiMessage) ([32]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalculateMessageHash", message)
+	ret0, _ := ret[0].([32]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CalculateMessageHash indicates an expected call of CalculateMessageHash.
+func (mr *MockMessageHasherMockRecorder) CalculateMessageHash(message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateMessageHash", reflect.TypeOf((*MockMessageHasher)(nil).CalculateMessageHash), message)
+}
+
+// MockMessageStorer is a mock of MessageStorer interface.
+type MockMessageStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageStorerMockRecorder
+}
+
+// MockMessageStorerMockRecorder is the mock recorder for MockMessageStorer.
+type MockMessageStorerMockRecorder struct {
+	mock *MockMessageStorer
+}
+
+// NewMockMessageStorer creates a new mock instance.
+func NewMockMessageStorer(ctrl *gomock.Controller) *MockMessageStorer {
+	mock := &MockMessageStorer{ctrl: ctrl}
+	mock.recorder = &MockMessageStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageStorer) EXPECT() *MockMessageStorerMockRecorder {
+	return m.recorder
+}
+
+// StoreMessage mocks base method.
+func (m *MockMessageStorer) StoreMessage(message *store.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreMessage", message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreMessage indicates an expected call of StoreMessage.
+func (mr *MockMessageStorerMockRecorder) StoreMessage(message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreMessage", reflect.TypeOf((*MockMessageStorer)(nil).StoreMessage), message)
+}
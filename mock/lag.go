@@ -0,0 +1,204 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/evm/monitor/lag.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./chains/evm/monitor/lag.go -destination=./mock/lag.go -package mock
+//
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	alert "github.com/sygmaprotocol/spectre-node/alert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLatestSlotFetcher is a mock of LatestSlotFetcher interface.
+type MockLatestSlotFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockLatestSlotFetcherMockRecorder
+}
+
+// MockLatestSlotFetcherMockRecorder is the mock recorder for MockLatestSlotFetcher.
+type MockLatestSlotFetcherMockRecorder struct {
+	mock *MockLatestSlotFetcher
+}
+
+// NewMockLatestSlotFetcher creates a new mock instance.
+func NewMockLatestSlotFetcher(ctrl *gomock.Controller) *MockLatestSlotFetcher {
+	mock := &MockLatestSlotFetcher{ctrl: ctrl}
+	mock.recorder = &MockLatestSlotFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLatestSlotFetcher) EXPECT() *MockLatestSlotFetcherMockRecorder {
+	return m.recorder
+}
+
+// LatestRotationSlot mocks base method.
+func (m *MockLatestSlotFetcher) LatestRotationSlot(destinationDomainID, sourceDomainID uint8) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestRotationSlot", destinationDomainID, sourceDomainID)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestRotationSlot indicates an expected call of LatestRotationSlot.
+func (mr *MockLatestSlotFetcherMockRecorder) LatestRotationSlot(destinationDomainID, sourceDomainID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestRotationSlot", reflect.TypeOf((*MockLatestSlotFetcher)(nil).LatestRotationSlot), destinationDomainID, sourceDomainID)
+}
+
+// LatestStateRootSlot mocks base method.
+func (m *MockLatestSlotFetcher) LatestStateRootSlot(destinationDomainID, sourceDomainID uint8) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestStateRootSlot", destinationDomainID, sourceDomainID)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestStateRootSlot indicates an expected call of LatestStateRootSlot.
+func (mr *MockLatestSlotFetcherMockRecorder) LatestStateRootSlot(destinationDomainID, sourceDomainID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestStateRootSlot", reflect.TypeOf((*MockLatestSlotFetcher)(nil).LatestStateRootSlot), destinationDomainID, sourceDomainID)
+}
+
+// MockStateRootFetcher is a mock of StateRootFetcher interface.
+type MockStateRootFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockStateRootFetcherMockRecorder
+}
+
+// MockStateRootFetcherMockRecorder is the mock recorder for MockStateRootFetcher.
+type MockStateRootFetcherMockRecorder struct {
+	mock *MockStateRootFetcher
+}
+
+// NewMockStateRootFetcher creates a new mock instance.
+func NewMockStateRootFetcher(ctrl *gomock.Controller) *MockStateRootFetcher {
+	mock := &MockStateRootFetcher{ctrl: ctrl}
+	mock.recorder = &MockStateRootFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStateRootFetcher) EXPECT() *MockStateRootFetcherMockRecorder {
+	return m.recorder
+}
+
+// GetStateRoot mocks base method.
+func (m *MockStateRootFetcher) GetStateRoot(domainID uint8, slot uint64) ([32]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStateRoot", domainID, slot)
+	ret0, _ := ret[0].([32]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStateRoot indicates an expected call of GetStateRoot.
+func (mr *MockStateRootFetcherMockRecorder) GetStateRoot(domainID, slot any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStateRoot", reflect.TypeOf((*MockStateRootFetcher)(nil).GetStateRoot), domainID, slot)
+}
+
+// Head mocks base method.
+func (m *MockStateRootFetcher) Head(domainID uint8) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Head", domainID)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Head indicates an expected call of Head.
+func (mr *MockStateRootFetcherMockRecorder) Head(domainID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Head", reflect.TypeOf((*MockStateRootFetcher)(nil).Head), domainID)
+}
+
+// MockGaugeSetter is a mock of GaugeSetter interface.
+type MockGaugeSetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockGaugeSetterMockRecorder
+}
+
+// MockGaugeSetterMockRecorder is the mock recorder for MockGaugeSetter.
+type MockGaugeSetterMockRecorder struct {
+	mock *MockGaugeSetter
+}
+
+// NewMockGaugeSetter creates a new mock instance.
+func NewMockGaugeSetter(ctrl *gomock.Controller) *MockGaugeSetter {
+	mock := &MockGaugeSetter{ctrl: ctrl}
+	mock.recorder = &MockGaugeSetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGaugeSetter) EXPECT() *MockGaugeSetterMockRecorder {
+	return m.recorder
+}
+
+// SetGauge mocks base method.
+func (m *MockGaugeSetter) SetGauge(name string, labels map[string]string, value float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetGauge", name, labels, value)
+}
+
+// SetGauge indicates an expected call of SetGauge.
+func (mr *MockGaugeSetterMockRecorder) SetGauge(name, labels, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGauge", reflect.TypeOf((*MockGaugeSetter)(nil).SetGauge), name, labels, value)
+}
+
+// MockAlerter is a mock of Alerter interface.
+type MockAlerter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlerterMockRecorder
+}
+
+// MockAlerterMockRecorder is the mock recorder for MockAlerter.
+type MockAlerterMockRecorder struct {
+	mock *MockAlerter
+}
+
+// NewMockAlerter creates a new mock instance.
+func NewMockAlerter(ctrl *gomock.Controller) *MockAlerter {
+	mock := &MockAlerter{ctrl: ctrl}
+	mock.recorder = &MockAlerterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlerter) EXPECT() *MockAlerterMockRecorder {
+	return m.recorder
+}
+
+// Fire mocks base method.
+func (m *MockAlerter) Fire(alert *alert.Alert) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Fire", alert)
+}
+
+// Fire indicates an expected call of Fire.
+func (mr *MockAlerterMockRecorder) Fire(alert any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fire", reflect.TypeOf((*MockAlerter)(nil).Fire), alert)
+}
+
+// Resolve mocks base method.
+func (m *MockAlerter) Resolve(alert *alert.Alert) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Resolve", alert)
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockAlerterMockRecorder) Resolve(alert any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockAlerter)(nil).Resolve), alert)
+}
@@ -15,6 +15,8 @@ import (
 
 	api "github.com/attestantio/go-eth2-client/api"
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	phase0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	beacon "github.com/sygmaprotocol/spectre-node/chains/evm/beacon"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -93,6 +95,216 @@ func (mr *MockBeaconProviderMockRecorder) Finality(ctx, opts any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finality", reflect.TypeOf((*MockBeaconProvider)(nil).Finality), ctx, opts)
 }
 
+// MockGapHandler is a mock of GapHandler interface.
+type MockGapHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockGapHandlerMockRecorder
+}
+
+// MockGapHandlerMockRecorder is the mock recorder for MockGapHandler.
+type MockGapHandlerMockRecorder struct {
+	mock *MockGapHandler
+}
+
+// NewMockGapHandler creates a new mock instance.
+func NewMockGapHandler(ctrl *gomock.Controller) *MockGapHandler {
+	mock := &MockGapHandler{ctrl: ctrl}
+	mock.recorder = &MockGapHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGapHandler) EXPECT() *MockGapHandlerMockRecorder {
+	return m.recorder
+}
+
+// HandleGap mocks base method.
+func (m *MockGapHandler) HandleGap(from, to phase0.Epoch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleGap", from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleGap indicates an expected call of HandleGap.
+func (mr *MockGapHandlerMockRecorder) HandleGap(from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleGap", reflect.TypeOf((*MockGapHandler)(nil).HandleGap), from, to)
+}
+
+// MockNamedHandler is a mock of NamedHandler interface.
+type MockNamedHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockNamedHandlerMockRecorder
+}
+
+// MockNamedHandlerMockRecorder is the mock recorder for MockNamedHandler.
+type MockNamedHandlerMockRecorder struct {
+	mock *MockNamedHandler
+}
+
+// NewMockNamedHandler creates a new mock instance.
+func NewMockNamedHandler(ctrl *gomock.Controller) *MockNamedHandler {
+	mock := &MockNamedHandler{ctrl: ctrl}
+	mock.recorder = &MockNamedHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNamedHandler) EXPECT() *MockNamedHandlerMockRecorder {
+	return m.recorder
+}
+
+// Name mocks base method.
+func (m *MockNamedHandler) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockNamedHandlerMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockNamedHandler)(nil).Name))
+}
+
+// MockCheckpointStorer is a mock of CheckpointStorer interface.
+type MockCheckpointStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockCheckpointStorerMockRecorder
+}
+
+// MockCheckpointStorerMockRecorder is the mock recorder for MockCheckpointStorer.
+type MockCheckpointStorerMockRecorder struct {
+	mock *MockCheckpointStorer
+}
+
+// NewMockCheckpointStorer creates a new mock instance.
+func NewMockCheckpointStorer(ctrl *gomock.Controller) *MockCheckpointStorer {
+	mock := &MockCheckpointStorer{ctrl: ctrl}
+	mock.recorder = &MockCheckpointStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCheckpointStorer) EXPECT() *MockCheckpointStorerMockRecorder {
+	return m.recorder
+}
+
+// Checkpoint mocks base method.
+func (m *MockCheckpointStorer) Checkpoint(domainID uint8) (*phase0.Checkpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkpoint", domainID)
+	ret0, _ := ret[0].(*phase0.Checkpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checkpoint indicates an expected call of Checkpoint.
+func (mr *MockCheckpointStorerMockRecorder) Checkpoint(domainID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkpoint", reflect.TypeOf((*MockCheckpointStorer)(nil).Checkpoint), domainID)
+}
+
+// StoreCheckpoint mocks base method.
+func (m *MockCheckpointStorer) StoreCheckpoint(domainID uint8, checkpoint *phase0.Checkpoint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreCheckpoint", domainID, checkpoint)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreCheckpoint indicates an expected call of StoreCheckpoint.
+func (mr *MockCheckpointStorerMockRecorder) StoreCheckpoint(domainID, checkpoint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreCheckpoint", reflect.TypeOf((*MockCheckpointStorer)(nil).StoreCheckpoint), domainID, checkpoint)
+}
+
+// MockMetrics is a mock of Metrics interface.
+type MockMetrics struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricsMockRecorder
+}
+
+// MockMetricsMockRecorder is the mock recorder for MockMetrics.
+type MockMetricsMockRecorder struct {
+	mock *MockMetrics
+}
+
+// NewMockMetrics creates a new mock instance.
+func NewMockMetrics(ctrl *gomock.Controller) *MockMetrics {
+	mock := &MockMetrics{ctrl: ctrl}
+	mock.recorder = &MockMetricsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetrics) EXPECT() *MockMetricsMockRecorder {
+	return m.recorder
+}
+
+// SetGauge mocks base method.
+func (m *MockMetrics) SetGauge(name string, labels map[string]string, value float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetGauge", name, labels, value)
+}
+
+// SetGauge indicates an expected call of SetGauge.
+func (mr *MockMetricsMockRecorder) SetGauge(name, labels, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGauge", reflect.TypeOf((*MockMetrics)(nil).SetGauge), name, labels, value)
+}
+
+// MockEventStream is a mock of EventStream interface.
+type MockEventStream struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventStreamMockRecorder
+}
+
+// MockEventStreamMockRecorder is the mock recorder for MockEventStream.
+type MockEventStreamMockRecorder struct {
+	mock *MockEventStream
+}
+
+// NewMockEventStream creates a new mock instance.
+func NewMockEventStream(ctrl *gomock.Controller) *MockEventStream {
+	mock := &MockEventStream{ctrl: ctrl}
+	mock.recorder = &MockEventStreamMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventStream) EXPECT() *MockEventStreamMockRecorder {
+	return m.recorder
+}
+
+// Connected mocks base method.
+func (m *MockEventStream) Connected() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Connected")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Connected indicates an expected call of Connected.
+func (mr *MockEventStreamMockRecorder) Connected() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Connected", reflect.TypeOf((*MockEventStream)(nil).Connected))
+}
+
+// Subscribe mocks base method.
+func (m *MockEventStream) Subscribe(ctx context.Context, events chan<- *beacon.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Subscribe", ctx, events)
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockEventStreamMockRecorder) Subscribe(ctx, events any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockEventStream)(nil).Subscribe), ctx, events)
+}
+
 // MockBlockStorer is a mock of BlockStorer interface.
 type MockBlockStorer struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,251 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/evm/monitor/spectre.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./chains/evm/monitor/spectre.go -destination=./mock/monitor.go -package mock
+//
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	big "math/big"
+	reflect "reflect"
+
+	common "github.com/ethereum/go-ethereum/common"
+	types "github.com/ethereum/go-ethereum/core/types"
+	store "github.com/sygmaprotocol/spectre-node/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLogFetcher is a mock of LogFetcher interface.
+type MockLogFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockLogFetcherMockRecorder
+}
+
+// MockLogFetcherMockRecorder is the mock recorder for MockLogFetcher.
+type MockLogFetcherMockRecorder struct {
+	mock *MockLogFetcher
+}
+
+// NewMockLogFetcher creates a new mock instance.
+func NewMockLogFetcher(ctrl *gomock.Controller) *MockLogFetcher {
+	mock := &MockLogFetcher{ctrl: ctrl}
+	mock.recorder = &MockLogFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLogFetcher) EXPECT() *MockLogFetcherMockRecorder {
+	return m.recorder
+}
+
+// FetchEventLogs mocks base method.
+func (m *MockLogFetcher) FetchEventLogs(ctx context.Context, contractAddress common.Address, event string, startBlock, endBlock *big.Int) ([]types.Log, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchEventLogs", ctx, contractAddress, event, startBlock, endBlock)
+	ret0, _ := ret[0].([]types.Log)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchEventLogs indicates an expected call of FetchEventLogs.
+func (mr *MockLogFetcherMockRecorder) FetchEventLogs(ctx, contractAddress, event, startBlock, endBlock any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchEventLogs", reflect.TypeOf((*MockLogFetcher)(nil).FetchEventLogs), ctx, contractAddress, event, startBlock, endBlock)
+}
+
+// LatestBlock mocks base method.
+func (m *MockLogFetcher) LatestBlock() (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestBlock")
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestBlock indicates an expected call of LatestBlock.
+func (mr *MockLogFetcherMockRecorder) LatestBlock() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestBlock", reflect.TypeOf((*MockLogFetcher)(nil).LatestBlock))
+}
+
+// MockSpectreEventStorer is a mock of SpectreEventStorer interface.
+type MockSpectreEventStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockSpectreEventStorerMockRecorder
+}
+
+// MockSpectreEventStorerMockRecorder is the mock recorder for MockSpectreEventStorer.
+type MockSpectreEventStorerMockRecorder struct {
+	mock *MockSpectreEventStorer
+}
+
+// NewMockSpectreEventStorer creates a new mock instance.
+func NewMockSpectreEventStorer(ctrl *gomock.Controller) *MockSpectreEventStorer {
+	mock := &MockSpectreEventStorer{ctrl: ctrl}
+	mock.recorder = &MockSpectreEventStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSpectreEventStorer) EXPECT() *MockSpectreEventStorerMockRecorder {
+	return m.recorder
+}
+
+// Block mocks base method.
+func (m *MockSpectreEventStorer) Block(destinationDomainID uint8) (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Block", destinationDomainID)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Block indicates an expected call of Block.
+func (mr *MockSpectreEventStorerMockRecorder) Block(destinationDomainID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Block", reflect.TypeOf((*MockSpectreEventStorer)(nil).Block), destinationDomainID)
+}
+
+// LatestStateRootSlot mocks base method.
+func (m *MockSpectreEventStorer) LatestStateRootSlot(destinationDomainID, sourceDomainID uint8) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestStateRootSlot", destinationDomainID, sourceDomainID)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestStateRootSlot indicates an expected call of LatestStateRootSlot.
+func (mr *MockSpectreEventStorerMockRecorder) LatestStateRootSlot(destinationDomainID, sourceDomainID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestStateRootSlot", reflect.TypeOf((*MockSpectreEventStorer)(nil).LatestStateRootSlot), destinationDomainID, sourceDomainID)
+}
+
+// Rotated mocks base method.
+func (m *MockSpectreEventStorer) Rotated(destinationDomainID, sourceDomainID uint8, slot uint64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotated", destinationDomainID, sourceDomainID, slot)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rotated indicates an expected call of Rotated.
+func (mr *MockSpectreEventStorerMockRecorder) Rotated(destinationDomainID, sourceDomainID, slot any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotated", reflect.TypeOf((*MockSpectreEventStorer)(nil).Rotated), destinationDomainID, sourceDomainID, slot)
+}
+
+// StateRoot mocks base method.
+func (m *MockSpectreEventStorer) StateRoot(destinationDomainID, sourceDomainID uint8, slot uint64) ([32]byte, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateRoot", destinationDomainID, sourceDomainID, slot)
+	ret0, _ := ret[0].([32]byte)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// StateRoot indicates an expected call of StateRoot.
+func (mr *MockSpectreEventStorerMockRecorder) StateRoot(destinationDomainID, sourceDomainID, slot any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateRoot", reflect.TypeOf((*MockSpectreEventStorer)(nil).StateRoot), destinationDomainID, sourceDomainID, slot)
+}
+
+// StoreBlock mocks base method.
+func (m *MockSpectreEventStorer) StoreBlock(destinationDomainID uint8, block *big.Int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreBlock", destinationDomainID, block)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreBlock indicates an expected call of StoreBlock.
+func (mr *MockSpectreEventStorerMockRecorder) StoreBlock(destinationDomainID, block any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreBlock", reflect.TypeOf((*MockSpectreEventStorer)(nil).StoreBlock), destinationDomainID, block)
+}
+
+// StoreRotation mocks base method.
+func (m *MockSpectreEventStorer) StoreRotation(destinationDomainID, sourceDomainID uint8, slot uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreRotation", destinationDomainID, sourceDomainID, slot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreRotation indicates an expected call of StoreRotation.
+func (mr *MockSpectreEventStorerMockRecorder) StoreRotation(destinationDomainID, sourceDomainID, slot any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreRotation", reflect.TypeOf((*MockSpectreEventStorer)(nil).StoreRotation), destinationDomainID, sourceDomainID, slot)
+}
+
+// StoreStateRoot mocks base method.
+func (m *MockSpectreEventStorer) StoreStateRoot(destinationDomainID, sourceDomainID uint8, slot uint64, stateRoot [32]byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreStateRoot", destinationDomainID, sourceDomainID, slot, stateRoot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreStateRoot indicates an expected call of StoreStateRoot.
+func (mr *MockSpectreEventStorerMockRecorder) StoreStateRoot(destinationDomainID, sourceDomainID, slot, stateRoot any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreStateRoot", reflect.TypeOf((*MockSpectreEventStorer)(nil).StoreStateRoot), destinationDomainID, sourceDomainID, slot, stateRoot)
+}
+
+// MockPendingSubmissionStorer is a mock of PendingSubmissionStorer interface.
+type MockPendingSubmissionStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockPendingSubmissionStorerMockRecorder
+}
+
+// MockPendingSubmissionStorerMockRecorder is the mock recorder for MockPendingSubmissionStorer.
+type MockPendingSubmissionStorerMockRecorder struct {
+	mock *MockPendingSubmissionStorer
+}
+
+// NewMockPendingSubmissionStorer creates a new mock instance.
+func NewMockPendingSubmissionStorer(ctrl *gomock.Controller) *MockPendingSubmissionStorer {
+	mock := &MockPendingSubmissionStorer{ctrl: ctrl}
+	mock.recorder = &MockPendingSubmissionStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPendingSubmissionStorer) EXPECT() *MockPendingSubmissionStorerMockRecorder {
+	return m.recorder
+}
+
+// PendingSubmissions mocks base method.
+func (m *MockPendingSubmissionStorer) PendingSubmissions(destinationDomainID uint8) ([]*store.Submission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingSubmissions", destinationDomainID)
+	ret0, _ := ret[0].([]*store.Submission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingSubmissions indicates an expected call of PendingSubmissions.
+func (mr *MockPendingSubmissionStorerMockRecorder) PendingSubmissions(destinationDomainID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingSubmissions", reflect.TypeOf((*MockPendingSubmissionStorer)(nil).PendingSubmissions), destinationDomainID)
+}
+
+// StoreSubmission mocks base method.
+func (m *MockPendingSubmissionStorer) StoreSubmission(destinationDomainID uint8, submission *store.Submission) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreSubmission", destinationDomainID, submission)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreSubmission indicates an expected call of StoreSubmission.
+func (mr *MockPendingSubmissionStorerMockRecorder) StoreSubmission(destinationDomainID, submission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreSubmission", reflect.TypeOf((*MockPendingSubmissionStorer)(nil).StoreSubmission), destinationDomainID, submission)
+}
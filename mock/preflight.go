@@ -0,0 +1,180 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/evm/preflight/preflight.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./chains/evm/preflight/preflight.go -destination=./mock/preflight.go -package mock
+//
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	big "math/big"
+	reflect "reflect"
+
+	api "github.com/attestantio/go-eth2-client/api"
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	phase0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	common "github.com/ethereum/go-ethereum/common"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockChainClient is a mock of ChainClient interface.
+type MockChainClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockChainClientMockRecorder
+}
+
+// MockChainClientMockRecorder is the mock recorder for MockChainClient.
+type MockChainClientMockRecorder struct {
+	mock *MockChainClient
+}
+
+// NewMockChainClient creates a new mock instance.
+func NewMockChainClient(ctrl *gomock.Controller) *MockChainClient {
+	mock := &MockChainClient{ctrl: ctrl}
+	mock.recorder = &MockChainClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChainClient) EXPECT() *MockChainClientMockRecorder {
+	return m.recorder
+}
+
+// ChainID mocks base method.
+func (m *MockChainClient) ChainID(ctx context.Context) (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainID", ctx)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainID indicates an expected call of ChainID.
+func (mr *MockChainClientMockRecorder) ChainID(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainID", reflect.TypeOf((*MockChainClient)(nil).ChainID), ctx)
+}
+
+// CodeAt mocks base method.
+func (m *MockChainClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CodeAt", ctx, account, blockNumber)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CodeAt indicates an expected call of CodeAt.
+func (mr *MockChainClientMockRecorder) CodeAt(ctx, account, blockNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CodeAt", reflect.TypeOf((*MockChainClient)(nil).CodeAt), ctx, account, blockNumber)
+}
+
+// MockSpectreReader is a mock of SpectreReader interface.
+type MockSpectreReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockSpectreReaderMockRecorder
+}
+
+// MockSpectreReaderMockRecorder is the mock recorder for MockSpectreReader.
+type MockSpectreReaderMockRecorder struct {
+	mock *MockSpectreReader
+}
+
+// NewMockSpectreReader creates a new mock instance.
+func NewMockSpectreReader(ctrl *gomock.Controller) *MockSpectreReader {
+	mock := &MockSpectreReader{ctrl: ctrl}
+	mock.recorder = &MockSpectreReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSpectreReader) EXPECT() *MockSpectreReaderMockRecorder {
+	return m.recorder
+}
+
+// HasRole mocks base method.
+func (m *MockSpectreReader) HasRole(role [32]byte, account common.Address) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasRole", role, account)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasRole indicates an expected call of HasRole.
+func (mr *MockSpectreReaderMockRecorder) HasRole(role, account any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasRole", reflect.TypeOf((*MockSpectreReader)(nil).HasRole), role, account)
+}
+
+// SpectreContract mocks base method.
+func (m *MockSpectreReader) SpectreContract(domainID uint8) (common.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SpectreContract", domainID)
+	ret0, _ := ret[0].(common.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SpectreContract indicates an expected call of SpectreContract.
+func (mr *MockSpectreReaderMockRecorder) SpectreContract(domainID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SpectreContract", reflect.TypeOf((*MockSpectreReader)(nil).SpectreContract), domainID)
+}
+
+// MockBeaconSpecProvider is a mock of BeaconSpecProvider interface.
+type MockBeaconSpecProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockBeaconSpecProviderMockRecorder
+}
+
+// MockBeaconSpecProviderMockRecorder is the mock recorder for MockBeaconSpecProvider.
+type MockBeaconSpecProviderMockRecorder struct {
+	mock *MockBeaconSpecProvider
+}
+
+// NewMockBeaconSpecProvider creates a new mock instance.
+func NewMockBeaconSpecProvider(ctrl *gomock.Controller) *MockBeaconSpecProvider {
+	mock := &MockBeaconSpecProvider{ctrl: ctrl}
+	mock.recorder = &MockBeaconSpecProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBeaconSpecProvider) EXPECT() *MockBeaconSpecProviderMockRecorder {
+	return m.recorder
+}
+
+// Fork mocks base method.
+func (m *MockBeaconSpecProvider) Fork(ctx context.Context, opts *api.ForkOpts) (*api.Response[*phase0.Fork], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fork", ctx, opts)
+	ret0, _ := ret[0].(*api.Response[*phase0.Fork])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Fork indicates an expected call of Fork.
+func (mr *MockBeaconSpecProviderMockRecorder) Fork(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fork", reflect.TypeOf((*MockBeaconSpecProvider)(nil).Fork), ctx, opts)
+}
+
+// Genesis mocks base method.
+func (m *MockBeaconSpecProvider) Genesis(ctx context.Context, opts *api.GenesisOpts) (*api.Response[*v1.Genesis], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Genesis", ctx, opts)
+	ret0, _ := ret[0].(*api.Response[*v1.Genesis])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Genesis indicates an expected call of Genesis.
+func (mr *MockBeaconSpecProviderMockRecorder) Genesis(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Genesis", reflect.TypeOf((*MockBeaconSpecProvider)(nil).Genesis), ctx, opts)
+}
@@ -0,0 +1,226 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./queue/relayer.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./queue/relayer.go -destination=./mock/queue.go -package mock
+//
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	store "github.com/sygmaprotocol/spectre-node/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockQueueStorer is a mock of QueueStorer interface.
+type MockQueueStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockQueueStorerMockRecorder
+}
+
+// MockQueueStorerMockRecorder is the mock recorder for MockQueueStorer.
+type MockQueueStorerMockRecorder struct {
+	mock *MockQueueStorer
+}
+
+// NewMockQueueStorer creates a new mock instance.
+func NewMockQueueStorer(ctrl *gomock.Controller) *MockQueueStorer {
+	mock := &MockQueueStorer{ctrl: ctrl}
+	mock.recorder = &MockQueueStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQueueStorer) EXPECT() *MockQueueStorerMockRecorder {
+	return m.recorder
+}
+
+// Ack mocks base method.
+func (m *MockQueueStorer) Ack(destinationDomainID uint8, seq uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ack", destinationDomainID, seq)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ack indicates an expected call of Ack.
+func (mr *MockQueueStorerMockRecorder) Ack(destinationDomainID, seq any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ack", reflect.TypeOf((*MockQueueStorer)(nil).Ack), destinationDomainID, seq)
+}
+
+// DeadLetter mocks base method.
+func (m *MockQueueStorer) DeadLetter(msg *store.QueuedMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeadLetter", msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeadLetter indicates an expected call of DeadLetter.
+func (mr *MockQueueStorerMockRecorder) DeadLetter(msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeadLetter", reflect.TypeOf((*MockQueueStorer)(nil).DeadLetter), msg)
+}
+
+// DeadLetters mocks base method.
+func (m *MockQueueStorer) DeadLetters(destinationDomainID uint8) ([]*store.QueuedMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeadLetters", destinationDomainID)
+	ret0, _ := ret[0].([]*store.QueuedMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeadLetters indicates an expected call of DeadLetters.
+func (mr *MockQueueStorerMockRecorder) DeadLetters(destinationDomainID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeadLetters", reflect.TypeOf((*MockQueueStorer)(nil).DeadLetters), destinationDomainID)
+}
+
+// Depth mocks base method.
+func (m *MockQueueStorer) Depth(destinationDomainID uint8) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Depth", destinationDomainID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Depth indicates an expected call of Depth.
+func (mr *MockQueueStorerMockRecorder) Depth(destinationDomainID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Depth", reflect.TypeOf((*MockQueueStorer)(nil).Depth), destinationDomainID)
+}
+
+// Enqueue mocks base method.
+func (m *MockQueueStorer) Enqueue(msg *store.QueuedMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockQueueStorerMockRecorder) Enqueue(msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockQueueStorer)(nil).Enqueue), msg)
+}
+
+// Pending mocks base method.
+func (m *MockQueueStorer) Pending(destinationDomainID uint8) ([]*store.QueuedMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pending", destinationDomainID)
+	ret0, _ := ret[0].([]*store.QueuedMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pending indicates an expected call of Pending.
+func (mr *MockQueueStorerMockRecorder) Pending(destinationDomainID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pending", reflect.TypeOf((*MockQueueStorer)(nil).Pending), destinationDomainID)
+}
+
+// Requeue mocks base method.
+func (m *MockQueueStorer) Requeue(msg *store.QueuedMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Requeue", msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Requeue indicates an expected call of Requeue.
+func (mr *MockQueueStorerMockRecorder) Requeue(msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Requeue", reflect.TypeOf((*MockQueueStorer)(nil).Requeue), msg)
+}
+
+// UpdateMessage mocks base method.
+func (m *MockQueueStorer) UpdateMessage(msg *store.QueuedMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMessage", msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMessage indicates an expected call of UpdateMessage.
+func (mr *MockQueueStorerMockRecorder) UpdateMessage(msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMessage", reflect.TypeOf((*MockQueueStorer)(nil).UpdateMessage), msg)
+}
+
+// MockQueueMetrics is a mock of QueueMetrics interface.
+type MockQueueMetrics struct {
+	ctrl     *gomock.Controller
+	recorder *MockQueueMetricsMockRecorder
+}
+
+// MockQueueMetricsMockRecorder is the mock recorder for MockQueueMetrics.
+type MockQueueMetricsMockRecorder struct {
+	mock *MockQueueMetrics
+}
+
+// NewMockQueueMetrics creates a new mock instance.
+func NewMockQueueMetrics(ctrl *gomock.Controller) *MockQueueMetrics {
+	mock := &MockQueueMetrics{ctrl: ctrl}
+	mock.recorder = &MockQueueMetricsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQueueMetrics) EXPECT() *MockQueueMetricsMockRecorder {
+	return m.recorder
+}
+
+// SetGauge mocks base method.
+func (m *MockQueueMetrics) SetGauge(name string, labels map[string]string, value float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetGauge", name, labels, value)
+}
+
+// SetGauge indicates an expected call of SetGauge.
+func (mr *MockQueueMetricsMockRecorder) SetGauge(name, labels, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGauge", reflect.TypeOf((*MockQueueMetrics)(nil).SetGauge), name, labels, value)
+}
+
+// MockPermanentError is a mock of PermanentError interface.
+type MockPermanentError struct {
+	ctrl     *gomock.Controller
+	recorder *MockPermanentErrorMockRecorder
+}
+
+// MockPermanentErrorMockRecorder is the mock recorder for MockPermanentError.
+type MockPermanentErrorMockRecorder struct {
+	mock *MockPermanentError
+}
+
+// NewMockPermanentError creates a new mock instance.
+func NewMockPermanentError(ctrl *gomock.Controller) *MockPermanentError {
+	mock := &MockPermanentError{ctrl: ctrl}
+	mock.recorder = &MockPermanentErrorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPermanentError) EXPECT() *MockPermanentErrorMockRecorder {
+	return m.recorder
+}
+
+// Permanent mocks base method.
+func (m *MockPermanentError) Permanent() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Permanent")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Permanent indicates an expected call of Permanent.
+func (mr *MockPermanentErrorMockRecorder) Permanent() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Permanent", reflect.TypeOf((*MockPermanentError)(nil).Permanent))
+}
@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./api/queue.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./api/queue.go -destination=./mock/queueapi.go -package mock
+//
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	store "github.com/sygmaprotocol/spectre-node/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockQueueFetcher is a mock of QueueFetcher interface.
+type MockQueueFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockQueueFetcherMockRecorder
+}
+
+// MockQueueFetcherMockRecorder is the mock recorder for MockQueueFetcher.
+type MockQueueFetcherMockRecorder struct {
+	mock *MockQueueFetcher
+}
+
+// NewMockQueueFetcher creates a new mock instance.
+func NewMockQueueFetcher(ctrl *gomock.Controller) *MockQueueFetcher {
+	mock := &MockQueueFetcher{ctrl: ctrl}
+	mock.recorder = &MockQueueFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQueueFetcher) EXPECT() *MockQueueFetcherMockRecorder {
+	return m.recorder
+}
+
+// DeadLetters mocks base method.
+func (m *MockQueueFetcher) DeadLetters(destinationDomainID uint8) ([]*store.QueuedMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeadLetters", destinationDomainID)
+	ret0, _ := ret[0].([]*store.QueuedMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeadLetters indicates an expected call of DeadLetters.
+func (mr *MockQueueFetcherMockRecorder) DeadLetters(destinationDomainID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeadLetters", reflect.TypeOf((*MockQueueFetcher)(nil).DeadLetters), destinationDomainID)
+}
+
+// Pending mocks base method.
+func (m *MockQueueFetcher) Pending(destinationDomainID uint8) ([]*store.QueuedMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pending", destinationDomainID)
+	ret0, _ := ret[0].([]*store.QueuedMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pending indicates an expected call of Pending.
+func (mr *MockQueueFetcherMockRecorder) Pending(destinationDomainID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pending", reflect.TypeOf((*MockQueueFetcher)(nil).Pending), destinationDomainID)
+}
@@ -0,0 +1,193 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/evm/monitor/receipt.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./chains/evm/monitor/receipt.go -destination=./mock/receipt.go -package mock
+//
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	big "math/big"
+	reflect "reflect"
+
+	common "github.com/ethereum/go-ethereum/common"
+	types "github.com/ethereum/go-ethereum/core/types"
+	store "github.com/sygmaprotocol/spectre-node/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReceiptFetcher is a mock of ReceiptFetcher interface.
+type MockReceiptFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockReceiptFetcherMockRecorder
+}
+
+// MockReceiptFetcherMockRecorder is the mock recorder for MockReceiptFetcher.
+type MockReceiptFetcherMockRecorder struct {
+	mock *MockReceiptFetcher
+}
+
+// NewMockReceiptFetcher creates a new mock instance.
+func NewMockReceiptFetcher(ctrl *gomock.Controller) *MockReceiptFetcher {
+	mock := &MockReceiptFetcher{ctrl: ctrl}
+	mock.recorder = &MockReceiptFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReceiptFetcher) EXPECT() *MockReceiptFetcherMockRecorder {
+	return m.recorder
+}
+
+// From mocks base method.
+func (m *MockReceiptFetcher) From() common.Address {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "From")
+	ret0, _ := ret[0].(common.Address)
+	return ret0
+}
+
+// From indicates an expected call of From.
+func (mr *MockReceiptFetcherMockRecorder) From() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "From", reflect.TypeOf((*MockReceiptFetcher)(nil).From))
+}
+
+// LatestBlock mocks base method.
+func (m *MockReceiptFetcher) LatestBlock() (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestBlock")
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestBlock indicates an expected call of LatestBlock.
+func (mr *MockReceiptFetcherMockRecorder) LatestBlock() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestBlock", reflect.TypeOf((*MockReceiptFetcher)(nil).LatestBlock))
+}
+
+// NonceAt mocks base method.
+func (m *MockReceiptFetcher) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NonceAt", ctx, account, blockNumber)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NonceAt indicates an expected call of NonceAt.
+func (mr *MockReceiptFetcherMockRecorder) NonceAt(ctx, account, blockNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NonceAt", reflect.TypeOf((*MockReceiptFetcher)(nil).NonceAt), ctx, account, blockNumber)
+}
+
+// TransactionByHash mocks base method.
+func (m *MockReceiptFetcher) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransactionByHash", ctx, hash)
+	ret0, _ := ret[0].(*types.Transaction)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TransactionByHash indicates an expected call of TransactionByHash.
+func (mr *MockReceiptFetcherMockRecorder) TransactionByHash(ctx, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransactionByHash", reflect.TypeOf((*MockReceiptFetcher)(nil).TransactionByHash), ctx, hash)
+}
+
+// TransactionReceipt mocks base method.
+func (m *MockReceiptFetcher) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransactionReceipt", ctx, txHash)
+	ret0, _ := ret[0].(*types.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransactionReceipt indicates an expected call of TransactionReceipt.
+func (mr *MockReceiptFetcherMockRecorder) TransactionReceipt(ctx, txHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransactionReceipt", reflect.TypeOf((*MockReceiptFetcher)(nil).TransactionReceipt), ctx, txHash)
+}
+
+// MockMessageRequeuer is a mock of MessageRequeuer interface.
+type MockMessageRequeuer struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageRequeuerMockRecorder
+}
+
+// MockMessageRequeuerMockRecorder is the mock recorder for MockMessageRequeuer.
+type MockMessageRequeuerMockRecorder struct {
+	mock *MockMessageRequeuer
+}
+
+// NewMockMessageRequeuer creates a new mock instance.
+func NewMockMessageRequeuer(ctrl *gomock.Controller) *MockMessageRequeuer {
+	mock := &MockMessageRequeuer{ctrl: ctrl}
+	mock.recorder = &MockMessageRequeuerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageRequeuer) EXPECT() *MockMessageRequeuerMockRecorder {
+	return m.recorder
+}
+
+// Requeue mocks base method.
+func (m *MockMessageRequeuer) Requeue(msg *store.QueuedMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Requeue", msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Requeue indicates an expected call of Requeue.
+func (mr *MockMessageRequeuerMockRecorder) Requeue(msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Requeue", reflect.TypeOf((*MockMessageRequeuer)(nil).Requeue), msg)
+}
+
+// MockDeliveryRevoker is a mock of DeliveryRevoker interface.
+type MockDeliveryRevoker struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryRevokerMockRecorder
+}
+
+// MockDeliveryRevokerMockRecorder is the mock recorder for MockDeliveryRevoker.
+type MockDeliveryRevokerMockRecorder struct {
+	mock *MockDeliveryRevoker
+}
+
+// NewMockDeliveryRevoker creates a new mock instance.
+func NewMockDeliveryRevoker(ctrl *gomock.Controller) *MockDeliveryRevoker {
+	mock := &MockDeliveryRevoker{ctrl: ctrl}
+	mock.recorder = &MockDeliveryRevokerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliveryRevoker) EXPECT() *MockDeliveryRevokerMockRecorder {
+	return m.recorder
+}
+
+// RevokeDeliveries mocks base method.
+func (m *MockDeliveryRevoker) RevokeDeliveries(sourceDomainID, destinationDomainID uint8, slot uint64) ([]*store.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeDeliveries", sourceDomainID, destinationDomainID, slot)
+	ret0, _ := ret[0].([]*store.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeDeliveries indicates an expected call of RevokeDeliveries.
+func (mr *MockDeliveryRevokerMockRecorder) RevokeDeliveries(sourceDomainID, destinationDomainID, slot any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeDeliveries", reflect.TypeOf((*MockDeliveryRevoker)(nil).RevokeDeliveries), sourceDomainID, destinationDomainID, slot)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/evm/beacon/spec.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./chains/evm/beacon/spec.go -destination=./mock/spec.go -package mock
+//
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	api "github.com/attestantio/go-eth2-client/api"
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSpecProvider is a mock of SpecProvider interface.
+type MockSpecProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockSpecProviderMockRecorder
+}
+
+// MockSpecProviderMockRecorder is the mock recorder for MockSpecProvider.
+type MockSpecProviderMockRecorder struct {
+	mock *MockSpecProvider
+}
+
+// NewMockSpecProvider creates a new mock instance.
+func NewMockSpecProvider(ctrl *gomock.Controller) *MockSpecProvider {
+	mock := &MockSpecProvider{ctrl: ctrl}
+	mock.recorder = &MockSpecProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSpecProvider) EXPECT() *MockSpecProviderMockRecorder {
+	return m.recorder
+}
+
+// Genesis mocks base method.
+func (m *MockSpecProvider) Genesis(ctx context.Context, opts *api.GenesisOpts) (*api.Response[*v1.Genesis], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Genesis", ctx, opts)
+	ret0, _ := ret[0].(*api.Response[*v1.Genesis])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Genesis indicates an expected call of Genesis.
+func (mr *MockSpecProviderMockRecorder) Genesis(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Genesis", reflect.TypeOf((*MockSpecProvider)(nil).Genesis), ctx, opts)
+}
+
+// Spec mocks base method.
+func (m *MockSpecProvider) Spec(ctx context.Context, opts *api.SpecOpts) (*api.Response[map[string]any], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Spec", ctx, opts)
+	ret0, _ := ret[0].(*api.Response[map[string]any])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Spec indicates an expected call of Spec.
+func (mr *MockSpecProviderMockRecorder) Spec(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Spec", reflect.TypeOf((*MockSpecProvider)(nil).Spec), ctx, opts)
+}
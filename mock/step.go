@@ -15,6 +15,7 @@ import (
 
 	api "github.com/attestantio/go-eth2-client/api"
 	spec "github.com/attestantio/go-eth2-client/spec"
+	execution "github.com/sygmaprotocol/spectre-node/chains/evm/execution"
 	message "github.com/sygmaprotocol/spectre-node/chains/evm/message"
 	prover "github.com/sygmaprotocol/spectre-node/chains/evm/prover"
 	gomock "go.uber.org/mock/gomock"
@@ -141,6 +142,44 @@ func (mr *MockBlockFetcherMockRecorder) SignedBeaconBlock(ctx, opts any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignedBeaconBlock", reflect.TypeOf((*MockBlockFetcher)(nil).SignedBeaconBlock), ctx, opts)
 }
 
+// MockExecutionProver is a mock of ExecutionProver interface.
+type MockExecutionProver struct {
+	ctrl     *gomock.Controller
+	recorder *MockExecutionProverMockRecorder
+}
+
+// MockExecutionProverMockRecorder is the mock recorder for MockExecutionProver.
+type MockExecutionProverMockRecorder struct {
+	mock *MockExecutionProver
+}
+
+// NewMockExecutionProver creates a new mock instance.
+func NewMockExecutionProver(ctrl *gomock.Controller) *MockExecutionProver {
+	mock := &MockExecutionProver{ctrl: ctrl}
+	mock.recorder = &MockExecutionProverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExecutionProver) EXPECT() *MockExecutionProverMockRecorder {
+	return m.recorder
+}
+
+// ProveAccounts mocks base method.
+func (m *MockExecutionProver) ProveAccounts(blockNumber uint64, accounts []*execution.Account) ([]message.AccountProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProveAccounts", blockNumber, accounts)
+	ret0, _ := ret[0].([]message.AccountProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProveAccounts indicates an expected call of ProveAccounts.
+func (mr *MockExecutionProverMockRecorder) ProveAccounts(blockNumber, accounts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProveAccounts", reflect.TypeOf((*MockExecutionProver)(nil).ProveAccounts), blockNumber, accounts)
+}
+
 // MockDomainCollector is a mock of DomainCollector interface.
 type MockDomainCollector struct {
 	ctrl     *gomock.Controller
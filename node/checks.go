@@ -0,0 +1,56 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package node
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	evmConfig "github.com/sygmaprotocol/spectre-node/chains/evm/config"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/preflight"
+)
+
+// checkDomain verifies the domain contracts, roles and chain ID before the node starts
+// relaying, so misconfiguration fails fast instead of reverting transactions later
+func checkDomain(ctx context.Context, config *evmConfig.EVMConfig, client preflight.ChainClient, spectre preflight.SpectreReader, relayer common.Address, sourceDomains []uint8) error {
+	if config.ChainID != 0 {
+		err := preflight.CheckChainID(ctx, client, config.ChainID)
+		if err != nil {
+			return err
+		}
+	}
+
+	contracts := map[string]string{
+		"router":             config.Router,
+		"yaho":               config.Yaho,
+		"execution receiver": config.ExecutionReceiver,
+		"hashi adapter":      config.HashiAdapter,
+	}
+	for name, address := range contracts {
+		if address == "" {
+			continue
+		}
+		err := preflight.CheckContract(ctx, client, name, common.HexToAddress(address))
+		if err != nil {
+			return err
+		}
+	}
+
+	if config.Spectre == "" {
+		if len(sourceDomains) > 0 {
+			return fmt.Errorf("spectre address is required to receive steps from domains %v", sourceDomains)
+		}
+		return nil
+	}
+	err := preflight.CheckContract(ctx, client, "spectre", common.HexToAddress(config.Spectre))
+	if err != nil {
+		return err
+	}
+	err = preflight.CheckSpectreContracts(spectre, sourceDomains)
+	if err != nil {
+		return err
+	}
+	return preflight.CheckRoles(spectre, relayer, config.RelayerRoles)
+}
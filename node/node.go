@@ -0,0 +1,411 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	nethttp "net/http"
+	"sort"
+	"time"
+
+	ethAPI "github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/http"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/spectre-node/api"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/beacon"
+	evmConfig "github.com/sygmaprotocol/spectre-node/chains/evm/config"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/contracts"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/execution"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/executor"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/lightclient"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/listener"
+	hashi "github.com/sygmaprotocol/spectre-node/chains/evm/listener/events/handlers"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/listener/handlers"
+	evmMessage "github.com/sygmaprotocol/spectre-node/chains/evm/message"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/monitor"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/preflight"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/prover"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/source"
+	"github.com/sygmaprotocol/spectre-node/config"
+	"github.com/sygmaprotocol/spectre-node/metrics"
+	"github.com/sygmaprotocol/spectre-node/queue"
+	"github.com/sygmaprotocol/spectre-node/store"
+	"github.com/sygmaprotocol/sygma-core/chains/evm"
+	"github.com/sygmaprotocol/sygma-core/chains/evm/client"
+	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor/gas"
+	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor/monitored"
+	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor/transaction"
+	"github.com/sygmaprotocol/sygma-core/crypto/secp256k1"
+	"github.com/sygmaprotocol/sygma-core/relayer"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+	coreStore "github.com/sygmaprotocol/sygma-core/store"
+	"github.com/ybbus/jsonrpc/v3"
+)
+
+// Start sets up the configured domains and relays messages between them until the
+// context is cancelled. Store endpoints are registered with the register function.
+func Start(
+	ctx context.Context,
+	cfg *config.Config,
+	db coreStore.KeyValueReaderWriter,
+	m *metrics.Metrics,
+	alerter monitor.Alerter,
+	register func(pattern string, handler nethttp.Handler),
+) error {
+	logLevel, err := zerolog.ParseLevel(cfg.Observability.LogLevel)
+	if err != nil {
+		return err
+	}
+
+	periodStore := store.NewPeriodStore(db)
+	proofStore := store.NewProofStore(db)
+	proofCache := store.NewProofCache(db, cfg.Prover.CacheTTL)
+	submissionStore := store.NewSubmissionStore(db)
+	spectreStore := store.NewSpectreStore(db)
+	checkpointStore := store.NewCheckpointStore(db)
+	messageStore := store.NewMessageStore(db)
+	queueStore := store.NewQueueStore(db)
+	register("/messages", api.NewMessageHandler(messageStore))
+	register("/queue", api.NewQueueHandler(queueStore))
+
+	proverClient := jsonrpc.NewClient(cfg.Prover.URL)
+
+	msgChan := make(chan []*message.Message)
+	chains := make(map[uint8]relayer.RelayedChain)
+	sources := make(map[uint8]*monitor.Source)
+	spectreListeners := make([]*monitor.SpectreListener, 0)
+	lagMonitors := make([]*monitor.LagMonitor, 0)
+
+	// execution proofs are configured on the destination but generated by the source
+	executionTargets := make(map[uint8]*execution.Targets)
+	// source domains routing steps or rotations to each destination
+	sourceDomains := make(map[uint8][]uint8)
+	for id, nType := range cfg.Domains {
+		if nType != "evm" {
+			continue
+		}
+		config, err := evmConfig.LoadEVMConfig(id)
+		if err != nil {
+			return err
+		}
+		targets, err := config.ExecutionTargets()
+		if err != nil {
+			return err
+		}
+		if !targets.Empty() {
+			executionTargets[id] = targets
+		}
+		for destination := range config.DestinationRoutes() {
+			sourceDomains[destination] = append(sourceDomains[destination], id)
+		}
+	}
+
+	beaconSources := make(source.Sources)
+	messageHandlers := make(map[uint8]*message.MessageHandler)
+	executors := make(map[uint8]*executor.EVMExecutor)
+	listenerConfigs := make(map[uint8]*evmConfig.EVMConfig)
+	receiptFetchers := make(map[uint8]monitor.ReceiptFetcher)
+	// domains are set up in order so shared beacon chains are configured by their lowest domain
+	for _, id := range sortedDomains(cfg.Domains) {
+		nType := cfg.Domains[id]
+		switch nType {
+		case "evm":
+			{
+				config, err := evmConfig.LoadEVMConfig(id)
+				if err != nil {
+					return err
+				}
+
+				kp, err := secp256k1.NewKeypairFromString(config.Key)
+				if err != nil {
+					return err
+				}
+
+				client, err := client.NewEVMClient(config.Endpoint, kp)
+				if err != nil {
+					return err
+				}
+
+				gasPricer := gas.NewLondonGasPriceClient(client, &gas.GasPricerOpts{
+					UpperLimitFeePerGas: big.NewInt(config.MaxGasPrice),
+					GasPriceFactor:      big.NewFloat(config.GasMultiplier),
+				})
+				t := monitored.NewMonitoredTransactor(transaction.NewTransaction, gasPricer, client, big.NewInt(config.MaxGasPrice), big.NewInt(config.GasIncreasePercentage))
+				go t.Monitor(ctx, time.Minute*3, time.Minute*10, time.Minute)
+
+				routes := config.DestinationRoutes()
+				if len(routes) > 0 {
+					beaconEndpoint := config.BeaconEndpoint
+					if config.BeaconReplayPath != "" {
+						replayer, err := beacon.NewReplayer(config.BeaconReplayPath)
+						if err != nil {
+							return err
+						}
+						beaconEndpoint, err = beacon.Serve(ctx, replayer)
+						if err != nil {
+							return err
+						}
+						log.Info().Uint8("domainID", id).Msgf("Replaying beacon responses from %s", config.BeaconReplayPath)
+					} else if config.BeaconRecordPath != "" {
+						recorder, err := beacon.NewRecorder(config.BeaconEndpoint, config.BeaconRecordPath)
+						if err != nil {
+							return err
+						}
+						beaconEndpoint, err = beacon.Serve(ctx, recorder)
+						if err != nil {
+							return err
+						}
+						log.Info().Uint8("domainID", id).Msgf("Recording beacon responses to %s", config.BeaconRecordPath)
+					}
+
+					beaconClient, err := http.New(ctx,
+						http.WithAddress(beaconEndpoint),
+						http.WithLogLevel(logLevel),
+						http.WithTimeout(time.Second*30),
+					)
+					if err != nil {
+						return err
+					}
+					beaconProvider := beaconClient.(*http.Service)
+					networkSpec, err := beacon.FetchNetworkSpec(ctx, beaconProvider)
+					if err != nil {
+						return err
+					}
+					err = config.ApplyNetworkSpec(id, networkSpec)
+					if err != nil {
+						return fmt.Errorf("invalid network spec of domain %d: %w", id, err)
+					}
+					chainSpec, err := config.ChainSpec()
+					if err != nil {
+						return fmt.Errorf("invalid spec of domain %d: %w", id, err)
+					}
+					log.Info().Uint8("domainID", id).Msgf(
+						"Using spec %s with %d slots per epoch, %d epochs per committee period and finality threshold %d",
+						config.Spec, config.SlotsPerEpoch, config.CommitteePeriodLength, config.FinalityThreshold)
+					// recordings made before the check was added don't include genesis and fork responses
+					if config.BeaconReplayPath == "" {
+						err = preflight.CheckBeaconSpec(ctx, beaconProvider, chainSpec)
+						if err != nil {
+							return fmt.Errorf("preflight check of domain %d failed: %w", id, err)
+						}
+					}
+
+					// domains on the same beacon chain share the listener and the prover
+					genesis, err := beaconProvider.Genesis(ctx, &ethAPI.GenesisOpts{})
+					if err != nil {
+						return err
+					}
+					beaconSource, ok := beaconSources[genesis.Data.GenesisValidatorsRoot]
+					if !ok {
+						// recordings don't include the event stream, so replays fall back to polling
+						var eventStream listener.EventStream
+						if config.BeaconReplayPath == "" {
+							eventStream = beacon.NewEventStream(
+								config.BeaconEndpoint,
+								[]string{beacon.FINALIZED_CHECKPOINT_TOPIC, beacon.LIGHT_CLIENT_FINALITY_UPDATE_TOPIC},
+								time.Duration(config.RetryInterval)*time.Second,
+								time.Duration(config.EventIdleTimeout)*time.Second,
+							)
+						}
+						lightClient := lightclient.NewLightClient(beaconEndpoint)
+						p := prover.NewCachedProver(
+							prover.NewProver(proverClient, beaconProvider, lightClient, chainSpec, config.FinalityThreshold, config.SlotsPerEpoch, config.CommitteePeriodLength),
+							proofCache,
+						)
+						beaconSource = source.NewSource(genesis.Data.GenesisValidatorsRoot, sourceParams(config), beaconProvider, eventStream, source.NewSharedProver(p))
+						beaconSources[genesis.Data.GenesisValidatorsRoot] = beaconSource
+					} else {
+						log.Info().Uint8("domainID", id).Msgf("Sharing beacon chain %#x with domains %v", genesis.Data.GenesisValidatorsRoot, beaconSource.Domains())
+					}
+					sources[id] = &monitor.Source{
+						Finality:       monitor.NewBeaconFinality(beaconSource.BeaconProvider, config.SlotsPerEpoch),
+						SlotsPerPeriod: config.SlotsPerEpoch * config.CommitteePeriodLength,
+						SlotDuration:   time.Duration(config.SecondsPerSlot) * time.Second,
+					}
+
+					storedPeriod, err := periodStore.Period(id)
+					if err != nil {
+						return err
+					}
+					var latestPeriod *big.Int
+					if (storedPeriod.Uint64() >= config.StartingPeriod) && !config.ForcePeriod {
+						latestPeriod = storedPeriod
+					} else {
+						latestPeriod = big.NewInt(int64(config.StartingPeriod))
+					}
+
+					domainCollectors := []handlers.DomainCollector{}
+					if config.Yaho != "" {
+						domainCollectors = append(domainCollectors, hashi.NewHashiDomainCollector(
+							id,
+							common.HexToAddress(config.Yaho),
+							client,
+							contracts.NewYahoContract(common.HexToAddress(config.Yaho), client),
+							messageStore,
+							routes.StepDomains(),
+							config.LogBlockRange,
+						))
+					}
+					if config.Router != "" {
+						domainCollectors = append(domainCollectors, hashi.NewDepositDomainCollector(
+							id,
+							common.HexToAddress(config.Router),
+							client,
+							messageStore,
+							routes,
+							config.LogBlockRange,
+						))
+					}
+					stepHandler := handlers.NewStepEventHandler(
+						msgChan,
+						domainCollectors,
+						beaconSource.BeaconProvider,
+						beaconSource.Prover,
+						proofStore,
+						execution.NewProver(client),
+						id,
+						routes,
+						executionTargets,
+					)
+					rotateHandler := handlers.NewRotateHandler(msgChan, periodStore, proofStore, beaconSource.Prover, id, routes.RotateDomains(), config.CommitteePeriodLength, config.RotationLead(), latestPeriod)
+					err = beaconSource.AddDomain(id, sourceParams(config), []listener.EventHandler{rotateHandler, stepHandler})
+					if err != nil {
+						return err
+					}
+				}
+
+				messageHandler := message.NewMessageHandler()
+				rotateMessageHandler := evmMessage.EvmRotateHandler{}
+				stepMessageHandler := evmMessage.EvmStepHandler{}
+				executionStepMessageHandler := evmMessage.EvmExecutionStepHandler{}
+				messageHandler.RegisterMessageHandler(evmMessage.EVMRotateMessage, &rotateMessageHandler)
+				messageHandler.RegisterMessageHandler(evmMessage.EVMStepMessage, &stepMessageHandler)
+				messageHandler.RegisterMessageHandler(evmMessage.EVMExecutionStepMessage, &executionStepMessageHandler)
+
+				spectre := contracts.NewSpectreContract(common.HexToAddress(config.Spectre), client, t)
+				err = checkDomain(ctx, config, client, spectre, kp.CommonAddress(), sourceDomains[id])
+				if err != nil {
+					return fmt.Errorf("preflight check of domain %d failed: %w", id, err)
+				}
+				var executionReceiver executor.ExecutionProofSubmitter
+				if config.ExecutionReceiver != "" {
+					executionReceiver = contracts.NewExecutionReceiverContract(common.HexToAddress(config.ExecutionReceiver), client, t)
+				}
+				var hashiAdapter executor.BlockHeaderStorer
+				if config.HashiAdapter != "" {
+					hashiAdapter = contracts.NewHashiAdapterContract(common.HexToAddress(config.HashiAdapter), client, t)
+				}
+				executor := executor.NewEVMExecutor(id, spectre, executionReceiver, hashiAdapter, submissionStore, messageStore, time.Duration(config.RetryInterval)*time.Second)
+
+				if config.Spectre != "" {
+					spectreListeners = append(spectreListeners, monitor.NewSpectreListener(
+						id,
+						common.HexToAddress(config.Spectre),
+						client,
+						spectreStore,
+						submissionStore,
+						time.Duration(config.ConfirmationTimeout)*time.Second,
+						time.Duration(config.MonitorInterval)*time.Second,
+						config.LogBlockRange,
+					))
+					lagMonitors = append(lagMonitors, monitor.NewLagMonitor(
+						id,
+						spectreStore,
+						spectre,
+						sources,
+						m,
+						alerter,
+						time.Duration(config.StateRootMaxAge)*time.Minute,
+						config.StateRootLagThreshold,
+						time.Duration(config.RotationGracePeriod)*time.Minute,
+						time.Duration(config.MonitorInterval)*time.Second,
+					))
+				}
+
+				messageHandlers[id] = messageHandler
+				executors[id] = executor
+				listenerConfigs[id] = config
+				receiptFetchers[id] = client
+			}
+		default:
+			{
+				return fmt.Errorf("invalid network type %s for id %d", nType, id)
+			}
+		}
+	}
+
+	// every beacon chain is followed by a single listener run for its lowest domain
+	evmListeners := make(map[uint8]*listener.EVMListener)
+	for _, beaconSource := range beaconSources {
+		id := beaconSource.ListenerDomain()
+		config := listenerConfigs[id]
+		evmListeners[id] = listener.NewEVMListener(
+			beaconSource.BeaconProvider,
+			beaconSource.EventStream,
+			checkpointStore,
+			m,
+			beaconSource.Handlers(),
+			id,
+			time.Duration(config.RetryInterval)*time.Second,
+			time.Duration(config.EventPollInterval)*time.Second,
+		)
+	}
+	for id, messageHandler := range messageHandlers {
+		chains[id] = evm.NewEVMChain(evmListeners[id], messageHandler, executors[id], id, nil)
+	}
+
+	r := queue.NewRelayer(chains, queueStore, m, map[message.MessageType]queue.DataDecoder{
+		evmMessage.EVMStepMessage:          queue.JSONDecoder[evmMessage.StepData](),
+		evmMessage.EVMExecutionStepMessage: queue.JSONDecoder[evmMessage.StepData](),
+		evmMessage.EVMRotateMessage:        queue.JSONDecoder[evmMessage.RotateData](),
+	}, cfg.Queue.MaxAttempts, cfg.Queue.RetryInterval)
+	go r.Start(ctx, msgChan)
+
+	for id, receiptFetcher := range receiptFetchers {
+		config := listenerConfigs[id]
+		receiptTracker := monitor.NewReceiptTracker(
+			id,
+			receiptFetcher,
+			submissionStore,
+			r,
+			messageStore,
+			config.Confirmations,
+			time.Duration(config.DropTimeout)*time.Second,
+			config.MaxResubmissions,
+			time.Duration(config.MonitorInterval)*time.Second,
+		)
+		go receiptTracker.Track(ctx)
+	}
+	for _, spectreListener := range spectreListeners {
+		go spectreListener.Listen(ctx)
+	}
+	for _, lagMonitor := range lagMonitors {
+		go lagMonitor.Monitor(ctx)
+	}
+
+	log.Info().Msgf("Started spectre node")
+	return nil
+}
+
+func sortedDomains(domains map[uint8]string) []uint8 {
+	ids := make([]uint8, 0, len(domains))
+	for id := range domains {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func sourceParams(config *evmConfig.EVMConfig) source.Params {
+	return source.Params{
+		Spec:                  prover.Spec(config.Spec),
+		FinalityThreshold:     config.FinalityThreshold,
+		SlotsPerEpoch:         config.SlotsPerEpoch,
+		CommitteePeriodLength: config.CommitteePeriodLength,
+	}
+}
@@ -0,0 +1,306 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/spectre-node/store"
+	"github.com/sygmaprotocol/sygma-core/relayer"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
+)
+
+const MAX_RETRY_BACKOFF = time.Minute * 10
+
+// IDLE_INTERVAL is how often an empty queue is checked in case a notification was missed
+const IDLE_INTERVAL = time.Minute
+
+type QueueStorer interface {
+	Enqueue(msg *store.QueuedMessage) error
+	Requeue(msg *store.QueuedMessage) error
+	UpdateMessage(msg *store.QueuedMessage) error
+	Pending(destinationDomainID uint8) ([]*store.QueuedMessage, error)
+	Depth(destinationDomainID uint8) (int, error)
+	Ack(destinationDomainID uint8, seq uint64) error
+	DeadLetter(msg *store.QueuedMessage) error
+	DeadLetters(destinationDomainID uint8) ([]*store.QueuedMessage, error)
+}
+
+type QueueMetrics interface {
+	SetGauge(name string, labels map[string]string, value float64)
+}
+
+// PermanentError is implemented by delivery errors that can't be resolved by
+// delivering the same message again
+type PermanentError interface {
+	Permanent() bool
+}
+
+// DataDecoder decodes the stored data of a queued message of a single message type
+type DataDecoder func(data []byte) (interface{}, error)
+
+// JSONDecoder decodes queued message data into the message data type
+func JSONDecoder[T any]() DataDecoder {
+	return func(data []byte) (interface{}, error) {
+		var value T
+		err := json.Unmarshal(data, &value)
+		return value, err
+	}
+}
+
+// Relayer routes messages of source domains through a persistent queue per
+// destination domain. Messages are stored before they are handed to the
+// destination executor and only removed once it succeeds, so they are
+// delivered at least once across restarts and a slow destination doesn't
+// block the listeners. Failing messages are retried with a backoff and
+// dead-lettered after the maximum number of attempts or on permanent errors.
+type Relayer struct {
+	chains        map[uint8]relayer.RelayedChain
+	queueStore    QueueStorer
+	metrics       QueueMetrics
+	decoders      map[message.MessageType]DataDecoder
+	maxAttempts   int
+	retryInterval time.Duration
+
+	notify map[uint8]chan struct{}
+}
+
+func NewRelayer(
+	chains map[uint8]relayer.RelayedChain,
+	queueStore QueueStorer,
+	metrics QueueMetrics,
+	decoders map[message.MessageType]DataDecoder,
+	maxAttempts int,
+	retryInterval time.Duration,
+) *Relayer {
+	notify := make(map[uint8]chan struct{})
+	for domainID := range chains {
+		notify[domainID] = make(chan struct{}, 1)
+	}
+
+	return &Relayer{
+		chains:        chains,
+		queueStore:    queueStore,
+		metrics:       metrics,
+		decoders:      decoders,
+		maxAttempts:   maxAttempts,
+		retryInterval: retryInterval,
+		notify:        notify,
+	}
+}
+
+// Start polls events of every chain, starts the delivery of every destination queue
+// and enqueues messages received on the channel until the context is cancelled
+func (r *Relayer) Start(ctx context.Context, msgChan chan []*message.Message) {
+	log.Info().Msgf("Starting relayer")
+
+	for _, c := range r.chains {
+		log.Debug().Msgf("Starting chain %v", c.DomainID())
+		go c.PollEvents(ctx)
+		go r.deliver(ctx, c)
+	}
+
+	for {
+		select {
+		case msgs := <-msgChan:
+			for _, m := range msgs {
+				r.enqueue(m)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// enqueue stores the message in the queue of its destination and wakes up its delivery
+func (r *Relayer) enqueue(m *message.Message) {
+	log := log.With().Uint8("domainID", m.Destination).Str("type", string(m.Type)).Logger()
+	notify, ok := r.notify[m.Destination]
+	if !ok {
+		log.Error().Msgf("No chain registered for destination domain")
+		return
+	}
+
+	data, err := json.Marshal(m.Data)
+	if err != nil {
+		log.Error().Err(err).Msgf("Unable to encode message from domain %d", m.Source)
+		return
+	}
+	err = r.store(notify, r.queueStore.Enqueue, &store.QueuedMessage{
+		ID:          m.ID,
+		Source:      m.Source,
+		Destination: m.Destination,
+		Type:        string(m.Type),
+		Data:        data,
+	})
+	if err != nil {
+		log.Error().Err(err).Msgf("Unable to enqueue message from domain %d", m.Source)
+	}
+}
+
+// Requeue adds an already encoded message, such as the message of a reverted
+// or dropped submission, to the head of its destination queue so a retried
+// rotation is delivered before the steps of the next period
+func (r *Relayer) Requeue(msg *store.QueuedMessage) error {
+	notify, ok := r.notify[msg.Destination]
+	if !ok {
+		return fmt.Errorf("no chain registered for destination domain %d", msg.Destination)
+	}
+
+	msg.Attempts = 0
+	msg.LastError = ""
+	return r.store(notify, r.queueStore.Requeue, msg)
+}
+
+func (r *Relayer) store(notify chan struct{}, enqueue func(msg *store.QueuedMessage) error, msg *store.QueuedMessage) error {
+	now := time.Now()
+	msg.EnqueuedAt = now
+	msg.NextAttempt = now
+	err := enqueue(msg)
+	if err != nil {
+		return err
+	}
+
+	r.updateMetrics(msg.Destination)
+	select {
+	case notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// deliver delivers queued messages to the destination chain whenever a message is
+// enqueued or the next retry is due
+func (r *Relayer) deliver(ctx context.Context, chain relayer.RelayedChain) {
+	wait := time.Duration(0)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.notify[chain.DomainID()]:
+		case <-time.After(wait):
+		}
+
+		wait = r.deliverPending(chain)
+	}
+}
+
+// deliverPending delivers queued messages in order and returns how long to wait
+// before the queue is processed again. A failing message blocks the messages
+// behind it, so a rotation is never overtaken by steps of the next period.
+func (r *Relayer) deliverPending(chain relayer.RelayedChain) time.Duration {
+	domainID := chain.DomainID()
+	defer r.updateMetrics(domainID)
+
+	pending, err := r.queueStore.Pending(domainID)
+	if err != nil {
+		log.Error().Err(err).Uint8("domainID", domainID).Msgf("Unable to fetch queued messages")
+		return r.retryInterval
+	}
+
+	for _, msg := range pending {
+		if wait := time.Until(msg.NextAttempt); wait > 0 {
+			return wait
+		}
+
+		log := log.With().Uint8("domainID", domainID).Uint64("seq", msg.Seq).Str("type", msg.Type).Logger()
+		decoder, ok := r.decoders[message.MessageType(msg.Type)]
+		if !ok {
+			r.deadLetter(msg, fmt.Errorf("no decoder registered for message type %s", msg.Type))
+			continue
+		}
+		data, err := decoder(msg.Data)
+		if err != nil {
+			r.deadLetter(msg, err)
+			continue
+		}
+
+		err = r.deliverMessage(chain, msg, data)
+		if err == nil {
+			log.Debug().Msgf("Delivered queued message from domain %d", msg.Source)
+			err = r.queueStore.Ack(domainID, msg.Seq)
+			if err != nil {
+				log.Error().Err(err).Msgf("Unable to acknowledge delivered message")
+				return r.retryInterval
+			}
+			continue
+		}
+
+		msg.Attempts++
+		msg.LastError = err.Error()
+		var permanentErr PermanentError
+		if msg.Attempts >= r.maxAttempts || (errors.As(err, &permanentErr) && permanentErr.Permanent()) {
+			r.deadLetter(msg, err)
+			continue
+		}
+
+		backoff := r.backoff(msg.Attempts)
+		msg.NextAttempt = time.Now().Add(backoff)
+		log.Warn().Err(err).Int("attempts", msg.Attempts).Msgf("Unable to deliver message from domain %d, retrying in %s", msg.Source, backoff)
+		err = r.queueStore.UpdateMessage(msg)
+		if err != nil {
+			log.Error().Err(err).Msgf("Unable to store message attempts")
+		}
+		return backoff
+	}
+	return IDLE_INTERVAL
+}
+
+func (r *Relayer) deliverMessage(chain relayer.RelayedChain, msg *store.QueuedMessage, data interface{}) error {
+	prop, err := chain.ReceiveMessage(&message.Message{
+		Source:      msg.Source,
+		Destination: msg.Destination,
+		Data:        data,
+		ID:          msg.ID,
+		Type:        message.MessageType(msg.Type),
+	})
+	if err != nil {
+		return err
+	}
+	if prop == nil {
+		return nil
+	}
+
+	return chain.Write([]*proposal.Proposal{prop})
+}
+
+func (r *Relayer) deadLetter(msg *store.QueuedMessage, err error) {
+	msg.LastError = err.Error()
+	log.Error().Err(err).Uint8("domainID", msg.Destination).Uint64("seq", msg.Seq).Int("attempts", msg.Attempts).Msgf("Dead-lettering %s message from domain %d", msg.Type, msg.Source)
+
+	err = r.queueStore.DeadLetter(msg)
+	if err != nil {
+		log.Error().Err(err).Uint8("domainID", msg.Destination).Uint64("seq", msg.Seq).Msgf("Unable to dead-letter message")
+	}
+}
+
+func (r *Relayer) updateMetrics(domainID uint8) {
+	labels := map[string]string{"destination": fmt.Sprint(domainID)}
+	depth, err := r.queueStore.Depth(domainID)
+	if err == nil {
+		r.metrics.SetGauge("spectre_queue_depth", labels, float64(depth))
+	}
+	deadLetters, err := r.queueStore.DeadLetters(domainID)
+	if err == nil {
+		r.metrics.SetGauge("spectre_queue_dead_letters", labels, float64(len(deadLetters)))
+	}
+}
+
+// backoff doubles the retry interval for every consecutive failure up to the maximum backoff
+func (r *Relayer) backoff(attempts int) time.Duration {
+	backoff := r.retryInterval
+	for i := 1; i < attempts && backoff < MAX_RETRY_BACKOFF; i++ {
+		backoff *= 2
+	}
+	if backoff > MAX_RETRY_BACKOFF {
+		return MAX_RETRY_BACKOFF
+	}
+	return backoff
+}
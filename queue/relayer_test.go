@@ -0,0 +1,301 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package queue_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	evmMessage "github.com/sygmaprotocol/spectre-node/chains/evm/message"
+	"github.com/sygmaprotocol/spectre-node/metrics"
+	"github.com/sygmaprotocol/spectre-node/mock"
+	"github.com/sygmaprotocol/spectre-node/queue"
+	"github.com/sygmaprotocol/spectre-node/store"
+	"github.com/sygmaprotocol/sygma-core/relayer"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
+	"github.com/syndtr/goleveldb/leveldb"
+	"go.uber.org/mock/gomock"
+)
+
+// permanentError fails the delivery of a message for good
+type permanentError struct{}
+
+func (e *permanentError) Error() string {
+	return "permanent"
+}
+
+func (e *permanentError) Permanent() bool {
+	return true
+}
+
+// testChain records written proposals and fails writes of a message type
+// the configured number of times
+type testChain struct {
+	lock      sync.Mutex
+	domainID  uint8
+	failures  map[message.MessageType]int
+	permanent map[message.MessageType]bool
+	writes    []*proposal.Proposal
+}
+
+func (c *testChain) PollEvents(ctx context.Context) {}
+
+func (c *testChain) ReceiveMessage(m *message.Message) (*proposal.Proposal, error) {
+	return &proposal.Proposal{
+		Source:      m.Source,
+		Destination: m.Destination,
+		Data:        m.Data,
+		Type:        proposal.ProposalType(m.Type),
+	}, nil
+}
+
+func (c *testChain) Write(props []*proposal.Proposal) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	msgType := message.MessageType(props[0].Type)
+	if c.permanent[msgType] {
+		return &permanentError{}
+	}
+	if c.failures[msgType] > 0 {
+		c.failures[msgType]--
+		return fmt.Errorf("error")
+	}
+	c.writes = append(c.writes, props...)
+	return nil
+}
+
+func (c *testChain) DomainID() uint8 {
+	return c.domainID
+}
+
+func (c *testChain) Writes() []*proposal.Proposal {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return append([]*proposal.Proposal{}, c.writes...)
+}
+
+type RelayerTestSuite struct {
+	suite.Suite
+
+	db         map[string][]byte
+	dbLock     sync.Mutex
+	queueStore *store.QueueStore
+	metrics    *metrics.Metrics
+	chain      *testChain
+	msgChan    chan []*message.Message
+	cancel     context.CancelFunc
+}
+
+func TestRunRelayerTestSuite(t *testing.T) {
+	suite.Run(t, new(RelayerTestSuite))
+}
+
+func (s *RelayerTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	keyValueReaderWriter := mock.NewMockKeyValueReaderWriter(ctrl)
+	s.db = make(map[string][]byte)
+	keyValueReaderWriter.EXPECT().GetByKey(gomock.Any()).DoAndReturn(func(key []byte) ([]byte, error) {
+		s.dbLock.Lock()
+		defer s.dbLock.Unlock()
+		value, ok := s.db[string(key)]
+		if !ok {
+			return nil, leveldb.ErrNotFound
+		}
+		return value, nil
+	}).AnyTimes()
+	keyValueReaderWriter.EXPECT().SetByKey(gomock.Any(), gomock.Any()).DoAndReturn(func(key []byte, value []byte) error {
+		s.dbLock.Lock()
+		defer s.dbLock.Unlock()
+		s.db[string(key)] = value
+		return nil
+	}).AnyTimes()
+
+	s.queueStore = store.NewQueueStore(keyValueReaderWriter)
+	s.metrics = metrics.NewMetrics()
+	s.chain = &testChain{
+		domainID:  2,
+		failures:  make(map[message.MessageType]int),
+		permanent: make(map[message.MessageType]bool),
+	}
+	s.msgChan = make(chan []*message.Message)
+}
+
+func (s *RelayerTestSuite) TearDownTest() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+func (s *RelayerTestSuite) start(maxAttempts int) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	r := queue.NewRelayer(
+		map[uint8]relayer.RelayedChain{2: s.chain},
+		s.queueStore,
+		s.metrics,
+		map[message.MessageType]queue.DataDecoder{
+			evmMessage.EVMStepMessage:   queue.JSONDecoder[evmMessage.StepData](),
+			evmMessage.EVMRotateMessage: queue.JSONDecoder[evmMessage.RotateData](),
+		},
+		maxAttempts,
+		time.Millisecond*10,
+	)
+	go r.Start(ctx, s.msgChan)
+}
+
+func (s *RelayerTestSuite) waitForWrites(count int) []*proposal.Proposal {
+	s.Eventually(func() bool {
+		return len(s.chain.Writes()) == count
+	}, time.Second*5, time.Millisecond*10)
+	return s.chain.Writes()
+}
+
+func (s *RelayerTestSuite) Test_Start_MessageDeliveredAndAcked() {
+	s.start(3)
+	stepData := evmMessage.StepData{
+		Proof: []byte{1, 2, 3},
+		Args:  evmMessage.SyncStepInput{FinalizedSlot: 100},
+	}
+
+	s.msgChan <- []*message.Message{evmMessage.NewEvmStepMessage(1, 2, stepData)}
+
+	writes := s.waitForWrites(1)
+	s.Equal(writes[0].Data, stepData)
+	s.Equal(writes[0].Source, uint8(1))
+	s.Eventually(func() bool {
+		depth, ok := s.metrics.Gauge("spectre_queue_depth", map[string]string{"destination": "2"})
+		return ok && depth == 0
+	}, time.Second*5, time.Millisecond*10)
+}
+
+func (s *RelayerTestSuite) Test_Start_StoredMessagesDeliveredAfterRestart() {
+	err := s.queueStore.Enqueue(&store.QueuedMessage{
+		Source:      1,
+		Destination: 2,
+		Type:        string(evmMessage.EVMRotateMessage),
+		Data:        []byte(`{"RotateProof":"AQ=="}`),
+	})
+	s.Nil(err)
+
+	s.start(3)
+
+	writes := s.waitForWrites(1)
+	s.Equal(writes[0].Data, evmMessage.RotateData{RotateProof: []byte{1}})
+}
+
+func (s *RelayerTestSuite) Test_Start_FailedDeliveryRetriedInOrder() {
+	s.chain.failures[evmMessage.EVMRotateMessage] = 2
+	s.start(3)
+
+	s.msgChan <- []*message.Message{evmMessage.NewEvmRotateMessage(1, 2, evmMessage.RotateData{})}
+	s.msgChan <- []*message.Message{evmMessage.NewEvmStepMessage(1, 2, evmMessage.StepData{})}
+
+	writes := s.waitForWrites(2)
+	s.Equal(writes[0].Type, proposal.ProposalType(evmMessage.EVMRotateMessage))
+	s.Equal(writes[1].Type, proposal.ProposalType(evmMessage.EVMStepMessage))
+	deadLetters, err := s.queueStore.DeadLetters(2)
+	s.Nil(err)
+	s.Equal(len(deadLetters), 0)
+}
+
+func (s *RelayerTestSuite) Test_Start_MaxAttemptsExhausted_DeadLettered() {
+	s.chain.failures[evmMessage.EVMRotateMessage] = 10
+	s.start(2)
+
+	s.msgChan <- []*message.Message{evmMessage.NewEvmRotateMessage(1, 2, evmMessage.RotateData{})}
+	s.msgChan <- []*message.Message{evmMessage.NewEvmStepMessage(1, 2, evmMessage.StepData{})}
+
+	writes := s.waitForWrites(1)
+	s.Equal(writes[0].Type, proposal.ProposalType(evmMessage.EVMStepMessage))
+	s.Eventually(func() bool {
+		deadLetters, _ := s.metrics.Gauge("spectre_queue_dead_letters", map[string]string{"destination": "2"})
+		return deadLetters == 1
+	}, time.Second*5, time.Millisecond*10)
+	deadLetters, err := s.queueStore.DeadLetters(2)
+	s.Nil(err)
+	s.Equal(deadLetters[0].Type, string(evmMessage.EVMRotateMessage))
+	s.Equal(deadLetters[0].Attempts, 2)
+	s.Equal(deadLetters[0].LastError, "error")
+}
+
+func (s *RelayerTestSuite) Test_Start_PermanentError_DeadLetteredWithoutRetry() {
+	s.chain.permanent[evmMessage.EVMRotateMessage] = true
+	s.start(10)
+
+	s.msgChan <- []*message.Message{evmMessage.NewEvmRotateMessage(1, 2, evmMessage.RotateData{})}
+	s.msgChan <- []*message.Message{evmMessage.NewEvmStepMessage(1, 2, evmMessage.StepData{})}
+
+	s.waitForWrites(1)
+	deadLetters, err := s.queueStore.DeadLetters(2)
+	s.Nil(err)
+	s.Equal(deadLetters[0].Attempts, 1)
+	s.Equal(deadLetters[0].LastError, "permanent")
+}
+
+func (s *RelayerTestSuite) Test_Start_UnknownMessageType_DeadLettered() {
+	s.start(3)
+
+	s.msgChan <- []*message.Message{{Source: 1, Destination: 2, Type: "unknown"}}
+	s.msgChan <- []*message.Message{evmMessage.NewEvmStepMessage(1, 2, evmMessage.StepData{})}
+
+	s.waitForWrites(1)
+	deadLetters, err := s.queueStore.DeadLetters(2)
+	s.Nil(err)
+	s.Equal(deadLetters[0].Type, "unknown")
+	s.Equal(deadLetters[0].Attempts, 0)
+}
+
+func (s *RelayerTestSuite) Test_Requeue_AttemptsReset() {
+	r := queue.NewRelayer(map[uint8]relayer.RelayedChain{2: s.chain}, s.queueStore, s.metrics, nil, 3, time.Millisecond*10)
+
+	err := r.Requeue(&store.QueuedMessage{
+		Source:      1,
+		Destination: 2,
+		Type:        string(evmMessage.EVMRotateMessage),
+		Data:        []byte(`{"RotateProof":"AQ=="}`),
+		Attempts:    5,
+		LastError:   "error",
+	})
+	s.Nil(err)
+
+	pending, err := s.queueStore.Pending(2)
+	s.Nil(err)
+	s.Equal(len(pending), 1)
+	s.Equal(pending[0].Attempts, 0)
+	s.Equal(pending[0].LastError, "")
+}
+
+func (s *RelayerTestSuite) Test_Requeue_AheadOfQueuedMessages() {
+	r := queue.NewRelayer(map[uint8]relayer.RelayedChain{2: s.chain}, s.queueStore, s.metrics, nil, 3, time.Millisecond*10)
+	s.Nil(s.queueStore.Enqueue(&store.QueuedMessage{Source: 1, Destination: 2, Type: string(evmMessage.EVMStepMessage)}))
+
+	err := r.Requeue(&store.QueuedMessage{
+		Source:      1,
+		Destination: 2,
+		Type:        string(evmMessage.EVMRotateMessage),
+	})
+	s.Nil(err)
+
+	pending, err := s.queueStore.Pending(2)
+	s.Nil(err)
+	s.Equal(len(pending), 2)
+	s.Equal(pending[0].Type, string(evmMessage.EVMRotateMessage))
+	s.Equal(pending[1].Type, string(evmMessage.EVMStepMessage))
+}
+
+func (s *RelayerTestSuite) Test_Requeue_UnknownDestination() {
+	r := queue.NewRelayer(map[uint8]relayer.RelayedChain{2: s.chain}, s.queueStore, s.metrics, nil, 3, time.Millisecond*10)
+
+	err := r.Requeue(&store.QueuedMessage{Source: 1, Destination: 3})
+
+	s.NotNil(err)
+}
@@ -0,0 +1,106 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"time"
+
+	"flag"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog/log"
+	evmConfig "github.com/sygmaprotocol/spectre-node/chains/evm/config"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/contracts"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/executor"
+	evmMessage "github.com/sygmaprotocol/spectre-node/chains/evm/message"
+	"github.com/sygmaprotocol/spectre-node/config"
+	"github.com/sygmaprotocol/spectre-node/store"
+	"github.com/sygmaprotocol/sygma-core/chains/evm/client"
+	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor/gas"
+	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor/signAndSend"
+	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor/transaction"
+	"github.com/sygmaprotocol/sygma-core/crypto/secp256k1"
+	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
+	"github.com/sygmaprotocol/sygma-core/store/lvldb"
+)
+
+const REPLAY_COMMAND = "replay"
+
+// replay re-submits an archived proof to the destination domain without generating it again.
+// The proof is selected by its content hash or by the source domain and the slot (step)
+// or period (rotate) it was generated for. The node must be stopped as the store is locked while running.
+func replay(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet(REPLAY_COMMAND, flag.ExitOnError)
+	source := flags.Uint("source", 0, "source domain of the archived proof")
+	destination := flags.Uint("destination", 0, "destination domain the proof is submitted to")
+	slot := flags.Uint64("slot", 0, "finalized slot of the archived step proof")
+	period := flags.Uint64("period", 0, "period of the archived rotate proof")
+	hash := flags.String("hash", "", "content hash of the archived proof")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+	if *destination == 0 {
+		return fmt.Errorf("destination domain is required")
+	}
+
+	db, err := lvldb.NewLvlDB(cfg.Store.Path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	proofStore := store.NewProofStore(db)
+
+	var record *store.ProofRecord
+	switch {
+	case *hash != "":
+		record, err = proofStore.Proof(common.HexToHash(*hash))
+	case *period != 0:
+		record, err = proofStore.RotateProof(uint8(*source), *period)
+	default:
+		record, err = proofStore.StepProof(uint8(*source), *slot)
+	}
+	if err != nil {
+		return err
+	}
+
+	prop := &proposal.Proposal{
+		Source:      record.SourceDomain,
+		Destination: uint8(*destination),
+	}
+	switch record.Type {
+	case store.StepProofType:
+		prop.Type = evmMessage.EVMStepProposal
+		prop.Data = *record.Step
+	case store.RotateProofType:
+		prop.Type = evmMessage.EVMRotateProposal
+		prop.Data = *record.Rotate
+	default:
+		return fmt.Errorf("invalid archived proof type %s", record.Type)
+	}
+
+	config, err := evmConfig.LoadEVMConfig(prop.Destination)
+	if err != nil {
+		return err
+	}
+	kp, err := secp256k1.NewKeypairFromString(config.Key)
+	if err != nil {
+		return err
+	}
+	client, err := client.NewEVMClient(config.Endpoint, kp)
+	if err != nil {
+		return err
+	}
+	gasPricer := gas.NewLondonGasPriceClient(client, &gas.GasPricerOpts{
+		UpperLimitFeePerGas: big.NewInt(config.MaxGasPrice),
+		GasPriceFactor:      big.NewFloat(config.GasMultiplier),
+	})
+	t := signAndSend.NewSignAndSendTransactor(transaction.NewTransaction, gasPricer, client)
+	spectre := contracts.NewSpectreContract(common.HexToAddress(config.Spectre), client, t)
+
+	log.Info().Uint8("domainID", prop.Destination).Uint64("slot", record.Slot).Msgf("Replaying %s proof from domain %d", record.Type, record.SourceDomain)
+	return executor.NewEVMExecutor(prop.Destination, spectre, nil, nil, store.NewSubmissionStore(db), store.NewMessageStore(db), time.Duration(config.RetryInterval)*time.Second).Execute([]*proposal.Proposal{prop})
+}
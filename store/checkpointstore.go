@@ -0,0 +1,55 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sygmaprotocol/sygma-core/store"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// CheckpointStore stores the last finalized checkpoint handled per domain
+type CheckpointStore struct {
+	db store.KeyValueReaderWriter
+}
+
+func NewCheckpointStore(db store.KeyValueReaderWriter) *CheckpointStore {
+	return &CheckpointStore{
+		db: db,
+	}
+}
+
+// StoreCheckpoint stores the last handled finalized checkpoint of the domain
+func (s *CheckpointStore) StoreCheckpoint(domainID uint8, checkpoint *phase0.Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	return s.db.SetByKey(checkpointKey(domainID), data)
+}
+
+// Checkpoint returns the last handled finalized checkpoint of the domain or nil
+// if no checkpoint was handled yet
+func (s *CheckpointStore) Checkpoint(domainID uint8) (*phase0.Checkpoint, error) {
+	data, err := s.db.GetByKey(checkpointKey(domainID))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	checkpoint := &phase0.Checkpoint{}
+	err = json.Unmarshal(data, checkpoint)
+	return checkpoint, err
+}
+
+func checkpointKey(domainID uint8) []byte {
+	return []byte(fmt.Sprintf("chain:%d:checkpoint", domainID))
+}
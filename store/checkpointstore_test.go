@@ -0,0 +1,85 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/mock"
+	"github.com/sygmaprotocol/spectre-node/store"
+	"github.com/syndtr/goleveldb/leveldb"
+	"go.uber.org/mock/gomock"
+)
+
+type CheckpointStoreTestSuite struct {
+	suite.Suite
+	checkpointStore      *store.CheckpointStore
+	keyValueReaderWriter *mock.MockKeyValueReaderWriter
+}
+
+func TestRunCheckpointStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(CheckpointStoreTestSuite))
+}
+
+func (s *CheckpointStoreTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.keyValueReaderWriter = mock.NewMockKeyValueReaderWriter(gomockController)
+	s.checkpointStore = store.NewCheckpointStore(s.keyValueReaderWriter)
+}
+
+func (s *CheckpointStoreTestSuite) Test_StoreCheckpoint_FailedStore() {
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte("chain:1:checkpoint"), gomock.Any()).Return(errors.New("error"))
+
+	err := s.checkpointStore.StoreCheckpoint(1, &phase0.Checkpoint{Epoch: 10, Root: phase0.Root{1}})
+
+	s.NotNil(err)
+}
+
+func (s *CheckpointStoreTestSuite) Test_StoreCheckpoint_Success() {
+	var stored []byte
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte("chain:1:checkpoint"), gomock.Any()).DoAndReturn(func(key []byte, value []byte) error {
+		stored = value
+		return nil
+	})
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte("chain:1:checkpoint")).DoAndReturn(func(key []byte) ([]byte, error) {
+		return stored, nil
+	})
+
+	err := s.checkpointStore.StoreCheckpoint(1, &phase0.Checkpoint{Epoch: 10, Root: phase0.Root{1}})
+	s.Nil(err)
+	checkpoint, err := s.checkpointStore.Checkpoint(1)
+
+	s.Nil(err)
+	s.Equal(checkpoint, &phase0.Checkpoint{Epoch: 10, Root: phase0.Root{1}})
+}
+
+func (s *CheckpointStoreTestSuite) Test_Checkpoint_NotFound() {
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte("chain:1:checkpoint")).Return(nil, leveldb.ErrNotFound)
+
+	checkpoint, err := s.checkpointStore.Checkpoint(1)
+
+	s.Nil(err)
+	s.Nil(checkpoint)
+}
+
+func (s *CheckpointStoreTestSuite) Test_Checkpoint_FailedFetch() {
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte("chain:1:checkpoint")).Return(nil, errors.New("error"))
+
+	_, err := s.checkpointStore.Checkpoint(1)
+
+	s.NotNil(err)
+}
+
+func (s *CheckpointStoreTestSuite) Test_Checkpoint_InvalidData() {
+	data, _ := json.Marshal("invalid")
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte("chain:1:checkpoint")).Return(data, nil)
+
+	_, err := s.checkpointStore.Checkpoint(1)
+
+	s.NotNil(err)
+}
@@ -0,0 +1,370 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sygmaprotocol/sygma-core/store"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+type MessageStatus string
+
+const (
+	DispatchedMessage MessageStatus = "dispatched"
+	DeliveredMessage  MessageStatus = "delivered"
+)
+
+type MessageType string
+
+const (
+	HashiMessageType   MessageType = "hashi"
+	DepositMessageType MessageType = "deposit"
+)
+
+// Delivery is the submission that made the message block available on a destination domain
+type Delivery struct {
+	Slot        uint64
+	BlockNumber uint64
+	TxHash      string
+	DeliveredAt time.Time
+}
+
+// Message is a Hashi message dispatched by the Yaho contract or a deposit
+// made on the Router contract of the source domain
+type Message struct {
+	Type MessageType
+	// ID is the Hashi message ID or the deposit ID returned by DepositID
+	ID            string
+	Hash          string `json:",omitempty"`
+	TargetChainID string `json:",omitempty"`
+	Nonce         uint64 `json:",omitempty"`
+	SourceDomain  uint8
+	BlockNumber   uint64
+	TxHash        string
+	DispatchedAt  time.Time
+	Destinations  []uint8
+	Deliveries    map[uint8]*Delivery
+	Status        MessageStatus
+}
+
+// MessageStore tracks dispatched messages of the source domain until the
+// block they were dispatched in is delivered to every destination domain
+type MessageStore struct {
+	db   store.KeyValueReaderWriter
+	lock sync.Mutex
+}
+
+func NewMessageStore(db store.KeyValueReaderWriter) *MessageStore {
+	return &MessageStore{
+		db: db,
+	}
+}
+
+// DepositID returns the ID of the deposit to the destination domain with the nonce
+func DepositID(destinationDomainID uint8, nonce uint64) string {
+	return fmt.Sprintf("%d-%d", destinationDomainID, nonce)
+}
+
+// StoreMessage stores the dispatched message and adds it to pending messages of the source domain
+func (s *MessageStore) StoreMessage(message *Message) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	pending, err := s.pendingMessages(message.SourceDomain)
+	if err != nil {
+		return err
+	}
+	updatedPending := make([]*Message, 0, len(pending)+1)
+	for _, p := range pending {
+		if p.Type == message.Type && p.ID == message.ID {
+			continue
+		}
+		updatedPending = append(updatedPending, p)
+	}
+	updatedPending = append(updatedPending, message)
+
+	err = s.storeMessage(message)
+	if err != nil {
+		return err
+	}
+	err = s.indexTx(message)
+	if err != nil {
+		return err
+	}
+	return s.storePending(message.SourceDomain, updatedPending)
+}
+
+// DeliverMessages records the delivery to the destination domain of pending messages
+// dispatched up to the block number and returns the messages delivered by it
+func (s *MessageStore) DeliverMessages(sourceDomainID uint8, destinationDomainID uint8, delivery *Delivery) ([]*Message, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	pending, err := s.pendingMessages(sourceDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivered := make([]*Message, 0)
+	updatedPending := make([]*Message, 0, len(pending))
+	for _, message := range pending {
+		if message.BlockNumber > delivery.BlockNumber || !message.addressedTo(destinationDomainID) || message.Deliveries[destinationDomainID] != nil {
+			updatedPending = append(updatedPending, message)
+			continue
+		}
+
+		if message.Deliveries == nil {
+			message.Deliveries = make(map[uint8]*Delivery)
+		}
+		message.Deliveries[destinationDomainID] = delivery
+		if message.delivered() {
+			message.Status = DeliveredMessage
+		} else {
+			updatedPending = append(updatedPending, message)
+		}
+
+		err = s.storeMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		delivered = append(delivered, message)
+	}
+	if len(delivered) == 0 {
+		return delivered, nil
+	}
+
+	err = s.indexDeliveries(sourceDomainID, destinationDomainID, delivery.Slot, delivered)
+	if err != nil {
+		return nil, err
+	}
+	return delivered, s.storePending(sourceDomainID, updatedPending)
+}
+
+// RevokeDeliveries removes deliveries to the destination domain recorded for the step
+// of the slot, if its submission reverted or was dropped, and returns the messages
+// that are pending again
+func (s *MessageStore) RevokeDeliveries(sourceDomainID uint8, destinationDomainID uint8, slot uint64) ([]*Message, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	keys, err := s.messageKeys(deliveriesKey(sourceDomainID, destinationDomainID, slot))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return []*Message{}, nil
+		}
+		return nil, err
+	}
+	pending, err := s.pendingMessages(sourceDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked := make([]*Message, 0, len(keys))
+	for _, key := range keys {
+		message, err := s.Message(sourceDomainID, key.Type, key.ID)
+		if err != nil {
+			return nil, err
+		}
+		delivery := message.Deliveries[destinationDomainID]
+		if delivery == nil || delivery.Slot != slot {
+			continue
+		}
+
+		delete(message.Deliveries, destinationDomainID)
+		if message.Status == DeliveredMessage {
+			message.Status = DispatchedMessage
+		}
+		err = s.storeMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		pending = replaceMessage(pending, message)
+		revoked = append(revoked, message)
+	}
+
+	err = s.storeMessageKeys(deliveriesKey(sourceDomainID, destinationDomainID, slot), []txMessageKey{})
+	if err != nil {
+		return nil, err
+	}
+	if len(revoked) == 0 {
+		return revoked, nil
+	}
+	return revoked, s.storePending(sourceDomainID, pending)
+}
+
+// Message returns the message of the source domain with the message type and ID
+func (s *MessageStore) Message(sourceDomainID uint8, messageType MessageType, id string) (*Message, error) {
+	data, err := s.db.GetByKey(messageKey(sourceDomainID, messageType, id))
+	if err != nil {
+		return nil, err
+	}
+
+	message := &Message{}
+	err = json.Unmarshal(data, message)
+	return message, err
+}
+
+// MessagesByTx returns messages of the source domain sent in the transaction
+func (s *MessageStore) MessagesByTx(sourceDomainID uint8, txHash string) ([]*Message, error) {
+	keys, err := s.txMessageKeys(sourceDomainID, txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]*Message, len(keys))
+	for i, key := range keys {
+		messages[i], err = s.Message(sourceDomainID, key.Type, key.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return messages, nil
+}
+
+// PendingMessages returns messages of the source domain that are not yet delivered to every destination
+func (s *MessageStore) PendingMessages(sourceDomainID uint8) ([]*Message, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.pendingMessages(sourceDomainID)
+}
+
+type txMessageKey struct {
+	Type MessageType
+	ID   string
+}
+
+func (s *MessageStore) storeMessage(message *Message) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	return s.db.SetByKey(messageKey(message.SourceDomain, message.Type, message.ID), data)
+}
+
+// indexTx adds the message to messages sent in its transaction
+func (s *MessageStore) indexTx(message *Message) error {
+	keys, err := s.txMessageKeys(message.SourceDomain, message.TxHash)
+	if err != nil && !errors.Is(err, leveldb.ErrNotFound) {
+		return err
+	}
+	for _, key := range keys {
+		if key.Type == message.Type && key.ID == message.ID {
+			return nil
+		}
+	}
+	keys = append(keys, txMessageKey{Type: message.Type, ID: message.ID})
+	return s.storeMessageKeys(txMessagesKey(message.SourceDomain, message.TxHash), keys)
+}
+
+func (s *MessageStore) txMessageKeys(sourceDomainID uint8, txHash string) ([]txMessageKey, error) {
+	return s.messageKeys(txMessagesKey(sourceDomainID, txHash))
+}
+
+// indexDeliveries adds the messages to messages delivered by the step of the slot
+// so the deliveries can be revoked if the step fails
+func (s *MessageStore) indexDeliveries(sourceDomainID uint8, destinationDomainID uint8, slot uint64, messages []*Message) error {
+	key := deliveriesKey(sourceDomainID, destinationDomainID, slot)
+	keys, err := s.messageKeys(key)
+	if err != nil && !errors.Is(err, leveldb.ErrNotFound) {
+		return err
+	}
+	for _, message := range messages {
+		keys = append(keys, txMessageKey{Type: message.Type, ID: message.ID})
+	}
+	return s.storeMessageKeys(key, keys)
+}
+
+func (s *MessageStore) messageKeys(key []byte) ([]txMessageKey, error) {
+	data, err := s.db.GetByKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []txMessageKey
+	err = json.Unmarshal(data, &keys)
+	return keys, err
+}
+
+func (s *MessageStore) storeMessageKeys(key []byte, keys []txMessageKey) error {
+	data, err := json.Marshal(keys)
+	if err != nil {
+		return err
+	}
+	return s.db.SetByKey(key, data)
+}
+
+func (s *MessageStore) storePending(sourceDomainID uint8, pending []*Message) error {
+	data, err := json.Marshal(pending)
+	if err != nil {
+		return err
+	}
+	return s.db.SetByKey(pendingMessagesKey(sourceDomainID), data)
+}
+
+func (s *MessageStore) pendingMessages(sourceDomainID uint8) ([]*Message, error) {
+	data, err := s.db.GetByKey(pendingMessagesKey(sourceDomainID))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return []*Message{}, nil
+		}
+		return nil, err
+	}
+
+	var messages []*Message
+	err = json.Unmarshal(data, &messages)
+	return messages, err
+}
+
+// replaceMessage replaces the pending message with the same type and ID or adds it to pending messages
+func replaceMessage(pending []*Message, message *Message) []*Message {
+	for i, p := range pending {
+		if p.Type == message.Type && p.ID == message.ID {
+			pending[i] = message
+			return pending
+		}
+	}
+	return append(pending, message)
+}
+
+func (m *Message) addressedTo(destinationDomainID uint8) bool {
+	for _, destination := range m.Destinations {
+		if destination == destinationDomainID {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Message) delivered() bool {
+	for _, destination := range m.Destinations {
+		if m.Deliveries[destination] == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func messageKey(sourceDomainID uint8, messageType MessageType, id string) []byte {
+	return []byte(fmt.Sprintf("chain:%d:message:%s:%s", sourceDomainID, messageType, id))
+}
+
+func txMessagesKey(sourceDomainID uint8, txHash string) []byte {
+	return []byte(fmt.Sprintf("chain:%d:tx:%s:messages", sourceDomainID, strings.ToLower(txHash)))
+}
+
+func deliveriesKey(sourceDomainID uint8, destinationDomainID uint8, slot uint64) []byte {
+	return []byte(fmt.Sprintf("chain:%d:destination:%d:slot:%d:deliveries", sourceDomainID, destinationDomainID, slot))
+}
+
+func pendingMessagesKey(sourceDomainID uint8) []byte {
+	return []byte(fmt.Sprintf("chain:%d:messages:pending", sourceDomainID))
+}
@@ -0,0 +1,191 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/mock"
+	"github.com/sygmaprotocol/spectre-node/store"
+	"github.com/syndtr/goleveldb/leveldb"
+	"go.uber.org/mock/gomock"
+)
+
+type MessageStoreTestSuite struct {
+	suite.Suite
+	messageStore         *store.MessageStore
+	keyValueReaderWriter *mock.MockKeyValueReaderWriter
+	db                   map[string][]byte
+}
+
+func TestRunMessageStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(MessageStoreTestSuite))
+}
+
+func (s *MessageStoreTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.keyValueReaderWriter = mock.NewMockKeyValueReaderWriter(gomockController)
+	s.messageStore = store.NewMessageStore(s.keyValueReaderWriter)
+	s.db = make(map[string][]byte)
+}
+
+func (s *MessageStoreTestSuite) mockDB() {
+	s.keyValueReaderWriter.EXPECT().GetByKey(gomock.Any()).DoAndReturn(func(key []byte) ([]byte, error) {
+		value, ok := s.db[string(key)]
+		if !ok {
+			return nil, leveldb.ErrNotFound
+		}
+		return value, nil
+	}).AnyTimes()
+	s.keyValueReaderWriter.EXPECT().SetByKey(gomock.Any(), gomock.Any()).DoAndReturn(func(key []byte, value []byte) error {
+		s.db[string(key)] = value
+		return nil
+	}).AnyTimes()
+}
+
+func (s *MessageStoreTestSuite) Test_StoreMessage_FailedStore() {
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte("chain:1:messages:pending")).Return(nil, leveldb.ErrNotFound)
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte("chain:1:message:hashi:5"), gomock.Any()).Return(errors.New("error"))
+
+	err := s.messageStore.StoreMessage(&store.Message{Type: store.HashiMessageType, ID: "5", SourceDomain: 1})
+
+	s.NotNil(err)
+}
+
+func (s *MessageStoreTestSuite) Test_StoreMessage_AddedToPending() {
+	s.mockDB()
+
+	err := s.messageStore.StoreMessage(&store.Message{Type: store.HashiMessageType, ID: "5", SourceDomain: 1, TxHash: "0xAB", Status: store.DispatchedMessage})
+	s.Nil(err)
+	err = s.messageStore.StoreMessage(&store.Message{Type: store.HashiMessageType, ID: "5", SourceDomain: 1, TxHash: "0xAB", Status: store.DispatchedMessage})
+	s.Nil(err)
+
+	message, err := s.messageStore.Message(1, store.HashiMessageType, "5")
+	s.Nil(err)
+	s.Equal(message.Status, store.DispatchedMessage)
+	pending, err := s.messageStore.PendingMessages(1)
+	s.Nil(err)
+	s.Len(pending, 1)
+}
+
+func (s *MessageStoreTestSuite) Test_DeliverMessages_DeliveredToEveryDestination() {
+	s.mockDB()
+	err := s.messageStore.StoreMessage(&store.Message{Type: store.HashiMessageType, ID: "5", SourceDomain: 1, BlockNumber: 100, Destinations: []uint8{2, 3}})
+	s.Nil(err)
+	err = s.messageStore.StoreMessage(&store.Message{Type: store.HashiMessageType, ID: "6", SourceDomain: 1, BlockNumber: 200, Destinations: []uint8{2, 3}})
+	s.Nil(err)
+
+	delivered, err := s.messageStore.DeliverMessages(1, 2, &store.Delivery{BlockNumber: 150, Slot: 10})
+	s.Nil(err)
+	s.Len(delivered, 1)
+	s.Equal(delivered[0].ID, "5")
+	s.Equal(delivered[0].Status, store.MessageStatus(""))
+
+	// repeated deliveries to the same destination are ignored
+	delivered, err = s.messageStore.DeliverMessages(1, 2, &store.Delivery{BlockNumber: 150, Slot: 11})
+	s.Nil(err)
+	s.Len(delivered, 0)
+
+	delivered, err = s.messageStore.DeliverMessages(1, 3, &store.Delivery{BlockNumber: 150, Slot: 10})
+	s.Nil(err)
+	s.Len(delivered, 1)
+	s.Equal(delivered[0].Status, store.DeliveredMessage)
+
+	message, err := s.messageStore.Message(1, store.HashiMessageType, "5")
+	s.Nil(err)
+	s.Equal(message.Status, store.DeliveredMessage)
+	s.Equal(message.Deliveries[2].Slot, uint64(10))
+	pending, err := s.messageStore.PendingMessages(1)
+	s.Nil(err)
+	s.Len(pending, 1)
+	s.Equal(pending[0].ID, "6")
+}
+
+func (s *MessageStoreTestSuite) Test_DeliverMessages_OtherDestinationSkipped() {
+	s.mockDB()
+	err := s.messageStore.StoreMessage(&store.Message{Type: store.DepositMessageType, ID: "3-1", SourceDomain: 1, BlockNumber: 100, Destinations: []uint8{3}})
+	s.Nil(err)
+
+	delivered, err := s.messageStore.DeliverMessages(1, 2, &store.Delivery{BlockNumber: 150, Slot: 10})
+	s.Nil(err)
+	s.Len(delivered, 0)
+
+	message, err := s.messageStore.Message(1, store.DepositMessageType, "3-1")
+	s.Nil(err)
+	s.Nil(message.Deliveries[2])
+	pending, err := s.messageStore.PendingMessages(1)
+	s.Nil(err)
+	s.Len(pending, 1)
+}
+
+func (s *MessageStoreTestSuite) Test_RevokeDeliveries_NoDeliveries() {
+	s.mockDB()
+
+	revoked, err := s.messageStore.RevokeDeliveries(1, 2, 10)
+
+	s.Nil(err)
+	s.Len(revoked, 0)
+}
+
+func (s *MessageStoreTestSuite) Test_RevokeDeliveries_PendingAgain() {
+	s.mockDB()
+	err := s.messageStore.StoreMessage(&store.Message{Type: store.DepositMessageType, ID: "2-1", SourceDomain: 1, BlockNumber: 100, Destinations: []uint8{2}, Status: store.DispatchedMessage})
+	s.Nil(err)
+	err = s.messageStore.StoreMessage(&store.Message{Type: store.HashiMessageType, ID: "5", SourceDomain: 1, BlockNumber: 100, Destinations: []uint8{2, 3}, Status: store.DispatchedMessage})
+	s.Nil(err)
+	delivered, err := s.messageStore.DeliverMessages(1, 2, &store.Delivery{BlockNumber: 150, Slot: 10})
+	s.Nil(err)
+	s.Len(delivered, 2)
+	pending, err := s.messageStore.PendingMessages(1)
+	s.Nil(err)
+	s.Len(pending, 1)
+
+	revoked, err := s.messageStore.RevokeDeliveries(1, 2, 10)
+	s.Nil(err)
+	s.Len(revoked, 2)
+
+	deposit, err := s.messageStore.Message(1, store.DepositMessageType, "2-1")
+	s.Nil(err)
+	s.Equal(deposit.Status, store.DispatchedMessage)
+	s.Nil(deposit.Deliveries[2])
+	pending, err = s.messageStore.PendingMessages(1)
+	s.Nil(err)
+	s.Len(pending, 2)
+	for _, message := range pending {
+		s.Nil(message.Deliveries[2])
+	}
+
+	// messages are delivered again by the retried step
+	delivered, err = s.messageStore.DeliverMessages(1, 2, &store.Delivery{BlockNumber: 150, Slot: 10})
+	s.Nil(err)
+	s.Len(delivered, 2)
+}
+
+func (s *MessageStoreTestSuite) Test_MessagesByTx_NotFound() {
+	s.mockDB()
+
+	_, err := s.messageStore.MessagesByTx(1, "0xab")
+
+	s.ErrorIs(err, leveldb.ErrNotFound)
+}
+
+func (s *MessageStoreTestSuite) Test_MessagesByTx_IndexesEveryMessageOfTheTx() {
+	s.mockDB()
+	err := s.messageStore.StoreMessage(&store.Message{Type: store.HashiMessageType, ID: "5", SourceDomain: 1, TxHash: "0xAB"})
+	s.Nil(err)
+	err = s.messageStore.StoreMessage(&store.Message{Type: store.DepositMessageType, ID: store.DepositID(2, 7), Nonce: 7, SourceDomain: 1, TxHash: "0xAB"})
+	s.Nil(err)
+	err = s.messageStore.StoreMessage(&store.Message{Type: store.HashiMessageType, ID: "6", SourceDomain: 1, TxHash: "0xCD"})
+	s.Nil(err)
+
+	messages, err := s.messageStore.MessagesByTx(1, "0xab")
+
+	s.Nil(err)
+	s.Len(messages, 2)
+	s.Equal(messages[0].ID, "5")
+	s.Equal(messages[1].Type, store.DepositMessageType)
+	s.Equal(messages[1].Nonce, uint64(7))
+}
@@ -0,0 +1,194 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sygmaprotocol/sygma-core/store"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+type cacheEntry struct {
+	Expiry time.Time
+	Value  json.RawMessage
+}
+
+type keyLock struct {
+	sync.Mutex
+	refs int
+}
+
+// ProofCache caches generated proofs in memory and in the key value store
+// so they can be reused until their TTL expires. The expiry of every stored
+// proof is kept in an index so expired proofs are cleared from the store.
+type ProofCache struct {
+	db  store.KeyValueReaderWriter
+	ttl time.Duration
+
+	entries  map[string]cacheEntry
+	keyLocks map[string]*keyLock
+	lock     sync.Mutex
+}
+
+func NewProofCache(db store.KeyValueReaderWriter, ttl time.Duration) *ProofCache {
+	return &ProofCache{
+		db:       db,
+		ttl:      ttl,
+		entries:  make(map[string]cacheEntry),
+		keyLocks: make(map[string]*keyLock),
+	}
+}
+
+// Proof decodes the cached proof for the key into proof and returns
+// false if there is no valid cached proof
+func (c *ProofCache) Proof(key string, proof interface{}) (bool, error) {
+	c.lock.Lock()
+	entry, ok := c.entries[key]
+	c.lock.Unlock()
+
+	if !ok {
+		data, err := c.db.GetByKey(cacheKey(key))
+		if err != nil {
+			if errors.Is(err, leveldb.ErrNotFound) {
+				return false, nil
+			}
+			return false, err
+		}
+
+		// cleared entries are stored empty
+		if len(data) == 0 {
+			return false, nil
+		}
+		err = json.Unmarshal(data, &entry)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if time.Now().After(entry.Expiry) {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		delete(c.entries, key)
+		return false, c.db.SetByKey(cacheKey(key), []byte{})
+	}
+
+	c.lock.Lock()
+	c.entries[key] = entry
+	c.lock.Unlock()
+	return true, json.Unmarshal(entry.Value, proof)
+}
+
+// StoreProof caches the proof under the key for the configured TTL and
+// clears expired proofs from the store
+func (c *ProofCache) StoreProof(key string, proof interface{}) error {
+	value, err := json.Marshal(proof)
+	if err != nil {
+		return err
+	}
+	entry := cacheEntry{
+		Expiry: time.Now().Add(c.ttl),
+		Value:  value,
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	err = c.db.SetByKey(cacheKey(key), data)
+	if err != nil {
+		return err
+	}
+
+	for k, e := range c.entries {
+		if time.Now().After(e.Expiry) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = entry
+
+	index, err := c.index()
+	if err != nil {
+		return err
+	}
+	index[key] = entry.Expiry
+	return c.prune(index)
+}
+
+// prune clears expired proofs of the index from the store and stores the
+// index of the remaining proofs
+func (c *ProofCache) prune(index map[string]time.Time) error {
+	for k, expiry := range index {
+		if time.Now().Before(expiry) {
+			continue
+		}
+
+		// the store can't delete keys so the entries of expired proofs are cleared
+		err := c.db.SetByKey(cacheKey(k), []byte{})
+		if err != nil {
+			return err
+		}
+		delete(index, k)
+	}
+
+	data, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	return c.db.SetByKey(cacheIndexKey(), data)
+}
+
+func (c *ProofCache) index() (map[string]time.Time, error) {
+	index := make(map[string]time.Time)
+	data, err := c.db.GetByKey(cacheIndexKey())
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return index, nil
+		}
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &index)
+	return index, err
+}
+
+// Lock locks the key so concurrent callers generating the same proof wait
+// for the first one to cache it. The returned function releases the lock.
+func (c *ProofCache) Lock(key string) func() {
+	c.lock.Lock()
+	l, ok := c.keyLocks[key]
+	if !ok {
+		l = &keyLock{}
+		c.keyLocks[key] = l
+	}
+	l.refs++
+	c.lock.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+
+		c.lock.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(c.keyLocks, key)
+		}
+		c.lock.Unlock()
+	}
+}
+
+func cacheKey(key string) []byte {
+	return []byte(fmt.Sprintf("proofcache:%s", key))
+}
+
+func cacheIndexKey() []byte {
+	return []byte("proofcache:index")
+}
@@ -0,0 +1,132 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/mock"
+	"github.com/sygmaprotocol/spectre-node/store"
+	"github.com/syndtr/goleveldb/leveldb"
+	"go.uber.org/mock/gomock"
+)
+
+type ProofCacheTestSuite struct {
+	suite.Suite
+	proofCache           *store.ProofCache
+	keyValueReaderWriter *mock.MockKeyValueReaderWriter
+}
+
+func TestRunProofCacheTestSuite(t *testing.T) {
+	suite.Run(t, new(ProofCacheTestSuite))
+}
+
+func (s *ProofCacheTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.keyValueReaderWriter = mock.NewMockKeyValueReaderWriter(gomockController)
+	s.proofCache = store.NewProofCache(s.keyValueReaderWriter, time.Hour)
+}
+
+func (s *ProofCacheTestSuite) Test_Proof_NotCached() {
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte("proofcache:key")).Return(nil, leveldb.ErrNotFound)
+
+	var proof []byte
+	cached, err := s.proofCache.Proof("key", &proof)
+
+	s.Nil(err)
+	s.False(cached)
+}
+
+func (s *ProofCacheTestSuite) Test_Proof_FailedFetch() {
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte("proofcache:key")).Return(nil, errors.New("error"))
+
+	var proof []byte
+	_, err := s.proofCache.Proof("key", &proof)
+
+	s.NotNil(err)
+}
+
+func (s *ProofCacheTestSuite) Test_Proof_CachedInMemory() {
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte("proofcache:key"), gomock.Any()).Return(nil)
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte("proofcache:index")).Return(nil, leveldb.ErrNotFound)
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte("proofcache:index"), gomock.Any()).Return(nil)
+	err := s.proofCache.StoreProof("key", []byte{1, 2})
+	s.Nil(err)
+
+	var proof []byte
+	cached, err := s.proofCache.Proof("key", &proof)
+
+	s.Nil(err)
+	s.True(cached)
+	s.Equal(proof, []byte{1, 2})
+}
+
+func (s *ProofCacheTestSuite) Test_Proof_PersistedEntry() {
+	value, _ := json.Marshal([]byte{1, 2})
+	entry, _ := json.Marshal(map[string]interface{}{
+		"Expiry": time.Now().Add(time.Minute),
+		"Value":  json.RawMessage(value),
+	})
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte("proofcache:key")).Return(entry, nil)
+
+	var proof []byte
+	cached, err := s.proofCache.Proof("key", &proof)
+
+	s.Nil(err)
+	s.True(cached)
+	s.Equal(proof, []byte{1, 2})
+}
+
+func (s *ProofCacheTestSuite) Test_Proof_ExpiredEntry() {
+	value, _ := json.Marshal([]byte{1, 2})
+	entry, _ := json.Marshal(map[string]interface{}{
+		"Expiry": time.Now().Add(-time.Minute),
+		"Value":  json.RawMessage(value),
+	})
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte("proofcache:key")).Return(entry, nil)
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte("proofcache:key"), []byte{}).Return(nil)
+
+	var proof []byte
+	cached, err := s.proofCache.Proof("key", &proof)
+
+	s.Nil(err)
+	s.False(cached)
+}
+
+func (s *ProofCacheTestSuite) Test_Proof_ClearedEntry() {
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte("proofcache:key")).Return([]byte{}, nil)
+
+	var proof []byte
+	cached, err := s.proofCache.Proof("key", &proof)
+
+	s.Nil(err)
+	s.False(cached)
+}
+
+func (s *ProofCacheTestSuite) Test_StoreProof_ExpiredEntriesCleared() {
+	index, _ := json.Marshal(map[string]time.Time{
+		"expired": time.Now().Add(-time.Minute),
+		"valid":   time.Now().Add(time.Minute),
+	})
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte("proofcache:key"), gomock.Any()).Return(nil)
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte("proofcache:index")).Return(index, nil)
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte("proofcache:expired"), []byte{}).Return(nil)
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte("proofcache:index"), gomock.Any()).DoAndReturn(func(key []byte, value []byte) error {
+		var stored map[string]time.Time
+		err := json.Unmarshal(value, &stored)
+		s.Nil(err)
+		s.Equal(len(stored), 2)
+		s.Contains(stored, "key")
+		s.Contains(stored, "valid")
+		return nil
+	})
+
+	err := s.proofCache.StoreProof("key", []byte{1, 2})
+
+	s.Nil(err)
+}
@@ -0,0 +1,127 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/message"
+	"github.com/sygmaprotocol/sygma-core/store"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+type ProofType string
+
+const (
+	StepProofType   ProofType = "step"
+	RotateProofType ProofType = "rotate"
+)
+
+var ErrProofNotFound = errors.New("proof not found")
+
+// ProofRecord is an archived proof together with the prover input
+// it was generated from
+type ProofRecord struct {
+	Type         ProofType
+	SourceDomain uint8
+	Slot         uint64
+	Period       uint64
+
+	// Spec, Domain, Pubkeys and Update are the prover arguments, the update
+	// being the SSZ encoded light client update
+	Spec    string
+	Domain  [32]byte
+	Pubkeys []byte
+	Update  []byte
+
+	Step   *message.StepData   `json:",omitempty"`
+	Rotate *message.RotateData `json:",omitempty"`
+}
+
+type ProofStore struct {
+	db store.KeyValueReaderWriter
+}
+
+func NewProofStore(db store.KeyValueReaderWriter) *ProofStore {
+	return &ProofStore{
+		db: db,
+	}
+}
+
+// StoreProof stores the proof record under its content hash and indexes it
+// by source domain and slot for steps or period for rotations
+func (s *ProofStore) StoreProof(record *ProofRecord) (common.Hash, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	hash := crypto.Keccak256Hash(data)
+
+	err = s.db.SetByKey(proofKey(hash), data)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	err = s.db.SetByKey(indexKey(record.Type, record.SourceDomain, record.Slot, record.Period), hash.Bytes())
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return hash, nil
+}
+
+// Proof returns the proof record stored under the content hash
+func (s *ProofStore) Proof(hash common.Hash) (*ProofRecord, error) {
+	data, err := s.db.GetByKey(proofKey(hash))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return nil, ErrProofNotFound
+		}
+		return nil, err
+	}
+
+	record := &ProofRecord{}
+	err = json.Unmarshal(data, record)
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// StepProof returns the latest step proof stored for the source domain and slot
+func (s *ProofStore) StepProof(domainID uint8, slot uint64) (*ProofRecord, error) {
+	return s.indexedProof(indexKey(StepProofType, domainID, slot, 0))
+}
+
+// RotateProof returns the latest rotate proof stored for the source domain and period
+func (s *ProofStore) RotateProof(domainID uint8, period uint64) (*ProofRecord, error) {
+	return s.indexedProof(indexKey(RotateProofType, domainID, 0, period))
+}
+
+func (s *ProofStore) indexedProof(key []byte) (*ProofRecord, error) {
+	hash, err := s.db.GetByKey(key)
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return nil, ErrProofNotFound
+		}
+		return nil, err
+	}
+
+	return s.Proof(common.BytesToHash(hash))
+}
+
+func proofKey(hash common.Hash) []byte {
+	return []byte(fmt.Sprintf("proof:%s", hash.Hex()))
+}
+
+func indexKey(proofType ProofType, domainID uint8, slot uint64, period uint64) []byte {
+	if proofType == RotateProofType {
+		return []byte(fmt.Sprintf("chain:%d:period:%d:proof", domainID, period))
+	}
+	return []byte(fmt.Sprintf("chain:%d:slot:%d:proof", domainID, slot))
+}
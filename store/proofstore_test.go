@@ -0,0 +1,106 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/chains/evm/message"
+	"github.com/sygmaprotocol/spectre-node/mock"
+	"github.com/sygmaprotocol/spectre-node/store"
+	"github.com/syndtr/goleveldb/leveldb"
+	"go.uber.org/mock/gomock"
+)
+
+type ProofStoreTestSuite struct {
+	suite.Suite
+	proofStore           *store.ProofStore
+	keyValueReaderWriter *mock.MockKeyValueReaderWriter
+}
+
+func TestRunProofStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(ProofStoreTestSuite))
+}
+
+func (s *ProofStoreTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.keyValueReaderWriter = mock.NewMockKeyValueReaderWriter(gomockController)
+	s.proofStore = store.NewProofStore(s.keyValueReaderWriter)
+}
+
+func (s *ProofStoreTestSuite) Test_StoreProof_FailedStore() {
+	s.keyValueReaderWriter.EXPECT().SetByKey(gomock.Any(), gomock.Any()).Return(errors.New("error"))
+
+	_, err := s.proofStore.StoreProof(&store.ProofRecord{
+		Type: store.StepProofType,
+	})
+
+	s.NotNil(err)
+}
+
+func (s *ProofStoreTestSuite) Test_StoreProof_StepIndexedBySlot() {
+	record := &store.ProofRecord{
+		Type:         store.StepProofType,
+		SourceDomain: 1,
+		Slot:         100,
+		Step:         &message.StepData{Proof: []byte{1}},
+	}
+	data, _ := json.Marshal(record)
+	hash := crypto.Keccak256Hash(data)
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte("proof:"+hash.Hex()), data).Return(nil)
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte("chain:1:slot:100:proof"), hash.Bytes()).Return(nil)
+
+	storedHash, err := s.proofStore.StoreProof(record)
+
+	s.Nil(err)
+	s.Equal(storedHash, hash)
+}
+
+func (s *ProofStoreTestSuite) Test_StoreProof_RotateIndexedByPeriod() {
+	record := &store.ProofRecord{
+		Type:         store.RotateProofType,
+		SourceDomain: 1,
+		Slot:         100,
+		Period:       5,
+		Rotate:       &message.RotateData{RotateProof: []byte{1}},
+	}
+	data, _ := json.Marshal(record)
+	hash := crypto.Keccak256Hash(data)
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte("proof:"+hash.Hex()), data).Return(nil)
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte("chain:1:period:5:proof"), hash.Bytes()).Return(nil)
+
+	_, err := s.proofStore.StoreProof(record)
+
+	s.Nil(err)
+}
+
+func (s *ProofStoreTestSuite) Test_StepProof_NotFound() {
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte("chain:1:slot:100:proof")).Return(nil, leveldb.ErrNotFound)
+
+	_, err := s.proofStore.StepProof(1, 100)
+
+	s.ErrorIs(err, store.ErrProofNotFound)
+}
+
+func (s *ProofStoreTestSuite) Test_RotateProof_SuccessfulFetch() {
+	record := &store.ProofRecord{
+		Type:         store.RotateProofType,
+		SourceDomain: 1,
+		Period:       5,
+		Rotate:       &message.RotateData{RotateProof: []byte{1}},
+	}
+	data, _ := json.Marshal(record)
+	hash := crypto.Keccak256Hash(data)
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte("chain:1:period:5:proof")).Return(hash.Bytes(), nil)
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte("proof:"+hash.Hex())).Return(data, nil)
+
+	storedRecord, err := s.proofStore.RotateProof(1, 5)
+
+	s.Nil(err)
+	s.Equal(storedRecord, record)
+}
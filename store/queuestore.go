@@ -0,0 +1,264 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sygmaprotocol/sygma-core/store"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// QueuedMessage is a message waiting to be delivered to the destination domain
+type QueuedMessage struct {
+	Seq         uint64
+	ID          string
+	Source      uint8
+	Destination uint8
+	Type        string
+	Data        json.RawMessage `json:",omitempty"`
+	Attempts    int
+	LastError   string `json:",omitempty"`
+	EnqueuedAt  time.Time
+	NextAttempt time.Time
+}
+
+type queueIndex struct {
+	Next    uint64
+	Pending []uint64
+	// Requeued is the number of requeued messages at the head of Pending
+	Requeued int `json:",omitempty"`
+}
+
+// QueueStore persists the outbound messages of every destination domain until
+// they are acknowledged or dead-lettered
+type QueueStore struct {
+	db   store.KeyValueReaderWriter
+	lock sync.Mutex
+}
+
+func NewQueueStore(db store.KeyValueReaderWriter) *QueueStore {
+	return &QueueStore{
+		db: db,
+	}
+}
+
+// Enqueue stores the message at the tail of the destination queue and sets its sequence number
+func (s *QueueStore) Enqueue(msg *QueuedMessage) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	index, err := s.index(msg.Destination)
+	if err != nil {
+		return err
+	}
+
+	msg.Seq = index.Next
+	err = s.storeMessage(msg)
+	if err != nil {
+		return err
+	}
+
+	index.Next++
+	index.Pending = append(index.Pending, msg.Seq)
+	return s.storeIndex(msg.Destination, index)
+}
+
+// Requeue stores the message at the head of the destination queue, behind messages
+// that were requeued before it, and sets its sequence number
+func (s *QueueStore) Requeue(msg *QueuedMessage) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	index, err := s.index(msg.Destination)
+	if err != nil {
+		return err
+	}
+
+	msg.Seq = index.Next
+	err = s.storeMessage(msg)
+	if err != nil {
+		return err
+	}
+
+	index.Next++
+	pending := make([]uint64, 0, len(index.Pending)+1)
+	pending = append(pending, index.Pending[:index.Requeued]...)
+	pending = append(pending, msg.Seq)
+	index.Pending = append(pending, index.Pending[index.Requeued:]...)
+	index.Requeued++
+	return s.storeIndex(msg.Destination, index)
+}
+
+// UpdateMessage stores the delivery attempts of a queued message
+func (s *QueueStore) UpdateMessage(msg *QueuedMessage) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.storeMessage(msg)
+}
+
+// Pending returns the messages of the destination queue in the order they were enqueued
+func (s *QueueStore) Pending(destinationDomainID uint8) ([]*QueuedMessage, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	index, err := s.index(destinationDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := make([]*QueuedMessage, 0, len(index.Pending))
+	for _, seq := range index.Pending {
+		data, err := s.db.GetByKey(queuedMessageKey(destinationDomainID, seq))
+		if err != nil {
+			return nil, err
+		}
+
+		msg := &QueuedMessage{}
+		err = json.Unmarshal(data, msg)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+// Depth returns the number of messages waiting in the destination queue
+func (s *QueueStore) Depth(destinationDomainID uint8) (int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	index, err := s.index(destinationDomainID)
+	if err != nil {
+		return 0, err
+	}
+	return len(index.Pending), nil
+}
+
+// Ack removes the delivered message from the destination queue
+func (s *QueueStore) Ack(destinationDomainID uint8, seq uint64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.remove(destinationDomainID, seq)
+}
+
+// DeadLetter removes the message from the destination queue and adds it to
+// the dead letters of the destination
+func (s *QueueStore) DeadLetter(msg *QueuedMessage) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	deadLetters, err := s.deadLetters(msg.Destination)
+	if err != nil {
+		return err
+	}
+	deadLetters = append(deadLetters, msg)
+	data, err := json.Marshal(deadLetters)
+	if err != nil {
+		return err
+	}
+	err = s.db.SetByKey(deadLettersKey(msg.Destination), data)
+	if err != nil {
+		return err
+	}
+
+	return s.remove(msg.Destination, msg.Seq)
+}
+
+// DeadLetters returns messages to the destination domain that were dropped after exhausting their attempts
+func (s *QueueStore) DeadLetters(destinationDomainID uint8) ([]*QueuedMessage, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.deadLetters(destinationDomainID)
+}
+
+func (s *QueueStore) remove(destinationDomainID uint8, seq uint64) error {
+	index, err := s.index(destinationDomainID)
+	if err != nil {
+		return err
+	}
+
+	pending := make([]uint64, 0, len(index.Pending))
+	for i, p := range index.Pending {
+		if p == seq {
+			if i < index.Requeued {
+				index.Requeued--
+			}
+			continue
+		}
+		pending = append(pending, p)
+	}
+	index.Pending = pending
+	err = s.storeIndex(destinationDomainID, index)
+	if err != nil {
+		return err
+	}
+
+	// the store can't delete keys so the payload of removed messages is cleared
+	return s.db.SetByKey(queuedMessageKey(destinationDomainID, seq), []byte{})
+}
+
+func (s *QueueStore) storeMessage(msg *QueuedMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return s.db.SetByKey(queuedMessageKey(msg.Destination, msg.Seq), data)
+}
+
+func (s *QueueStore) index(destinationDomainID uint8) (*queueIndex, error) {
+	data, err := s.db.GetByKey(queueIndexKey(destinationDomainID))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return &queueIndex{Pending: []uint64{}}, nil
+		}
+		return nil, err
+	}
+
+	index := &queueIndex{}
+	err = json.Unmarshal(data, index)
+	return index, err
+}
+
+func (s *QueueStore) storeIndex(destinationDomainID uint8, index *queueIndex) error {
+	data, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	return s.db.SetByKey(queueIndexKey(destinationDomainID), data)
+}
+
+func (s *QueueStore) deadLetters(destinationDomainID uint8) ([]*QueuedMessage, error) {
+	data, err := s.db.GetByKey(deadLettersKey(destinationDomainID))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return []*QueuedMessage{}, nil
+		}
+		return nil, err
+	}
+
+	var msgs []*QueuedMessage
+	err = json.Unmarshal(data, &msgs)
+	return msgs, err
+}
+
+func queueIndexKey(destinationDomainID uint8) []byte {
+	return []byte(fmt.Sprintf("chain:%d:queue", destinationDomainID))
+}
+
+func queuedMessageKey(destinationDomainID uint8, seq uint64) []byte {
+	return []byte(fmt.Sprintf("chain:%d:queue:%d", destinationDomainID, seq))
+}
+
+func deadLettersKey(destinationDomainID uint8) []byte {
+	return []byte(fmt.Sprintf("chain:%d:queue:deadletters", destinationDomainID))
+}
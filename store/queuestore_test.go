@@ -0,0 +1,169 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/mock"
+	"github.com/sygmaprotocol/spectre-node/store"
+	"github.com/syndtr/goleveldb/leveldb"
+	"go.uber.org/mock/gomock"
+)
+
+type QueueStoreTestSuite struct {
+	suite.Suite
+	queueStore           *store.QueueStore
+	keyValueReaderWriter *mock.MockKeyValueReaderWriter
+	db                   map[string][]byte
+}
+
+func TestRunQueueStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(QueueStoreTestSuite))
+}
+
+func (s *QueueStoreTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.keyValueReaderWriter = mock.NewMockKeyValueReaderWriter(gomockController)
+	s.queueStore = store.NewQueueStore(s.keyValueReaderWriter)
+	s.db = make(map[string][]byte)
+}
+
+func (s *QueueStoreTestSuite) mockDB() {
+	s.keyValueReaderWriter.EXPECT().GetByKey(gomock.Any()).DoAndReturn(func(key []byte) ([]byte, error) {
+		value, ok := s.db[string(key)]
+		if !ok {
+			return nil, leveldb.ErrNotFound
+		}
+		return value, nil
+	}).AnyTimes()
+	s.keyValueReaderWriter.EXPECT().SetByKey(gomock.Any(), gomock.Any()).DoAndReturn(func(key []byte, value []byte) error {
+		s.db[string(key)] = value
+		return nil
+	}).AnyTimes()
+}
+
+func (s *QueueStoreTestSuite) Test_Enqueue_FailedStore() {
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte("chain:2:queue")).Return(nil, leveldb.ErrNotFound)
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte("chain:2:queue:0"), gomock.Any()).Return(errors.New("error"))
+
+	err := s.queueStore.Enqueue(&store.QueuedMessage{Destination: 2})
+
+	s.NotNil(err)
+}
+
+func (s *QueueStoreTestSuite) Test_Pending_EmptyQueue() {
+	s.mockDB()
+
+	pending, err := s.queueStore.Pending(2)
+
+	s.Nil(err)
+	s.Equal(len(pending), 0)
+}
+
+func (s *QueueStoreTestSuite) Test_Enqueue_PendingInOrder() {
+	s.mockDB()
+
+	s.Nil(s.queueStore.Enqueue(&store.QueuedMessage{Destination: 2, ID: "first", Data: []byte(`{"Proof":"AQ=="}`)}))
+	s.Nil(s.queueStore.Enqueue(&store.QueuedMessage{Destination: 2, ID: "second"}))
+	s.Nil(s.queueStore.Enqueue(&store.QueuedMessage{Destination: 3, ID: "other"}))
+
+	pending, err := s.queueStore.Pending(2)
+	s.Nil(err)
+	s.Equal(len(pending), 2)
+	s.Equal(pending[0].ID, "first")
+	s.Equal(pending[0].Seq, uint64(0))
+	s.Equal(string(pending[0].Data), `{"Proof":"AQ=="}`)
+	s.Equal(pending[1].ID, "second")
+	s.Equal(pending[1].Seq, uint64(1))
+	depth, err := s.queueStore.Depth(3)
+	s.Nil(err)
+	s.Equal(depth, 1)
+}
+
+func (s *QueueStoreTestSuite) Test_Ack_RemovedFromPending() {
+	s.mockDB()
+	s.Nil(s.queueStore.Enqueue(&store.QueuedMessage{Destination: 2, ID: "first"}))
+	s.Nil(s.queueStore.Enqueue(&store.QueuedMessage{Destination: 2, ID: "second"}))
+
+	err := s.queueStore.Ack(2, 0)
+	s.Nil(err)
+	s.Nil(s.queueStore.Enqueue(&store.QueuedMessage{Destination: 2, ID: "third"}))
+
+	pending, err := s.queueStore.Pending(2)
+	s.Nil(err)
+	s.Equal(len(pending), 2)
+	s.Equal(pending[0].ID, "second")
+	s.Equal(pending[1].ID, "third")
+	s.Equal(pending[1].Seq, uint64(2))
+}
+
+func (s *QueueStoreTestSuite) Test_Requeue_AheadOfEnqueuedInOrder() {
+	s.mockDB()
+	s.Nil(s.queueStore.Enqueue(&store.QueuedMessage{Destination: 2, ID: "first"}))
+	s.Nil(s.queueStore.Requeue(&store.QueuedMessage{Destination: 2, ID: "requeued"}))
+	s.Nil(s.queueStore.Requeue(&store.QueuedMessage{Destination: 2, ID: "requeued-later"}))
+	s.Nil(s.queueStore.Enqueue(&store.QueuedMessage{Destination: 2, ID: "second"}))
+
+	pending, err := s.queueStore.Pending(2)
+	s.Nil(err)
+	s.Equal(len(pending), 4)
+	s.Equal(pending[0].ID, "requeued")
+	s.Equal(pending[0].Seq, uint64(1))
+	s.Equal(pending[1].ID, "requeued-later")
+	s.Equal(pending[2].ID, "first")
+	s.Equal(pending[3].ID, "second")
+}
+
+func (s *QueueStoreTestSuite) Test_Requeue_AfterRequeuedAcked() {
+	s.mockDB()
+	s.Nil(s.queueStore.Enqueue(&store.QueuedMessage{Destination: 2, ID: "first"}))
+	s.Nil(s.queueStore.Requeue(&store.QueuedMessage{Destination: 2, ID: "requeued"}))
+	s.Nil(s.queueStore.Ack(2, 1))
+
+	s.Nil(s.queueStore.Requeue(&store.QueuedMessage{Destination: 2, ID: "requeued-later"}))
+
+	pending, err := s.queueStore.Pending(2)
+	s.Nil(err)
+	s.Equal(len(pending), 2)
+	s.Equal(pending[0].ID, "requeued-later")
+	s.Equal(pending[1].ID, "first")
+}
+
+func (s *QueueStoreTestSuite) Test_UpdateMessage_AttemptsStored() {
+	s.mockDB()
+	msg := &store.QueuedMessage{Destination: 2, ID: "first"}
+	s.Nil(s.queueStore.Enqueue(msg))
+
+	msg.Attempts = 3
+	msg.LastError = "error"
+	err := s.queueStore.UpdateMessage(msg)
+	s.Nil(err)
+
+	pending, err := s.queueStore.Pending(2)
+	s.Nil(err)
+	s.Equal(pending[0].Attempts, 3)
+	s.Equal(pending[0].LastError, "error")
+}
+
+func (s *QueueStoreTestSuite) Test_DeadLetter_MovedFromPending() {
+	s.mockDB()
+	msg := &store.QueuedMessage{Destination: 2, ID: "first"}
+	s.Nil(s.queueStore.Enqueue(msg))
+	s.Nil(s.queueStore.Enqueue(&store.QueuedMessage{Destination: 2, ID: "second"}))
+
+	err := s.queueStore.DeadLetter(msg)
+	s.Nil(err)
+
+	pending, err := s.queueStore.Pending(2)
+	s.Nil(err)
+	s.Equal(len(pending), 1)
+	s.Equal(pending[0].ID, "second")
+	deadLetters, err := s.queueStore.DeadLetters(2)
+	s.Nil(err)
+	s.Equal(len(deadLetters), 1)
+	s.Equal(deadLetters[0].ID, "first")
+}
@@ -0,0 +1,132 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/sygmaprotocol/sygma-core/store"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// SpectreStore indexes events emitted by the Spectre contracts on destination domains
+type SpectreStore struct {
+	db store.KeyValueReaderWriter
+}
+
+func NewSpectreStore(db store.KeyValueReaderWriter) *SpectreStore {
+	return &SpectreStore{
+		db: db,
+	}
+}
+
+// StoreStateRoot stores the state root submitted on the destination domain for the source slot
+// and updates the latest submitted slot
+func (s *SpectreStore) StoreStateRoot(destinationDomainID uint8, sourceDomainID uint8, slot uint64, stateRoot [32]byte) error {
+	err := s.db.SetByKey(
+		[]byte(fmt.Sprintf("chain:%d:source:%d:slot:%d:stateroot", destinationDomainID, sourceDomainID, slot)),
+		stateRoot[:],
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.storeLatest(fmt.Sprintf("chain:%d:source:%d:stateroot:latest", destinationDomainID, sourceDomainID), slot)
+}
+
+// StateRoot returns the state root submitted on the destination domain for the source slot
+func (s *SpectreStore) StateRoot(destinationDomainID uint8, sourceDomainID uint8, slot uint64) ([32]byte, bool, error) {
+	var stateRoot [32]byte
+	v, err := s.db.GetByKey([]byte(fmt.Sprintf("chain:%d:source:%d:slot:%d:stateroot", destinationDomainID, sourceDomainID, slot)))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return stateRoot, false, nil
+		}
+		return stateRoot, false, err
+	}
+
+	copy(stateRoot[:], v)
+	return stateRoot, true, nil
+}
+
+// LatestStateRootSlot returns the latest source slot with a state root submitted on the destination domain
+func (s *SpectreStore) LatestStateRootSlot(destinationDomainID uint8, sourceDomainID uint8) (uint64, error) {
+	return s.latest(fmt.Sprintf("chain:%d:source:%d:stateroot:latest", destinationDomainID, sourceDomainID))
+}
+
+// StoreRotation stores the committee rotation on the destination domain for the source slot
+// and updates the latest rotated slot
+func (s *SpectreStore) StoreRotation(destinationDomainID uint8, sourceDomainID uint8, slot uint64) error {
+	err := s.db.SetByKey(
+		[]byte(fmt.Sprintf("chain:%d:source:%d:slot:%d:rotation", destinationDomainID, sourceDomainID, slot)),
+		[]byte{1},
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.storeLatest(fmt.Sprintf("chain:%d:source:%d:rotation:latest", destinationDomainID, sourceDomainID), slot)
+}
+
+// Rotated returns true if the committee was rotated on the destination domain for the source slot
+func (s *SpectreStore) Rotated(destinationDomainID uint8, sourceDomainID uint8, slot uint64) (bool, error) {
+	_, err := s.db.GetByKey([]byte(fmt.Sprintf("chain:%d:source:%d:slot:%d:rotation", destinationDomainID, sourceDomainID, slot)))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// LatestRotationSlot returns the latest source slot the committee was rotated with on the destination domain
+func (s *SpectreStore) LatestRotationSlot(destinationDomainID uint8, sourceDomainID uint8) (uint64, error) {
+	return s.latest(fmt.Sprintf("chain:%d:source:%d:rotation:latest", destinationDomainID, sourceDomainID))
+}
+
+// StoreBlock stores the latest destination block indexed for Spectre events
+func (s *SpectreStore) StoreBlock(destinationDomainID uint8, block *big.Int) error {
+	return s.db.SetByKey([]byte(fmt.Sprintf("chain:%d:spectre:block", destinationDomainID)), block.Bytes())
+}
+
+// Block returns the latest destination block indexed for Spectre events
+func (s *SpectreStore) Block(destinationDomainID uint8) (*big.Int, error) {
+	v, err := s.db.GetByKey([]byte(fmt.Sprintf("chain:%d:spectre:block", destinationDomainID)))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return big.NewInt(0), nil
+		}
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(v), nil
+}
+
+func (s *SpectreStore) storeLatest(key string, slot uint64) error {
+	latest, err := s.latest(key)
+	if err != nil {
+		return err
+	}
+	if slot <= latest {
+		return nil
+	}
+
+	return s.db.SetByKey([]byte(key), new(big.Int).SetUint64(slot).Bytes())
+}
+
+func (s *SpectreStore) latest(key string) (uint64, error) {
+	v, err := s.db.GetByKey([]byte(key))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return new(big.Int).SetBytes(v).Uint64(), nil
+}
@@ -0,0 +1,77 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/mock"
+	"github.com/sygmaprotocol/spectre-node/store"
+	"github.com/syndtr/goleveldb/leveldb"
+	"go.uber.org/mock/gomock"
+)
+
+type SpectreStoreTestSuite struct {
+	suite.Suite
+	spectreStore         *store.SpectreStore
+	keyValueReaderWriter *mock.MockKeyValueReaderWriter
+}
+
+func TestRunSpectreStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(SpectreStoreTestSuite))
+}
+
+func (s *SpectreStoreTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.keyValueReaderWriter = mock.NewMockKeyValueReaderWriter(gomockController)
+	s.spectreStore = store.NewSpectreStore(s.keyValueReaderWriter)
+}
+
+func (s *SpectreStoreTestSuite) Test_StoreStateRoot_FailedStore() {
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte("chain:2:source:1:slot:100:stateroot"), gomock.Any()).Return(errors.New("error"))
+
+	err := s.spectreStore.StoreStateRoot(2, 1, 100, [32]byte{1})
+
+	s.NotNil(err)
+}
+
+func (s *SpectreStoreTestSuite) Test_StoreStateRoot_NewLatestSlot() {
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte("chain:2:source:1:slot:100:stateroot"), gomock.Any()).Return(nil)
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte("chain:2:source:1:stateroot:latest")).Return([]byte{90}, nil)
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte("chain:2:source:1:stateroot:latest"), []byte{100}).Return(nil)
+
+	err := s.spectreStore.StoreStateRoot(2, 1, 100, [32]byte{1})
+
+	s.Nil(err)
+}
+
+func (s *SpectreStoreTestSuite) Test_StoreStateRoot_OlderSlot() {
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte("chain:2:source:1:slot:80:stateroot"), gomock.Any()).Return(nil)
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte("chain:2:source:1:stateroot:latest")).Return([]byte{90}, nil)
+
+	err := s.spectreStore.StoreStateRoot(2, 1, 80, [32]byte{1})
+
+	s.Nil(err)
+}
+
+func (s *SpectreStoreTestSuite) Test_StateRoot_NotFound() {
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte("chain:2:source:1:slot:100:stateroot")).Return(nil, leveldb.ErrNotFound)
+
+	_, found, err := s.spectreStore.StateRoot(2, 1, 100)
+
+	s.Nil(err)
+	s.False(found)
+}
+
+func (s *SpectreStoreTestSuite) Test_Block_NotFound() {
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte("chain:2:spectre:block")).Return(nil, leveldb.ErrNotFound)
+
+	block, err := s.spectreStore.Block(2)
+
+	s.Nil(err)
+	s.Equal(block, big.NewInt(0))
+}
@@ -0,0 +1,177 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/sygmaprotocol/sygma-core/store"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+type SubmissionStatus string
+
+const (
+	PendingSubmission   SubmissionStatus = "pending"
+	ConfirmedSubmission SubmissionStatus = "confirmed"
+	MissingSubmission   SubmissionStatus = "missing"
+	FailedSubmission    SubmissionStatus = "failed"
+	RetriedSubmission   SubmissionStatus = "retried"
+)
+
+type ReceiptOutcome string
+
+const (
+	SuccessOutcome ReceiptOutcome = "success"
+	RevertOutcome  ReceiptOutcome = "revert"
+	DroppedOutcome ReceiptOutcome = "dropped"
+	// ReplacedOutcome is the outcome of transactions whose nonce was mined under another hash
+	ReplacedOutcome ReceiptOutcome = "replaced"
+)
+
+// Receipt is the final outcome of a submitted transaction once it has enough confirmations
+// or was dropped without being mined
+type Receipt struct {
+	Outcome           ReceiptOutcome
+	BlockNumber       uint64   `json:",omitempty"`
+	GasUsed           uint64   `json:",omitempty"`
+	EffectiveGasPrice *big.Int `json:",omitempty"`
+}
+
+// Submission is a proof submitted to the Spectre contract on the destination domain
+type Submission struct {
+	Type         ProofType
+	SourceDomain uint8
+	Slot         uint64
+	TxHash       string
+	SubmittedAt  time.Time
+	Status       SubmissionStatus
+	// Failure is the kind of failure and Error the decoded error of failed submissions
+	Failure string `json:",omitempty"`
+	Error   string `json:",omitempty"`
+	// MessageType and Data are the message the proof was submitted for, so it can be
+	// delivered again if the transaction reverts or is dropped
+	MessageType string          `json:",omitempty"`
+	Data        json.RawMessage `json:",omitempty"`
+	Receipt     *Receipt        `json:",omitempty"`
+	Retries     int             `json:",omitempty"`
+	// Nonce is the nonce of the submission transaction, recorded while it is pending
+	Nonce *uint64 `json:",omitempty"`
+}
+
+// SubmissionStore tracks proofs submitted to destination domains until they
+// are confirmed by the Spectre contract events
+type SubmissionStore struct {
+	db   store.KeyValueReaderWriter
+	lock sync.Mutex
+}
+
+func NewSubmissionStore(db store.KeyValueReaderWriter) *SubmissionStore {
+	return &SubmissionStore{
+		db: db,
+	}
+}
+
+// StoreSubmission stores the submission and adds it to pending submissions
+// of the destination domain if it is pending. A pending resubmission of a
+// retried submission keeps its retry count.
+func (s *SubmissionStore) StoreSubmission(destinationDomainID uint8, submission *Submission) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := submissionKey(destinationDomainID, submission.Type, submission.SourceDomain, submission.Slot)
+	if submission.Status == PendingSubmission {
+		previous, err := s.submission(key)
+		if err != nil && !errors.Is(err, leveldb.ErrNotFound) {
+			return err
+		}
+		if err == nil && previous.Status == RetriedSubmission {
+			submission.Retries = previous.Retries
+		}
+	}
+
+	data, err := json.Marshal(submission)
+	if err != nil {
+		return err
+	}
+	err = s.db.SetByKey(key, data)
+	if err != nil {
+		return err
+	}
+
+	pending, err := s.pendingSubmissions(destinationDomainID)
+	if err != nil {
+		return err
+	}
+	updatedPending := make([]*Submission, 0, len(pending)+1)
+	for _, p := range pending {
+		if p.Type == submission.Type && p.SourceDomain == submission.SourceDomain && p.Slot == submission.Slot {
+			continue
+		}
+		updatedPending = append(updatedPending, p)
+	}
+	if submission.Status == PendingSubmission {
+		updatedPending = append(updatedPending, submission)
+	}
+
+	data, err = json.Marshal(updatedPending)
+	if err != nil {
+		return err
+	}
+	return s.db.SetByKey(pendingSubmissionsKey(destinationDomainID), data)
+}
+
+// Submission returns the submission of the proof type for the source slot on the destination domain
+func (s *SubmissionStore) Submission(destinationDomainID uint8, proofType ProofType, sourceDomainID uint8, slot uint64) (*Submission, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.submission(submissionKey(destinationDomainID, proofType, sourceDomainID, slot))
+}
+
+func (s *SubmissionStore) submission(key []byte) (*Submission, error) {
+	data, err := s.db.GetByKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	submission := &Submission{}
+	err = json.Unmarshal(data, submission)
+	return submission, err
+}
+
+// PendingSubmissions returns submissions to the destination domain that are not yet confirmed
+func (s *SubmissionStore) PendingSubmissions(destinationDomainID uint8) ([]*Submission, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.pendingSubmissions(destinationDomainID)
+}
+
+func (s *SubmissionStore) pendingSubmissions(destinationDomainID uint8) ([]*Submission, error) {
+	data, err := s.db.GetByKey(pendingSubmissionsKey(destinationDomainID))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return []*Submission{}, nil
+		}
+		return nil, err
+	}
+
+	var submissions []*Submission
+	err = json.Unmarshal(data, &submissions)
+	return submissions, err
+}
+
+func submissionKey(destinationDomainID uint8, proofType ProofType, sourceDomainID uint8, slot uint64) []byte {
+	return []byte(fmt.Sprintf("chain:%d:source:%d:slot:%d:%s:submission", destinationDomainID, sourceDomainID, slot, proofType))
+}
+
+func pendingSubmissionsKey(destinationDomainID uint8) []byte {
+	return []byte(fmt.Sprintf("chain:%d:submissions:pending", destinationDomainID))
+}
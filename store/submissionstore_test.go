@@ -0,0 +1,156 @@
+// The Licensed Work is (c) 2023 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/spectre-node/mock"
+	"github.com/sygmaprotocol/spectre-node/store"
+	"github.com/syndtr/goleveldb/leveldb"
+	"go.uber.org/mock/gomock"
+)
+
+type SubmissionStoreTestSuite struct {
+	suite.Suite
+	submissionStore      *store.SubmissionStore
+	keyValueReaderWriter *mock.MockKeyValueReaderWriter
+	db                   map[string][]byte
+}
+
+func TestRunSubmissionStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(SubmissionStoreTestSuite))
+}
+
+func (s *SubmissionStoreTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.keyValueReaderWriter = mock.NewMockKeyValueReaderWriter(gomockController)
+	s.submissionStore = store.NewSubmissionStore(s.keyValueReaderWriter)
+	s.db = make(map[string][]byte)
+}
+
+func (s *SubmissionStoreTestSuite) mockDB() {
+	s.keyValueReaderWriter.EXPECT().GetByKey(gomock.Any()).DoAndReturn(func(key []byte) ([]byte, error) {
+		value, ok := s.db[string(key)]
+		if !ok {
+			return nil, leveldb.ErrNotFound
+		}
+		return value, nil
+	}).AnyTimes()
+	s.keyValueReaderWriter.EXPECT().SetByKey(gomock.Any(), gomock.Any()).DoAndReturn(func(key []byte, value []byte) error {
+		s.db[string(key)] = value
+		return nil
+	}).AnyTimes()
+}
+
+func (s *SubmissionStoreTestSuite) Test_StoreSubmission_FailedStore() {
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte("chain:2:source:1:slot:100:step:submission"), gomock.Any()).Return(errors.New("error"))
+
+	err := s.submissionStore.StoreSubmission(2, &store.Submission{Type: store.StepProofType, SourceDomain: 1, Slot: 100, Status: store.ConfirmedSubmission})
+
+	s.NotNil(err)
+}
+
+func (s *SubmissionStoreTestSuite) Test_Submission_NotFound() {
+	s.mockDB()
+
+	_, err := s.submissionStore.Submission(2, store.StepProofType, 1, 100)
+
+	s.True(errors.Is(err, leveldb.ErrNotFound))
+}
+
+func (s *SubmissionStoreTestSuite) Test_PendingSubmissions_NoSubmissions() {
+	s.mockDB()
+
+	pending, err := s.submissionStore.PendingSubmissions(2)
+
+	s.Nil(err)
+	s.Equal(len(pending), 0)
+}
+
+func (s *SubmissionStoreTestSuite) Test_PendingSubmissions_FailedFetch() {
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte("chain:2:submissions:pending")).Return(nil, errors.New("error"))
+
+	_, err := s.submissionStore.PendingSubmissions(2)
+
+	s.NotNil(err)
+}
+
+func (s *SubmissionStoreTestSuite) Test_StoreSubmission_PendingAndConfirmed() {
+	s.mockDB()
+	submission := &store.Submission{Type: store.StepProofType, SourceDomain: 1, Slot: 100, TxHash: "0x1", Status: store.PendingSubmission}
+
+	err := s.submissionStore.StoreSubmission(2, submission)
+	s.Nil(err)
+	pending, err := s.submissionStore.PendingSubmissions(2)
+	s.Nil(err)
+	s.Equal(len(pending), 1)
+
+	submission.Status = store.ConfirmedSubmission
+	submission.Receipt = &store.Receipt{Outcome: store.SuccessOutcome, BlockNumber: 10, GasUsed: 21000}
+	err = s.submissionStore.StoreSubmission(2, submission)
+	s.Nil(err)
+
+	pending, err = s.submissionStore.PendingSubmissions(2)
+	s.Nil(err)
+	s.Equal(len(pending), 0)
+	stored, err := s.submissionStore.Submission(2, store.StepProofType, 1, 100)
+	s.Nil(err)
+	s.Equal(stored.Status, store.ConfirmedSubmission)
+	s.Equal(stored.Receipt.GasUsed, uint64(21000))
+}
+
+func (s *SubmissionStoreTestSuite) Test_StoreSubmission_MissingRemovedFromPending() {
+	s.mockDB()
+	for slot := uint64(100); slot <= 101; slot++ {
+		err := s.submissionStore.StoreSubmission(2, &store.Submission{Type: store.StepProofType, SourceDomain: 1, Slot: slot, Status: store.PendingSubmission})
+		s.Nil(err)
+	}
+
+	err := s.submissionStore.StoreSubmission(2, &store.Submission{Type: store.StepProofType, SourceDomain: 1, Slot: 100, Status: store.MissingSubmission})
+	s.Nil(err)
+
+	pending, err := s.submissionStore.PendingSubmissions(2)
+	s.Nil(err)
+	s.Equal(len(pending), 1)
+	s.Equal(pending[0].Slot, uint64(101))
+}
+
+func (s *SubmissionStoreTestSuite) Test_StoreSubmission_ResubmissionKeepsRetries() {
+	s.mockDB()
+	err := s.submissionStore.StoreSubmission(2, &store.Submission{
+		Type: store.RotateProofType, SourceDomain: 1, Slot: 100, TxHash: "0x1", Status: store.RetriedSubmission, Retries: 2,
+	})
+	s.Nil(err)
+
+	err = s.submissionStore.StoreSubmission(2, &store.Submission{
+		Type: store.RotateProofType, SourceDomain: 1, Slot: 100, TxHash: "0x2", Status: store.PendingSubmission,
+	})
+	s.Nil(err)
+
+	pending, err := s.submissionStore.PendingSubmissions(2)
+	s.Nil(err)
+	s.Equal(len(pending), 1)
+	s.Equal(pending[0].TxHash, "0x2")
+	s.Equal(pending[0].Retries, 2)
+}
+
+func (s *SubmissionStoreTestSuite) Test_StoreSubmission_NewSubmissionWithoutRetries() {
+	s.mockDB()
+	err := s.submissionStore.StoreSubmission(2, &store.Submission{
+		Type: store.RotateProofType, SourceDomain: 1, Slot: 100, TxHash: "0x1", Status: store.ConfirmedSubmission, Retries: 2,
+	})
+	s.Nil(err)
+
+	err = s.submissionStore.StoreSubmission(2, &store.Submission{
+		Type: store.RotateProofType, SourceDomain: 1, Slot: 100, TxHash: "0x2", Status: store.PendingSubmission,
+	})
+	s.Nil(err)
+
+	stored, err := s.submissionStore.Submission(2, store.RotateProofType, 1, 100)
+	s.Nil(err)
+	s.Equal(stored.Retries, 0)
+}